@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Jenis komentar pada thread prestasi
+const (
+	CommentKindComment       = "comment"
+	CommentKindClarification = "clarification" // dosen meminta klarifikasi tanpa menolak
+	CommentKindRejection     = "rejection"     // dicatat otomatis saat prestasi ditolak
+)
+
+// AchievementComment adalah satu komentar pada thread prestasi
+type AchievementComment struct {
+	ID               string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440020"`
+	AchievementRefID string     `json:"achievement_ref_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ParentID         *string    `json:"parent_id" example:"550e8400-e29b-41d4-a716-446655440019"`
+	AuthorID         string     `json:"author_id" example:"uuid-user-456"`
+	AuthorName       string     `json:"author_name" example:"Dr. Budi Santoso"`
+	Kind             string     `json:"kind" example:"clarification"`
+	Body             string     `json:"body" example:"Mohon lampirkan surat keterangan dari panitia."`
+	AttachmentIDs    []string   `json:"attachment_ids" example:"0b9f6c1e-4c7a-4b7e-9a55-2d7f1c3e8a10"`
+	ResolvedAt       *time.Time `json:"resolved_at" swaggertype:"string"`
+	ResolvedBy       *string    `json:"resolved_by" example:"uuid-user-456"`
+	CreatedAt        time.Time  `json:"created_at" swaggerignore:"true"`
+
+	Replies []AchievementComment `json:"replies,omitempty"`
+}
+
+// AchievementCommentRequest digunakan untuk menulis komentar atau balasan
+type AchievementCommentRequest struct {
+	Body string `json:"body" example:"Surat keterangan sudah saya lampirkan."`
+	// Kosongkan untuk komentar baru; isi untuk membalas komentar lain
+	ParentID *string `json:"parent_id" example:"550e8400-e29b-41d4-a716-446655440019"`
+	// comment (default) | clarification (khusus dosen/verifikator)
+	Kind string `json:"kind" example:"comment"`
+	// ID attachment prestasi yang dirujuk komentar
+	AttachmentIDs []string `json:"attachment_ids" example:"0b9f6c1e-4c7a-4b7e-9a55-2d7f1c3e8a10"`
+}
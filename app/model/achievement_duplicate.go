@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// DuplicateMatch adalah satu prestasi lain yang kemungkinan sama
+type DuplicateMatch struct {
+	ReferenceID   string `json:"reference_id" bson:"referenceId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AchievementID string `json:"achievement_id" bson:"achievementId" example:"65a1b2c3d4e5f6a7b8c9d0e1"`
+	StudentID     string `json:"student_id" bson:"studentId" example:"uuid-student-123"`
+	Title         string `json:"title" bson:"title" example:"Juara 1 Hackathon Nasional 2025"`
+	Status        string `json:"status" bson:"status" example:"verified"`
+	// Skor kemiripan 0..1
+	Score float64 `json:"score" bson:"score" example:"0.92"`
+	// Alasan kecocokan: same_attachment, similar_title, same_event, same_date
+	Reasons []string `json:"reasons" bson:"reasons" example:"same_attachment,similar_title"`
+	// Path detail prestasi yang cocok
+	URL string `json:"url" bson:"url" example:"/achievements/550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// DuplicateCheck adalah hasil pemeriksaan duplikat yang disimpan di dokumen prestasi
+type DuplicateCheck struct {
+	CheckedAt time.Time        `json:"checked_at" bson:"checkedAt"`
+	Matches   []DuplicateMatch `json:"matches" bson:"matches"`
+}
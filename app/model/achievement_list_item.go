@@ -0,0 +1,24 @@
+package model
+
+// Jenis masalah saat menggabungkan reference Postgre dengan dokumen MongoDB
+const (
+	AchievementIssueMissing   = "missing"    // dokumen tidak ada di MongoDB
+	AchievementIssueCorrupt   = "corrupt"    // dokumen ada tapi gagal di-decode
+	AchievementIssueInvalidID = "invalid_id" // mongo_achievement_id bukan ObjectID
+)
+
+// AchievementIssue melaporkan reference yang dokumen MongoDB-nya tidak bisa dimuat
+type AchievementIssue struct {
+	ReferenceID        string `json:"reference_id" example:"uuid-ref-123"`
+	MongoAchievementID string `json:"mongo_achievement_id" example:"6751a2f0c1d2e3f4a5b6c7d8"`
+	Problem            string `json:"problem" example:"missing"`
+	Detail             string `json:"detail,omitempty"`
+}
+
+// AchievementListItem adalah satu baris list prestasi. Urutannya mengikuti
+// reference; Achievement bernilai null bila Issue terisi.
+type AchievementListItem struct {
+	Reference   AchievementReference `json:"reference"`
+	Achievement *AchievementMongo    `json:"achievement"`
+	Issue       *AchievementIssue    `json:"issue,omitempty"`
+}
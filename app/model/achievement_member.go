@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// Peran anggota tim prestasi
+const (
+	MemberRoleLeader = "ketua"
+	MemberRoleMember = "anggota"
+)
+
+// Status konfirmasi keikutsertaan anggota
+const (
+	MemberPending   = "pending"
+	MemberConfirmed = "confirmed"
+	MemberDeclined  = "declined"
+)
+
+// AchievementMember adalah satu mahasiswa dalam tim sebuah prestasi
+type AchievementMember struct {
+	AchievementRefID string     `json:"achievement_ref_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StudentID        string     `json:"student_id" example:"uuid-student-123"`
+	StudentNumber    string     `json:"student_number" example:"434231058"`
+	FullName         string     `json:"full_name" example:"Muhammad Farid"`
+	Role             string     `json:"role" example:"ketua"`
+	Status           string     `json:"status" example:"confirmed"`
+	RespondedAt      *time.Time `json:"responded_at" swaggertype:"string"`
+	Points           int        `json:"points" example:"75"`
+	VerifiedAt       *time.Time `json:"verified_at" swaggertype:"string"`
+	VerifiedBy       *string    `json:"verified_by" example:"uuid-user-456"`
+	CreatedAt        time.Time  `json:"created_at" swaggerignore:"true"`
+}
+
+// AchievementMemberInput adalah anggota tim pada request create/update
+type AchievementMemberInput struct {
+	StudentID string `json:"student_id" example:"uuid-student-456"`
+	// ketua | anggota (default anggota)
+	Role string `json:"role" example:"anggota"`
+}
+
+// AchievementMembersRequest mengganti daftar anggota tim (hanya saat draft/rejected)
+type AchievementMembersRequest struct {
+	Members []AchievementMemberInput `json:"members"`
+}
+
+// AchievementMemberResponseRequest digunakan anggota untuk mengonfirmasi atau menolak keikutsertaan
+type AchievementMemberResponseRequest struct {
+	// confirm | decline
+	Decision string `json:"decision" example:"confirm"`
+}
@@ -13,9 +13,13 @@ type AchievementMongo struct {
     Title           string                 `json:"title" bson:"title" example:"Juara 1 Hackathon Nasional 2025"`
     Description     string                 `json:"description" bson:"description" example:"Memenangkan kompetisi hackathon tingkat nasional"`
     Details         map[string]interface{} `json:"details" bson:"details" swaggertype:"object"` 
-    Attachments     []string               `json:"attachments" bson:"attachments" example:"sertifikat_juara.pdf"`
+    Attachments     []Attachment           `json:"attachments" bson:"attachments"`
     Tags            []string               `json:"tags" bson:"tags" example:"teknologi,programming"`
     Points          int                    `json:"points" bson:"points" example:"100"`
     CreatedAt       time.Time              `json:"createdAt" bson:"createdAt" swaggerignore:"true"`
     UpdatedAt       time.Time              `json:"updatedAt" bson:"updatedAt" swaggerignore:"true"`
+    // DedupKeys adalah kunci indeks global deteksi duplikat (judul, nama kegiatan + tanggal, hash lampiran)
+    DedupKeys       []string               `json:"-" bson:"dedupKeys,omitempty"`
+    // Duplicates adalah hasil pemeriksaan duplikat terakhir (saat create/submit) untuk dosen verifikator
+    Duplicates      *DuplicateCheck        `json:"duplicates,omitempty" bson:"duplicates,omitempty"`
 }
\ No newline at end of file
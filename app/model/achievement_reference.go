@@ -12,6 +12,11 @@ type AchievementReference struct {
 	VerifiedAt         *time.Time `json:"verified_at" swaggerignore:"true"`
 	VerifiedBy         *string    `json:"verified_by" example:"uuid-lecturer-456"`
 	RejectionNote      *string    `json:"rejection_note" example:"Bukti sertifikat tidak terbaca atau buram"`
+	Points             int        `json:"points" example:"150"`
+	ScoringRuleVersion *int       `json:"scoring_rule_version" example:"1"`
+	ReviewerID         *string    `json:"reviewer_id,omitempty" example:"uuid-lecturer-456"` // diisi bila pengajuan tetap ditinjau dosen wali lama
 	CreatedAt          time.Time  `json:"created_at" swaggerignore:"true"`
 	UpdatedAt          time.Time  `json:"updated_at" swaggerignore:"true"`
+	// Members hanya diisi saat membuat prestasi tim atau pada response detail
+	Members []AchievementMember `json:"members,omitempty"`
 }
\ No newline at end of file
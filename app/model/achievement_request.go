@@ -2,13 +2,16 @@ package model
 
 // AchievementCreateRequest digunakan oleh Mahasiswa untuk input prestasi baru (FR-003)
 type AchievementCreateRequest struct {
-	StudentID       string                 `json:"student_id" example:"uuid-student-123"`
+	// ID mahasiswa pemilik; hanya untuk Admin (achievement:create:all). Mahasiswa
+	// selalu membuat prestasi atas namanya sendiri sesuai token.
+	OnBehalfOf      string                 `json:"on_behalf_of,omitempty" example:"uuid-student-123"`
 	AchievementType string                 `json:"achievement_type" example:"competition"`
 	Title           string                 `json:"title" example:"Juara 1 Hackathon Nasional 2025"`
 	Description     string                 `json:"description" example:"Memenangkan kompetisi hackathon tingkat nasional"`
 	Details         map[string]interface{} `json:"details" swaggertype:"object" example:"competitionName:Indonesia Tech Innovation Challenge,rank:1"`
 	Tags            []string               `json:"tags" example:"teknologi,programming"`
-	Points          int                    `json:"points" example:"100"`
+	// Anggota tim selain pembuat (opsional). Pembuat otomatis menjadi ketua bila tidak ada ketua lain.
+	Members []AchievementMemberInput `json:"members"`
 }
 
 // AchievementUpdateRequest digunakan untuk memperbarui prestasi yang masih berstatus 'draft' (FR-003)
@@ -17,11 +20,39 @@ type AchievementUpdateRequest struct {
 	Description string                 `json:"description" example:"Update deskripsi prestasi"`
 	Details     map[string]interface{} `json:"details" swaggertype:"object"`
 	Tags        []string               `json:"tags" example:"teknologi"`
-	Points      int                    `json:"points" example:"100"`
 }
 
 // AchievementRejectRequest digunakan oleh Dosen Wali untuk memberikan alasan penolakan (FR-008)
 type AchievementRejectRequest struct {
 	// Alasan mengapa prestasi ditolak
 	Note string `json:"note" example:"Sertifikat tidak valid atau kadaluarsa"`
-}
\ No newline at end of file
+}
+
+// AchievementBatchReviewRequest digunakan Dosen Wali untuk memverifikasi/menolak banyak prestasi sekaligus.
+// Decision & Note di level atas menjadi default untuk item yang tidak mengisinya.
+type AchievementBatchReviewRequest struct {
+	// verify | reject
+	Decision string                       `json:"decision" example:"verify"`
+	Note     string                       `json:"note" example:"Bukti lengkap"`
+	Items    []AchievementBatchReviewItem `json:"items"`
+}
+
+// AchievementBatchReviewItem adalah keputusan untuk satu prestasi
+type AchievementBatchReviewItem struct {
+	ID string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// verify | reject (kosong = ikut decision di level atas)
+	Decision string `json:"decision" example:"reject"`
+	// Wajib untuk reject (boleh dari note di level atas)
+	Note string `json:"note" example:"Sertifikat tidak terbaca"`
+}
+
+// AchievementBatchReviewResult adalah hasil per item batch review
+type AchievementBatchReviewResult struct {
+	ID       string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Decision string `json:"decision" example:"verify"`
+	Success  bool   `json:"success" example:"true"`
+	// Status HTTP yang setara dengan endpoint tunggal
+	Status int    `json:"status" example:"200"`
+	Error  string `json:"error,omitempty" example:"Mahasiswa bukan bimbingan anda"`
+	Points *int   `json:"points,omitempty" example:"75"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AchievementReview represents one review round (verify/reject) stored in MongoDB,
+// including a snapshot of the achievement document as it was reviewed
+type AchievementReview struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty" swaggerignore:"true"`
+	AchievementID string             `json:"achievementId" bson:"achievementId" example:"654321098765432109876543"`
+	ReferenceID   string             `json:"referenceId" bson:"referenceId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Round         int                `json:"round" bson:"round" example:"1"`
+	Decision      string             `json:"decision" bson:"decision" example:"rejected"`
+	ReviewerID    string             `json:"reviewerId" bson:"reviewerId" example:"uuid-user-456"`
+	Note          string             `json:"note" bson:"note" example:"Sertifikat buram"`
+	Snapshot      AchievementMongo   `json:"snapshot" bson:"snapshot"`
+	ReviewedAt    time.Time          `json:"reviewedAt" bson:"reviewedAt" swaggerignore:"true"`
+}
+
+// AchievementFieldChange menggambarkan satu field yang berubah sejak review terakhir
+type AchievementFieldChange struct {
+	Field  string      `json:"field" example:"details.rank"`
+	Before interface{} `json:"before" swaggertype:"string" example:"2"`
+	After  interface{} `json:"after" swaggertype:"string" example:"1"`
+}
+
+// AchievementReviseRequest digunakan mahasiswa untuk membuka kembali prestasi yang ditolak
+type AchievementReviseRequest struct {
+	Note string `json:"note" example:"Sertifikat sudah diganti dengan scan yang lebih jelas"`
+}
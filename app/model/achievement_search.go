@@ -0,0 +1,24 @@
+package model
+
+// AchievementSearchHit adalah satu hasil pencarian prestasi, urut menurut relevansi
+type AchievementSearchHit struct {
+	Reference   AchievementReference `json:"reference"`
+	Achievement *AchievementMongo    `json:"achievement"`
+	// Skor relevansi MongoDB text search
+	Score float64 `json:"score" example:"2.75"`
+	// Potongan teks per field dengan kata yang cocok dibungkus <mark>…</mark> (sudah di-escape HTML)
+	Highlights map[string]string `json:"highlights"`
+}
+
+// FacetCount adalah jumlah hasil untuk satu nilai facet
+type FacetCount struct {
+	Value string `json:"value" example:"competition"`
+	Count int    `json:"count" example:"12"`
+}
+
+// SearchFacets adalah jumlah hasil pencarian per jenis, tag dan status
+type SearchFacets struct {
+	Types    []FacetCount `json:"types"`
+	Tags     []FacetCount `json:"tags"`
+	Statuses []FacetCount `json:"statuses"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Status prestasi pada achievement_references
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusVerified  = "verified"
+	StatusRejected  = "rejected"
+	StatusDeleted   = "deleted"
+)
+
+// AchievementStatusHistory represents one append-only status transition of an achievement
+type AchievementStatusHistory struct {
+	ID               string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	AchievementRefID string    `json:"achievement_ref_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FromStatus       *string   `json:"from_status" example:"submitted"`
+	ToStatus         string    `json:"to_status" example:"rejected"`
+	ActorID          *string   `json:"actor_id" example:"uuid-user-456"`
+	Note             *string   `json:"note" example:"Bukti sertifikat tidak terbaca"`
+	CreatedAt        time.Time `json:"created_at" swaggerignore:"true"`
+}
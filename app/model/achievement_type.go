@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tipe data yang didukung untuk field pada details prestasi
+const (
+	FieldTypeString  = "string"
+	FieldTypeNumber  = "number"
+	FieldTypeInteger = "integer"
+	FieldTypeBoolean = "boolean"
+	FieldTypeDate    = "date"
+	FieldTypeArray   = "array"
+)
+
+// AchievementType adalah skema details untuk satu jenis prestasi (disimpan di MongoDB)
+type AchievementType struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty" swaggerignore:"true"`
+	Code        string             `json:"code" bson:"code" example:"competition"`
+	Name        string             `json:"name" bson:"name" example:"Kompetisi"`
+	Description string             `json:"description" bson:"description" example:"Lomba atau kejuaraan akademik maupun non-akademik"`
+	Fields      []AchievementField `json:"fields" bson:"fields"`
+	AllowExtra  bool               `json:"allowExtra" bson:"allowExtra" example:"false"`
+	Active      bool               `json:"active" bson:"active" example:"true"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt" swaggerignore:"true"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt" swaggerignore:"true"`
+}
+
+// AchievementField mendefinisikan satu key di details beserta batasannya
+type AchievementField struct {
+	Name      string   `json:"name" bson:"name" example:"rank"`
+	Label     string   `json:"label" bson:"label" example:"Peringkat"`
+	Type      string   `json:"type" bson:"type" example:"integer"`
+	Required  bool     `json:"required" bson:"required" example:"true"`
+	Enum      []string `json:"enum,omitempty" bson:"enum,omitempty" example:"international,national,regional,campus"`
+	Min       *float64 `json:"min,omitempty" bson:"min,omitempty" example:"1"`
+	Max       *float64 `json:"max,omitempty" bson:"max,omitempty" example:"100"`
+	MaxLength int      `json:"maxLength,omitempty" bson:"maxLength,omitempty" example:"200"`
+	Pattern   string   `json:"pattern,omitempty" bson:"pattern,omitempty" example:"^[0-9]{4}-[0-9]{4}$"`
+}
+
+// AchievementTypeRequest digunakan admin untuk membuat / mengubah skema jenis prestasi
+type AchievementTypeRequest struct {
+	Code        string             `json:"code" example:"competition"`
+	Name        string             `json:"name" example:"Kompetisi"`
+	Description string             `json:"description" example:"Lomba atau kejuaraan akademik maupun non-akademik"`
+	Fields      []AchievementField `json:"fields"`
+	AllowExtra  bool               `json:"allow_extra" example:"false"`
+	Active      *bool              `json:"active" example:"true"`
+}
+
+// FieldError menjelaskan kesalahan validasi pada satu field
+type FieldError struct {
+	Field   string `json:"field" example:"details.rank"`
+	Message string `json:"message" example:"harus bilangan bulat"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// Pilihan untuk pengajuan prestasi (submitted) yang belum ditinjau saat dosen wali diganti
+const (
+	PendingReviewsTransfer = "transfer" // ikut pindah ke dosen wali baru
+	PendingReviewsKeep     = "keep"     // tetap ditinjau dosen wali lama
+)
+
+// AdvisorAssignment adalah satu periode bimbingan dosen wali. Penugasan yang
+// masih berlaku tidak memiliki EffectiveTo.
+type AdvisorAssignment struct {
+	ID            string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440010"`
+	StudentID     string     `json:"student_id" example:"uuid-student-123"`
+	LecturerID    string     `json:"lecturer_id" example:"uuid-lecturer-456"`
+	EffectiveFrom time.Time  `json:"effective_from" example:"2025-08-01T00:00:00Z"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+	AssignedBy    *string    `json:"assigned_by,omitempty" example:"uuid-user-admin"`
+	Reason        *string    `json:"reason,omitempty" example:"Dosen wali sebelumnya pensiun"`
+	// Pilihan transfer / keep saat penugasan ini menggantikan dosen wali sebelumnya
+	PendingReviews *string   `json:"pending_reviews,omitempty" example:"transfer"`
+	CreatedAt      time.Time `json:"created_at" swaggerignore:"true"`
+}
+
+// AdvisorAssignRequest digunakan untuk mengganti dosen wali satu mahasiswa
+type AdvisorAssignRequest struct {
+	// ID tabel lecturers; harus ada dan masih aktif
+	AdvisorID string `json:"advisor_id" example:"uuid-lecturer-456"`
+	// transfer | keep, wajib bila masih ada pengajuan yang belum ditinjau
+	PendingReviews string `json:"pending_reviews,omitempty" example:"transfer"`
+	// Tanggal mulai berlaku (YYYY-MM-DD), default hari ini; tidak boleh di masa depan
+	EffectiveDate string `json:"effective_date,omitempty" example:"2025-08-01"`
+	Reason        string `json:"reason,omitempty" example:"Permintaan program studi"`
+}
+
+// AdvisorReassignRequest digunakan untuk memindahkan mahasiswa bimbingan satu
+// dosen ke dosen lain sekaligus (mis. dosen pensiun atau pindah)
+type AdvisorReassignRequest struct {
+	ToAdvisorID string `json:"to_advisor_id" example:"uuid-lecturer-789"`
+	// Kosong berarti semua mahasiswa aktif bimbingan dosen ini
+	StudentIDs     []string `json:"student_ids,omitempty"`
+	PendingReviews string   `json:"pending_reviews,omitempty" example:"transfer"`
+	EffectiveDate  string   `json:"effective_date,omitempty" example:"2025-08-01"`
+	Reason         string   `json:"reason,omitempty" example:"Dosen wali pensiun"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// Attachment adalah metadata satu file bukti prestasi. Isi file ada di
+// storage.Backend pada Key; nama file dari klien hanya disimpan sebagai FileName.
+type Attachment struct {
+	ID         string    `json:"id" bson:"id" example:"0b9f6c1e-4c7a-4b7e-9a55-2d7f1c3e8a10"`
+	Key        string    `json:"-" bson:"key"`
+	FileName   string    `json:"file_name" bson:"fileName" example:"sertifikat_juara.pdf"`
+	Size       int64     `json:"size" bson:"size" example:"248913"`
+	MimeType   string    `json:"mime_type" bson:"mimeType" example:"application/pdf"`
+	SHA256     string    `json:"sha256" bson:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	UploadedBy string    `json:"uploaded_by" bson:"uploadedBy" example:"uuid-user-123"`
+	UploadedAt time.Time `json:"uploaded_at" bson:"uploadedAt"`
+	// Preview adalah thumbnail gambar / halaman pertama PDF, nil bila tidak tersedia
+	Preview *AttachmentPreview `json:"preview,omitempty" bson:"preview,omitempty"`
+	// Legacy menandai data lama yang hanya berupa nama file di ./uploads
+	Legacy bool `json:"legacy,omitempty" bson:"-"`
+	// URL unduhan bertanda tangan, hanya diisi pada response
+	URL string `json:"url,omitempty" bson:"-"`
+}
+
+// AttachmentPreview adalah gambar PNG kecil yang disimpan di samping file asli
+type AttachmentPreview struct {
+	Key      string `json:"-" bson:"key"`
+	Width    int    `json:"width" bson:"width" example:"320"`
+	Height   int    `json:"height" bson:"height" example:"226"`
+	MimeType string `json:"mime_type" bson:"mimeType" example:"image/png"`
+	Size     int64  `json:"size" bson:"size" example:"48211"`
+	// URL unduhan bertanda tangan, hanya diisi pada response
+	URL string `json:"url,omitempty" bson:"-"`
+}
+
+// UnmarshalBSONValue menerima format lama (string nama file) selain dokumen metadata
+func (a *Attachment) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.String:
+		var name string
+		if err := bson.UnmarshalValue(t, data, &name); err != nil {
+			return err
+		}
+		*a = Attachment{ID: name, Key: name, FileName: name, Legacy: true}
+		return nil
+
+	case bsontype.EmbeddedDocument:
+		type plain Attachment
+		var p plain
+		if err := bson.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		*a = Attachment(p)
+		return nil
+	}
+	return fmt.Errorf("attachment: tipe BSON %s tidak didukung", t)
+}
@@ -4,10 +4,26 @@ import "time"
 
 // Lecturer represents the lecturer profile data in PostgreSQL
 type Lecturer struct {
-	ID         string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440001"`
-	UserID     string    `json:"user_id" example:"uuid-user-456"`
+	ID     string `json:"id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	UserID string `json:"user_id" example:"uuid-user-456"`
 	// NIDN atau Nomor Induk Dosen
-	LecturerID string    `json:"lecturer_id" example:"198801012015011001"`
-	Department string    `json:"department" example:"Teknik Informatika"`
-	CreatedAt  time.Time `json:"created_at" swaggerignore:"true"`
-}
\ No newline at end of file
+	LecturerID string `json:"lecturer_id" example:"198801012015011001"`
+	Department string `json:"department" example:"Teknik Informatika"`
+	// Terisi bila profil dinonaktifkan (mis. pensiun atau pindah)
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" swaggerignore:"true"`
+}
+
+// LecturerProfileRequest adalah data profil dosen, dipakai saat membuat atau
+// mengubah profil maupun bersamaan dengan user baru (UserCreateRequest.Lecturer)
+type LecturerProfileRequest struct {
+	// NIDN atau Nomor Induk Dosen
+	LecturerID string `json:"lecturer_id" example:"198801012015011001"`
+	Department string `json:"department" example:"Teknik Informatika"`
+}
+
+// LecturerCreateRequest digunakan Admin untuk membuat profil dosen bagi user yang sudah ada
+type LecturerCreateRequest struct {
+	UserID string `json:"user_id" example:"uuid-user-456"`
+	LecturerProfileRequest
+}
@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// ListQuery adalah opsi paginasi & sorting yang sama untuk semua endpoint list.
+// Jika Cursor diisi maka paginasi memakai keyset dan Page diabaikan.
+type ListQuery struct {
+	Page   int
+	Limit  int
+	Cursor string
+	Sort   string
+	Order  string // asc | desc
+}
+
+// PageInfo dikembalikan di field "pagination" pada response list
+type PageInfo struct {
+	Total      int    `json:"total" example:"134"`
+	Limit      int    `json:"limit" example:"20"`
+	Page       int    `json:"page,omitempty" example:"1"`
+	Sort       string `json:"sort" example:"created_at"`
+	Order      string `json:"order" example:"desc"`
+	HasMore    bool   `json:"has_more" example:"true"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJzIjoiY3JlYXRlZF9hdCIsInYiOiIyMDI1LTAxLTAxVDAwOjAwOjAwWiIsImlkIjoiLi4uIn0"`
+}
+
+// AchievementFilter membatasi list prestasi. Field scope (StudentID, AdvisorID,
+// Department) diisi oleh service sesuai permission, bukan dari query string.
+type AchievementFilter struct {
+	StudentID  string
+	AdvisorID  string
+	Department string
+
+	Statuses       []string
+	IncludeDeleted bool
+	// MongoIDs non-nil berarti hasil filter Mongo (type/tags) sudah dihitung
+	MongoIDs     []string
+	From         *time.Time
+	To           *time.Time
+	ProgramStudy string
+	AcademicYear string
+}
+
+// UserFilter membatasi list user
+type UserFilter struct {
+	RoleID   string
+	IsActive *bool
+	Search   string
+}
+
+// StudentFilter membatasi list mahasiswa
+type StudentFilter struct {
+	AdvisorID    string
+	ProgramStudy string
+	AcademicYear string
+	IsActive     *bool
+	Search       string
+}
+
+// LecturerFilter membatasi list dosen
+type LecturerFilter struct {
+	Department string
+	IsActive   *bool
+	Search     string
+}
@@ -7,4 +7,13 @@ type Permission struct {
 	Resource    string `json:"resource" example:"achievement"`
 	Action      string `json:"action" example:"create"`
 	Description string `json:"description" example:"Memberikan akses untuk membuat laporan prestasi baru"`
-}
\ No newline at end of file
+}
+
+// PermissionRequest digunakan oleh Admin untuk membuat atau mengubah permission.
+// Jika Name kosong, nama dibentuk dari "resource:action".
+type PermissionRequest struct {
+	Name        string `json:"name" example:"achievement:create"`
+	Resource    string `json:"resource" example:"achievement"`
+	Action      string `json:"action" example:"create"`
+	Description string `json:"description" example:"Memberikan akses untuk membuat laporan prestasi baru"`
+}
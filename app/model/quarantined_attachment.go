@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// QuarantinedAttachment mencatat file yang ditolak pemindai. Filenya disimpan
+// di storage dengan prefix quarantine/ dan tidak muncul di attachment prestasi.
+type QuarantinedAttachment struct {
+	Attachment         Attachment `json:"attachment" bson:"attachment"`
+	ReferenceID        string     `json:"reference_id" bson:"referenceId"`
+	MongoAchievementID string     `json:"mongo_achievement_id" bson:"mongoAchievementId"`
+	Reason             string     `json:"reason" bson:"reason"`
+	CreatedAt          time.Time  `json:"created_at" bson:"createdAt"`
+}
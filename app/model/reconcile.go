@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// ReconcileOrphanDocument adalah dokumen MongoDB tanpa achievement_references
+type ReconcileOrphanDocument struct {
+	MongoAchievementID string    `json:"mongo_achievement_id" example:"6751a2f0c1d2e3f4a5b6c7d8"`
+	StudentID          string    `json:"student_id" example:"uuid-student-123"`
+	Title              string    `json:"title" example:"Juara 1 Hackathon Nasional 2025"`
+	CreatedAt          time.Time `json:"created_at"`
+	Repaired           bool      `json:"repaired"`
+	Error              string    `json:"error,omitempty"`
+}
+
+// ReconcileDanglingReference adalah reference yang dokumen MongoDB-nya tidak ada
+type ReconcileDanglingReference struct {
+	ReferenceID        string `json:"reference_id" example:"uuid-ref-123"`
+	MongoAchievementID string `json:"mongo_achievement_id" example:"6751a2f0c1d2e3f4a5b6c7d8"`
+	StudentID          string `json:"student_id" example:"uuid-student-123"`
+	Status             string `json:"status" example:"draft"`
+	Repaired           bool   `json:"repaired"`
+	Error              string `json:"error,omitempty"`
+}
+
+// ReconcileStudentMismatch adalah reference yang pemiliknya berbeda dengan
+// studentId dokumen MongoDB. Postgre menjadi acuan saat diperbaiki.
+type ReconcileStudentMismatch struct {
+	ReferenceID        string `json:"reference_id" example:"uuid-ref-123"`
+	MongoAchievementID string `json:"mongo_achievement_id" example:"6751a2f0c1d2e3f4a5b6c7d8"`
+	ReferenceStudentID string `json:"reference_student_id" example:"uuid-student-123"`
+	DocumentStudentID  string `json:"document_student_id" example:"uuid-student-456"`
+	Repaired           bool   `json:"repaired"`
+	Error              string `json:"error,omitempty"`
+}
+
+// ReconcileReport adalah hasil satu kali rekonsiliasi MongoDB ↔ Postgre
+type ReconcileReport struct {
+	Repair             bool                         `json:"repair"`
+	GracePeriod        string                       `json:"grace_period" example:"15m0s"`
+	StartedAt          time.Time                    `json:"started_at"`
+	FinishedAt         time.Time                    `json:"finished_at"`
+	ScannedDocuments   int                          `json:"scanned_documents"`
+	ScannedReferences  int                          `json:"scanned_references"`
+	OrphanDocuments    []ReconcileOrphanDocument    `json:"orphan_documents"`
+	DanglingReferences []ReconcileDanglingReference `json:"dangling_references"`
+	StudentMismatches  []ReconcileStudentMismatch   `json:"student_mismatches"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// RefreshToken represents an opaque refresh token stored (hashed) in PostgreSQL.
+// Token dalam satu FamilyID berasal dari satu kali login dan dirotasi setiap refresh.
+type RefreshToken struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	TokenHash  string     `json:"-"`
+	FamilyID   string     `json:"family_id"`
+	ReplacedBy *string    `json:"replaced_by"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// RefreshTokenRequest digunakan untuk menukar refresh token dengan access token baru (FR-001)
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" example:"3q2-7wX9..."`
+}
@@ -8,4 +8,10 @@ type Role struct {
 	Name        string    `json:"name" example:"Mahasiswa"`
 	Description string    `json:"description" example:"Pengguna yang berhak melaporkan prestasi"`
 	CreatedAt   time.Time `json:"created_at" swaggerignore:"true"`
-}
\ No newline at end of file
+}
+
+// RoleRequest digunakan oleh Admin untuk membuat atau mengubah role
+type RoleRequest struct {
+	Name        string `json:"name" example:"Kaprodi"`
+	Description string `json:"description" example:"Ketua program studi"`
+}
@@ -4,4 +4,9 @@ package model
 type RolePermission struct {
 	RoleID       string `json:"role_id" example:"550e8400-e29b-41d4-a716-446655440002"`
 	PermissionID string `json:"permission_id" example:"550e8400-e29b-41d4-a716-446655440005"`
-}
\ No newline at end of file
+}
+
+// RolePermissionRequest digunakan untuk menempelkan permission ke role
+type RolePermissionRequest struct {
+	PermissionID string `json:"permission_id" example:"550e8400-e29b-41d4-a716-446655440005"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+// ScoringRule adalah satu versi aturan perhitungan poin prestasi (disimpan di Postgre).
+// Versi lama tidak pernah diubah agar skor historis bisa dihitung ulang.
+type ScoringRule struct {
+	ID          string         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Version     int            `json:"version" example:"2"`
+	Description string         `json:"description" example:"Bobot internasional dinaikkan"`
+	Rules       ScoringRuleSet `json:"rules"`
+	IsActive    bool           `json:"is_active" example:"false"`
+	CreatedBy   *string        `json:"created_by" example:"uuid-admin"`
+	CreatedAt   time.Time      `json:"created_at" swaggerignore:"true"`
+	ActivatedAt *time.Time     `json:"activated_at" swaggerignore:"true"`
+}
+
+// ScoringRuleSet berisi parameter perhitungan:
+// poin = base[jenis] × multiplier level × multiplier peringkat × faktor tim
+type ScoringRuleSet struct {
+	BasePoints             map[string]float64 `json:"base_points" swaggertype:"object,number" example:"competition:50,default:20"`
+	LevelMultipliers       map[string]float64 `json:"level_multipliers" swaggertype:"object,number" example:"international:3,national:2"`
+	DefaultLevelMultiplier float64            `json:"default_level_multiplier" example:"1"`
+	RankMultipliers        []RankMultiplier   `json:"rank_multipliers"`
+	DefaultRankMultiplier  float64            `json:"default_rank_multiplier" example:"1"`
+	TeamSplit              string             `json:"team_split" example:"sqrt"`
+	MinPoints              int                `json:"min_points" example:"0"`
+	MaxPoints              int                `json:"max_points" example:"500"`
+}
+
+// RankMultiplier berlaku untuk peringkat <= MaxRank (dipilih yang MaxRank terkecil)
+type RankMultiplier struct {
+	MaxRank    int     `json:"max_rank" example:"1"`
+	Multiplier float64 `json:"multiplier" example:"2"`
+}
+
+// ScoringRuleRequest digunakan admin untuk membuat versi aturan skor baru
+type ScoringRuleRequest struct {
+	Description string         `json:"description" example:"Bobot internasional dinaikkan"`
+	Rules       ScoringRuleSet `json:"rules"`
+}
+
+// ScoreBreakdown menjelaskan bagaimana poin sebuah prestasi dihitung
+type ScoreBreakdown struct {
+	RuleVersion     int     `json:"rule_version" example:"1"`
+	AchievementType string  `json:"achievement_type" example:"competition"`
+	Base            float64 `json:"base" example:"50"`
+	Level           string  `json:"level" example:"national"`
+	LevelMultiplier float64 `json:"level_multiplier" example:"2"`
+	Rank            int     `json:"rank" example:"1"`
+	RankMultiplier  float64 `json:"rank_multiplier" example:"2"`
+	TeamSize        int     `json:"team_size" example:"3"`
+	TeamFactor      float64 `json:"team_factor" example:"0.577"`
+	Points          int     `json:"points" example:"115"`
+}
+
+// ScoringPreviewItem membandingkan poin tersimpan dengan poin menurut aturan yang disimulasikan
+type ScoringPreviewItem struct {
+	ReferenceID    string `json:"reference_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StudentID      string `json:"student_id" example:"uuid-student-123"`
+	Title          string `json:"title" example:"Juara 1 Hackathon Nasional 2025"`
+	CurrentPoints  int    `json:"current_points" example:"100"`
+	CurrentVersion *int   `json:"current_version" example:"1"`
+	NewPoints      int    `json:"new_points" example:"150"`
+	Delta          int    `json:"delta" example:"50"`
+}
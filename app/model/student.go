@@ -4,11 +4,38 @@ import "time"
 
 // Student represents the student profile data in PostgreSQL
 type Student struct {
-	ID           string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
-	UserID       string    `json:"user_id" example:"uuid-user-123"`
-	StudentID    string    `json:"student_id" example:"2021101234"`
-	ProgramStudy string    `json:"program_study" example:"Teknik Informatika"`
-	AcademicYear string    `json:"academic_year" example:"2021/2022"`
-	AdvisorID    string    `json:"advisor_id" example:"uuid-lecturer-456"`
-	CreatedAt    time.Time `json:"created_at" swaggerignore:"true"`
-}
\ No newline at end of file
+	ID           string `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	UserID       string `json:"user_id" example:"uuid-user-123"`
+	StudentID    string `json:"student_id" example:"2021101234"`
+	ProgramStudy string `json:"program_study" example:"Teknik Informatika"`
+	AcademicYear string `json:"academic_year" example:"2021/2022"`
+	AdvisorID    string `json:"advisor_id" example:"uuid-lecturer-456"`
+	// Terisi bila profil dinonaktifkan (mis. lulus atau keluar)
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" swaggerignore:"true"`
+}
+
+// StudentProfileRequest adalah data profil mahasiswa, dipakai saat membuat
+// profil maupun bersamaan dengan user baru (UserCreateRequest.Student)
+type StudentProfileRequest struct {
+	// NIM
+	StudentID    string `json:"student_id" example:"2021101234"`
+	ProgramStudy string `json:"program_study" example:"Teknik Informatika"`
+	AcademicYear string `json:"academic_year" example:"2021/2022"`
+	// Dosen wali (opsional, ID tabel lecturers)
+	AdvisorID string `json:"advisor_id,omitempty" example:"uuid-lecturer-456"`
+}
+
+// StudentCreateRequest digunakan Admin untuk membuat profil mahasiswa bagi user yang sudah ada
+type StudentCreateRequest struct {
+	UserID string `json:"user_id" example:"uuid-user-123"`
+	StudentProfileRequest
+}
+
+// StudentUpdateRequest digunakan Admin untuk mengubah profil mahasiswa.
+// Dosen wali diubah lewat PUT /students/{id}/advisor.
+type StudentUpdateRequest struct {
+	StudentID    string `json:"student_id" example:"2021101234"`
+	ProgramStudy string `json:"program_study" example:"Teknik Informatika"`
+	AcademicYear string `json:"academic_year" example:"2021/2022"`
+}
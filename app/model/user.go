@@ -14,6 +14,7 @@ type User struct {
 	FullName     string    `json:"full_name" example:"John Doe"`
 	RoleID       string    `json:"role_id" example:"uuid-role-mahasiswa"`
 	IsActive     bool      `json:"is_active" example:"true"`
+	TokenVersion int       `json:"-" swaggerignore:"true"` // naik setiap sesi user harus dicabut
 	CreatedAt    time.Time `json:"created_at" swaggerignore:"true"`
 	UpdatedAt    time.Time `json:"updated_at" swaggerignore:"true"`
 }
@@ -29,6 +30,10 @@ type JWTClaims struct {
 	UserID      string   `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	RoleName    string   `json:"role" example:"Mahasiswa"`
 	Permissions []string `json:"permissions" example:"achievement:create,achievement:read"`
+	// SessionID = family refresh token tempat access token ini diterbitkan
+	SessionID string `json:"sid,omitempty" example:"uuid-session"`
+	// TokenVersion harus sama dengan users.token_version agar token diterima
+	TokenVersion int `json:"ver" example:"0"`
 	jwt.RegisteredClaims
 }
 
@@ -40,6 +45,10 @@ type UserCreateRequest struct {
 	FullName string `json:"full_name" example:"Dr. Ahmad Yani"`
 	RoleID   string `json:"role_id" example:"uuid-role-dosen"`
 	IsActive bool   `json:"is_active" example:"true"`
+	// Profil yang dibuat dalam transaksi yang sama (opsional, sesuai role:
+	// student untuk Mahasiswa, lecturer untuk Dosen Wali)
+	Student  *StudentProfileRequest  `json:"student,omitempty"`
+	Lecturer *LecturerProfileRequest `json:"lecturer,omitempty"`
 }
 
 // UserUpdateRequest digunakan untuk memperbarui profil user
@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// Jenis data yang diimpor lewat POST /imports/{kind}
+const (
+	ImportKindStudents  = "students"
+	ImportKindLecturers = "lecturers"
+)
+
+// Hasil rencana impor untuk satu baris
+const (
+	ImportActionCreate    = "create"    // user + profil baru
+	ImportActionUpdate    = "update"    // user/profil sudah ada dan berubah
+	ImportActionUnchanged = "unchanged" // sudah ada dan sama persis
+	ImportActionError     = "error"     // tidak valid, lihat Errors
+)
+
+// UserImportRow adalah satu baris file impor beserta hasil validasinya
+type UserImportRow struct {
+	// Nomor baris di file (header = baris 1)
+	Row    int    `json:"row" example:"2"`
+	Action string `json:"action" example:"create"`
+	// NIM (students) atau NIDN (lecturers)
+	Number       string `json:"number" example:"2021101234"`
+	FullName     string `json:"full_name" example:"Budi Santoso"`
+	Email        string `json:"email" example:"budi@univ.ac.id"`
+	ProgramStudy string `json:"program_study,omitempty" example:"Teknik Informatika"`
+	AcademicYear string `json:"academic_year,omitempty" example:"2021/2022"`
+	AdvisorNIDN  string `json:"advisor_nidn,omitempty" example:"198801012015011001"`
+	Department   string `json:"department,omitempty" example:"Teknik Informatika"`
+	Username     string `json:"username,omitempty" example:"2021101234"`
+	// Hanya terisi sekali, saat commit membuat user baru
+	InitialPassword string       `json:"initial_password,omitempty" example:"Xk7m2Pq9Rt4w"`
+	Errors          []FieldError `json:"errors,omitempty"`
+}
+
+// UserImportSummary adalah jumlah baris per action
+type UserImportSummary struct {
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// UserImportReport adalah hasil dry-run atau commit satu file impor
+type UserImportReport struct {
+	Kind       string            `json:"kind" example:"students"`
+	FileName   string            `json:"file_name" example:"mahasiswa-2025.xlsx"`
+	RoleID     string            `json:"role_id" example:"550e8400-e29b-41d4-a716-446655440002"` // role untuk user baru
+	RoleName   string            `json:"role_name" example:"Mahasiswa"`
+	DryRun     bool              `json:"dry_run"`
+	Committed  bool              `json:"committed"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Summary    UserImportSummary `json:"summary"`
+	Rows       []UserImportRow   `json:"rows"`
+}
+
+// UserImportOp adalah perubahan untuk satu baris yang akan ditulis dalam
+// transaksi commit. Tepat satu dari Student / Lecturer terisi.
+type UserImportOp struct {
+	User       User
+	NewUser    bool
+	Student    *Student
+	Lecturer   *Lecturer
+	NewProfile bool
+	// Penggantian dosen wali untuk profil mahasiswa yang sudah ada
+	Advisor *AdvisorAssignment
+}
@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+// CanAccessAchievement mengecek apakah claims boleh melakukan action pada
+// satu prestasi. Aturannya:
+//   - scope all: selalu boleh
+//   - pemilik (mahasiswa yang login) boleh dengan scope apa pun
+//   - dosen wali mahasiswa (IsStudentAdvisedBy) boleh dengan scope advisees
+//     ke atas; untuk "verify" cukup permission tanpa scope
+//   - scope department: mahasiswa satu program studi dengan dosen
+//   - dosen pada reference.ReviewerID (dosen wali lama dengan pending_reviews
+//     = keep) boleh "read" dan "verify"
+//
+// Untuk "read" dan "verify", anggota tim yang tidak menolak diperlakukan
+// seperti pemilik, sehingga anggota dan dosen walinya ikut mendapat akses.
+// Error no rows dikembalikan apa adanya bila data mahasiswa pemilik hilang.
+func CanAccessAchievement(claims *model.JWTClaims, action string, ref *model.AchievementReference) (bool, error) {
+	scope := Can(claims, action, "achievement")
+	if scope == ScopeNone || ref == nil {
+		return false, nil
+	}
+	if scope == ScopeAll {
+		return true, nil
+	}
+
+	// dosen wali lama yang mempertahankan pengajuan tetap boleh membaca & meninjaunya
+	if ref.ReviewerID != nil && (action == "read" || action == "verify") {
+		lecturer, err := repository.GetLecturerByUserID(claims.UserID)
+		if err != nil && !repository.IsNoRows(err) {
+			return false, err
+		}
+		if lecturer != nil && lecturer.ID == *ref.ReviewerID {
+			return true, nil
+		}
+	}
+
+	owner, err := repository.GetStudentByID(ref.StudentID)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := canAccessAchievementStudent(claims, action, scope, owner)
+	if ok || err != nil || (action != "read" && action != "verify") {
+		return ok, err
+	}
+
+	members, err := repository.GetAchievementMembers(ref.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range members {
+		if m.StudentID == ref.StudentID || m.Status == model.MemberDeclined {
+			continue
+		}
+		student, err := repository.GetStudentByID(m.StudentID)
+		if err != nil {
+			if repository.IsNoRows(err) {
+				continue
+			}
+			return false, err
+		}
+		if ok, err := canAccessAchievementStudent(claims, action, scope, student); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// canAccessAchievementStudent menerapkan aturan CanAccessAchievement untuk satu mahasiswa
+func canAccessAchievementStudent(claims *model.JWTClaims, action string, scope Scope, student *model.Student) (bool, error) {
+	if student.UserID == claims.UserID {
+		return true, nil
+	}
+	if scope == ScopeOwn && action != "verify" {
+		return false, nil
+	}
+
+	lecturer, err := repository.GetLecturerByUserID(claims.UserID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	advised, err := repository.IsStudentAdvisedBy(lecturer.ID, student.ID)
+	if err != nil {
+		return false, err
+	}
+	if advised {
+		return true, nil
+	}
+
+	if scope == ScopeDepartment && lecturer.Department != "" {
+		return strings.EqualFold(lecturer.Department, student.ProgramStudy), nil
+	}
+	return false, nil
+}
@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+// Scope menyatakan jangkauan data yang boleh diakses oleh sebuah permission.
+// Urutannya dari paling sempit ke paling luas, sehingga scope yang lebih
+// luas selalu mencakup scope di bawahnya.
+type Scope int
+
+const (
+	ScopeNone Scope = iota
+	ScopeOwn
+	ScopeAdvisees
+	ScopeDepartment
+	ScopeAll
+)
+
+var scopeNames = map[string]Scope{
+	"own":        ScopeOwn,
+	"advisees":   ScopeAdvisees,
+	"department": ScopeDepartment,
+	"all":        ScopeAll,
+}
+
+func (s Scope) String() string {
+	for name, v := range scopeNames {
+		if v == s {
+			return name
+		}
+	}
+	return "none"
+}
+
+// ParsePermission memecah nama permission "resource:action[:scope]".
+// Permission tanpa suffix scope dianggap berlaku untuk data milik sendiri.
+func ParsePermission(name string) (resource, action string, scope Scope, ok bool) {
+	parts := strings.Split(name, ":")
+	switch len(parts) {
+	case 2:
+		return parts[0], parts[1], ScopeOwn, true
+	case 3:
+		s, known := scopeNames[parts[2]]
+		if !known {
+			return "", "", ScopeNone, false
+		}
+		return parts[0], parts[1], s, true
+	}
+	return "", "", ScopeNone, false
+}
+
+// Can mengembalikan scope terluas yang dimiliki claims untuk action pada
+// resource. ScopeNone berarti tidak diizinkan sama sekali.
+func Can(claims *model.JWTClaims, action, resource string) Scope {
+	if claims == nil {
+		return ScopeNone
+	}
+
+	best := ScopeNone
+	for _, p := range claims.Permissions {
+		res, act, scope, ok := ParsePermission(p)
+		if !ok || res != resource || act != action {
+			continue
+		}
+		if scope > best {
+			best = scope
+		}
+	}
+	return best
+}
+
+// HasPermission dipakai middleware route: permission "resource:action"
+// dianggap terpenuhi oleh varian ber-scope mana pun.
+func HasPermission(claims *model.JWTClaims, required string) bool {
+	res, act, _, ok := ParsePermission(required)
+	if !ok {
+		return false
+	}
+	return Can(claims, act, res) != ScopeNone
+}
+
+// Jenis profil yang dapat dimiliki user (tabel students / lecturers)
+const (
+	ProfileStudent  = "student"
+	ProfileLecturer = "lecturer"
+)
+
+// CanHoldProfile menentukan dari permission role, bukan dari nama role,
+// apakah user boleh memiliki profil tertentu:
+//   - mahasiswa: melaporkan prestasinya sendiri (achievement:create dengan
+//     scope own, tanpa scope yang lebih luas seperti milik admin)
+//   - dosen: boleh memverifikasi prestasi (achievement:verify)
+func CanHoldProfile(permissions []string, profile string) bool {
+	claims := &model.JWTClaims{Permissions: permissions}
+	switch profile {
+	case ProfileStudent:
+		return Can(claims, "create", "achievement") == ScopeOwn
+	case ProfileLecturer:
+		return Can(claims, "verify", "achievement") != ScopeNone
+	}
+	return false
+}
+
+// CanAccessStudent mengecek apakah claims boleh melakukan action pada
+// resource milik mahasiswa tertentu, sesuai scope yang dimiliki.
+func CanAccessStudent(claims *model.JWTClaims, action, resource string, student *model.Student) (bool, error) {
+	scope := Can(claims, action, resource)
+	if scope == ScopeNone || student == nil {
+		return false, nil
+	}
+	if scope == ScopeAll {
+		return true, nil
+	}
+
+	if student.UserID == claims.UserID {
+		return true, nil
+	}
+	if scope == ScopeOwn {
+		return false, nil
+	}
+
+	lecturer, err := repository.GetLecturerByUserID(claims.UserID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if student.AdvisorID != "" && student.AdvisorID == lecturer.ID {
+		return true, nil
+	}
+
+	if scope == ScopeDepartment && lecturer.Department != "" {
+		return strings.EqualFold(lecturer.Department, student.ProgramStudy), nil
+	}
+	return false, nil
+}
+
+// CanAccessLecturer mengecek akses ke data dosen. Scope own mencakup
+// profil dosen itu sendiri dan dosen wali dari mahasiswa yang login.
+func CanAccessLecturer(claims *model.JWTClaims, action, resource string, target *model.Lecturer) (bool, error) {
+	scope := Can(claims, action, resource)
+	if scope == ScopeNone || target == nil {
+		return false, nil
+	}
+	if scope == ScopeAll {
+		return true, nil
+	}
+
+	if target.UserID == claims.UserID {
+		return true, nil
+	}
+
+	if student, err := repository.GetStudentByUserID(claims.UserID); err == nil {
+		if student.AdvisorID == target.ID {
+			return true, nil
+		}
+	} else if !repository.IsNoRows(err) {
+		return false, err
+	}
+
+	if scope < ScopeDepartment {
+		return false, nil
+	}
+
+	self, err := repository.GetLecturerByUserID(claims.UserID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return self.Department != "" && strings.EqualFold(self.Department, target.Department), nil
+}
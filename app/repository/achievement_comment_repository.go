@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// =====================================
+// ACHIEVEMENT COMMENTS (Postgre)
+// =====================================
+
+const achievementCommentColumns = `
+	c.id, c.achievement_ref_id, c.parent_id, c.author_id, COALESCE(u.full_name, ''),
+	c.kind, c.body, c.attachment_ids, c.resolved_at, c.resolved_by, c.created_at`
+
+func scanAchievementComment(row rowScanner) (*model.AchievementComment, error) {
+	var cm model.AchievementComment
+	err := row.Scan(
+		&cm.ID, &cm.AchievementRefID, &cm.ParentID, &cm.AuthorID, &cm.AuthorName,
+		&cm.Kind, &cm.Body, pq.Array(&cm.AttachmentIDs), &cm.ResolvedAt, &cm.ResolvedBy, &cm.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if cm.AttachmentIDs == nil {
+		cm.AttachmentIDs = []string{}
+	}
+	return &cm, nil
+}
+
+// Simpan komentar baru
+var CreateAchievementComment = func(cm *model.AchievementComment) error {
+	if cm.ID == "" {
+		cm.ID = uuid.NewString()
+	}
+	if cm.AttachmentIDs == nil {
+		cm.AttachmentIDs = []string{}
+	}
+
+	return database.DB.QueryRow(`
+		INSERT INTO achievement_comments (
+			id, achievement_ref_id, parent_id, author_id, kind, body, attachment_ids, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at;
+	`, cm.ID, cm.AchievementRefID, cm.ParentID, cm.AuthorID, cm.Kind, cm.Body, pq.Array(cm.AttachmentIDs),
+	).Scan(&cm.CreatedAt)
+}
+
+// Semua komentar satu prestasi, urut dari yang paling lama
+func GetAchievementComments(refID string) ([]model.AchievementComment, error) {
+	rows, err := database.DB.Query(`
+		SELECT `+achievementCommentColumns+`
+		FROM achievement_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.achievement_ref_id = $1
+		ORDER BY c.created_at, c.id;
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.AchievementComment{}
+	for rows.Next() {
+		cm, err := scanAchievementComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *cm)
+	}
+	return list, rows.Err()
+}
+
+// Ambil satu komentar
+var GetAchievementCommentByID = func(id string) (*model.AchievementComment, error) {
+	row := database.DB.QueryRow(`
+		SELECT `+achievementCommentColumns+`
+		FROM achievement_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.id = $1;
+	`, id)
+	return scanAchievementComment(row)
+}
+
+// Tutup permintaan klarifikasi. sql.ErrNoRows bila komentar bukan
+// klarifikasi yang masih terbuka.
+func ResolveAchievementClarification(id, resolvedBy string) error {
+	res, err := database.DB.Exec(`
+		UPDATE achievement_comments
+		SET resolved_at = NOW(),
+		    resolved_by = $2
+		WHERE id = $1
+		  AND kind = 'clarification'
+		  AND resolved_at IS NULL;
+	`, id, resolvedBy)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
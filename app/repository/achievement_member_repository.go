@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+
+	"github.com/lib/pq"
+)
+
+// =====================================
+// ACHIEVEMENT_MEMBERS (Postgre)
+// =====================================
+
+// ErrMemberNotFound dikembalikan saat mahasiswa bukan anggota tim prestasi
+var ErrMemberNotFound = errors.New("anggota tim tidak ditemukan")
+
+// memberExists adalah awal subquery EXISTS untuk filter prestasi lewat anggota
+// tim yang belum menolak. Pemanggil menambahkan kondisi lalu menutup kurungnya.
+const memberExists = `EXISTS (
+		SELECT 1 FROM achievement_members m
+		JOIN students ms ON ms.id = m.student_id
+		WHERE m.achievement_ref_id = ar.id
+		  AND m.status <> 'declined'`
+
+// insertAchievementMembers menyimpan anggota tim saat reference dibuat.
+// Pembuat selalu tercatat sebagai anggota yang sudah konfirmasi.
+func insertAchievementMembers(tx *sql.Tx, ref *model.AchievementReference) error {
+	members := ref.Members
+	if len(members) == 0 {
+		members = []model.AchievementMember{{StudentID: ref.StudentID, Role: model.MemberRoleLeader}}
+	}
+
+	for _, m := range members {
+		status := model.MemberPending
+		if m.StudentID == ref.StudentID {
+			status = model.MemberConfirmed
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO achievement_members (achievement_ref_id, student_id, role, status, responded_at, created_at)
+			VALUES ($1, $2, $3, $4, CASE WHEN $5 THEN NOW() END, NOW());
+		`, ref.ID, m.StudentID, m.Role, status, status == model.MemberConfirmed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ambil anggota tim satu prestasi beserta NIM dan nama, ketua lebih dulu
+var GetAchievementMembers = func(refID string) ([]model.AchievementMember, error) {
+	query := `
+		SELECT m.achievement_ref_id, m.student_id, s.student_id, u.full_name,
+		       m.role, m.status, m.responded_at, m.points,
+		       m.verified_at, m.verified_by, m.created_at
+		FROM achievement_members m
+		JOIN students s ON s.id = m.student_id
+		JOIN users u ON u.id = s.user_id
+		WHERE m.achievement_ref_id = $1
+		ORDER BY m.role = 'ketua' DESC, m.created_at, s.student_id;
+	`
+
+	rows, err := database.DB.Query(query, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.AchievementMember
+	for rows.Next() {
+		var m model.AchievementMember
+		var respondedAt, verifiedAt sql.NullTime
+		var verifiedBy sql.NullString
+
+		if err := rows.Scan(
+			&m.AchievementRefID,
+			&m.StudentID,
+			&m.StudentNumber,
+			&m.FullName,
+			&m.Role,
+			&m.Status,
+			&respondedAt,
+			&m.Points,
+			&verifiedAt,
+			&verifiedBy,
+			&m.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if respondedAt.Valid {
+			t := respondedAt.Time
+			m.RespondedAt = &t
+		}
+		if verifiedAt.Valid {
+			t := verifiedAt.Time
+			m.VerifiedAt = &t
+		}
+		if verifiedBy.Valid {
+			v := verifiedBy.String
+			m.VerifiedBy = &v
+		}
+
+		list = append(list, m)
+	}
+	return list, rows.Err()
+}
+
+// ReplaceAchievementMembers mengganti susunan tim. Anggota yang tetap ada
+// mempertahankan status konfirmasinya, anggota baru menunggu konfirmasi,
+// dan anggota yang tidak lagi tercantum dihapus. Anggota yang pernah menolak
+// lalu dicantumkan lagi diundang ulang (kembali pending), karena anggota
+// declined tidak bisa membuka prestasinya untuk merespons.
+var ReplaceAchievementMembers = func(refID, ownerID string, members []model.AchievementMember) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// peran direset dulu agar index satu-ketua tidak bentrok saat ketua berpindah
+	if _, err := tx.Exec(`
+		UPDATE achievement_members SET role = 'anggota' WHERE achievement_ref_id = $1;
+	`, refID); err != nil {
+		return err
+	}
+
+	keep := make([]string, 0, len(members))
+	for _, m := range members {
+		status := model.MemberPending
+		if m.StudentID == ownerID {
+			status = model.MemberConfirmed
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO achievement_members (achievement_ref_id, student_id, role, status, responded_at, created_at)
+			VALUES ($1, $2, $3, $4, CASE WHEN $5 THEN NOW() END, NOW())
+			ON CONFLICT (achievement_ref_id, student_id) DO UPDATE
+			SET role = EXCLUDED.role,
+			    status = CASE WHEN achievement_members.status = 'declined' AND EXCLUDED.status = 'pending'
+			                  THEN 'pending' ELSE achievement_members.status END,
+			    responded_at = CASE WHEN achievement_members.status = 'declined' AND EXCLUDED.status = 'pending'
+			                        THEN NULL ELSE achievement_members.responded_at END;
+		`, refID, m.StudentID, m.Role, status, status == model.MemberConfirmed); err != nil {
+			return err
+		}
+		keep = append(keep, m.StudentID)
+	}
+
+	if _, err := tx.Exec(`
+		DELETE FROM achievement_members
+		WHERE achievement_ref_id = $1
+		  AND NOT (student_id::text = ANY($2));
+	`, refID, pq.Array(keep)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RespondAchievementMember mencatat konfirmasi (confirmed) atau penolakan
+// (declined) keikutsertaan seorang anggota
+var RespondAchievementMember = func(refID, studentID, status string) error {
+	res, err := database.DB.Exec(`
+		UPDATE achievement_members
+		SET status = $3,
+		    responded_at = NOW()
+		WHERE achievement_ref_id = $1
+		  AND student_id = $2;
+	`, refID, studentID, status)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// VerifyAchievementMember memverifikasi bagian satu anggota (oleh dosen walinya).
+// Jika seluruh anggota yang sudah konfirmasi telah diverifikasi, reference
+// ikut berpindah submitted → verified di transaksi yang sama dan finalized = true.
+func VerifyAchievementMember(refID, studentID, verifierUserID string, points, ruleVersion int) (finalized bool, err error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	if err := tx.QueryRow(`
+		SELECT status FROM achievement_references WHERE id = $1 FOR UPDATE;
+	`, refID).Scan(&status); err != nil {
+		return false, err
+	}
+	if status != model.StatusSubmitted {
+		return false, ErrStatusConflict
+	}
+
+	res, err := tx.Exec(`
+		UPDATE achievement_members
+		SET points = $3,
+		    verified_at = NOW(),
+		    verified_by = $4
+		WHERE achievement_ref_id = $1
+		  AND student_id = $2
+		  AND status = 'confirmed'
+		  AND verified_at IS NULL;
+	`, refID, studentID, points, verifierUserID)
+	if err != nil {
+		return false, err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return false, ErrMemberNotFound
+	}
+
+	var remaining int
+	if err := tx.QueryRow(`
+		SELECT COUNT(*) FROM achievement_members
+		WHERE achievement_ref_id = $1
+		  AND status = 'confirmed'
+		  AND verified_at IS NULL;
+	`, refID).Scan(&remaining); err != nil {
+		return false, err
+	}
+
+	if remaining == 0 {
+		if _, err := tx.Exec(`
+			UPDATE achievement_references
+			SET status = $2,
+			    updated_at = NOW(),
+			    verified_at = NOW(),
+			    verified_by = $3,
+			    points = $4,
+			    scoring_rule_version = $5
+			WHERE id = $1;
+		`, refID, model.StatusVerified, verifierUserID, points, ruleVersion); err != nil {
+			return false, err
+		}
+
+		from := model.StatusSubmitted
+		note := "Seluruh anggota tim telah diverifikasi"
+		if err := insertStatusHistory(tx, refID, &from, model.StatusVerified, verifierUserID, &note); err != nil {
+			return false, err
+		}
+		finalized = true
+	}
+
+	return finalized, tx.Commit()
+}
+
+// resetMemberVerification membatalkan verifikasi per anggota (dipakai saat prestasi ditolak)
+func resetMemberVerification(refID string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE achievement_members
+			SET points = 0,
+			    verified_at = NULL,
+			    verified_by = NULL
+			WHERE achievement_ref_id = $1;
+		`, refID)
+		return err
+	}
+}
+
+// GetConfirmedMemberCounts menghitung anggota yang sudah konfirmasi per reference
+var GetConfirmedMemberCounts = func(refIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(refIDs))
+	if len(refIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := database.DB.Query(`
+		SELECT achievement_ref_id, COUNT(*)
+		FROM achievement_members
+		WHERE achievement_ref_id::text = ANY($1)
+		  AND status = 'confirmed'
+		GROUP BY achievement_ref_id;
+	`, pq.Array(refIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
@@ -11,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // =====================================
@@ -69,6 +70,78 @@ func GetAchievementByID(id string) (*model.AchievementMongo, error) {
 	return &doc, nil
 }
 
+// ErrInvalidAchievementID menandai mongo_achievement_id yang bukan ObjectID hex
+var ErrInvalidAchievementID = errors.New("ID achievement MongoDB tidak valid")
+
+// Jumlah ID per query $in agar satu query tidak terlalu besar
+const achievementBatchSize = 500
+
+// Ambil banyak achievement sekaligus dengan $in.
+// found berisi dokumen yang berhasil di-decode, failed berisi ID yang tidak valid
+// atau dokumennya rusak. ID yang tidak ada di keduanya berarti dokumennya tidak ditemukan.
+var GetAchievementsByIDs = func(ids []string) (map[string]*model.AchievementMongo, map[string]error, error) {
+	found := map[string]*model.AchievementMongo{}
+	failed := map[string]error{}
+	if len(ids) == 0 {
+		return found, failed, nil
+	}
+
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	seen := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			failed[id] = ErrInvalidAchievementID
+			continue
+		}
+		if !seen[oid] {
+			seen[oid] = true
+			oids = append(oids, oid)
+		}
+	}
+
+	for start := 0; start < len(oids); start += achievementBatchSize {
+		end := min(start+achievementBatchSize, len(oids))
+		if err := findAchievementBatch(coll, oids[start:end], found, failed); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return found, failed, nil
+}
+
+func findAchievementBatch(coll *mongo.Collection, oids []primitive.ObjectID, found map[string]*model.AchievementMongo, failed map[string]error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := coll.Find(ctx, bson.M{"_id": bson.M{"$in": oids}})
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		oid, ok := cur.Current.Lookup("_id").ObjectIDOK()
+		if !ok {
+			continue
+		}
+
+		// decode per dokumen supaya satu dokumen rusak tidak menggagalkan seluruh list
+		var doc model.AchievementMongo
+		if err := bson.Unmarshal(cur.Current, &doc); err != nil {
+			failed[oid.Hex()] = err
+			continue
+		}
+		found[oid.Hex()] = &doc
+	}
+	return cur.Err()
+}
+
 // Ambil semua achievement berdasarkan studentId
 var GetAchievementsByStudentID = func(studentID string) ([]model.AchievementMongo, error) {
 	coll, err := getAchievementCollection()
@@ -97,7 +170,7 @@ var GetAchievementsByStudentID = func(studentID string) ([]model.AchievementMong
 }
 
 // Update achievement (title, description, dsb.)
-func UpdateAchievement(id string, update bson.M) error {
+var UpdateAchievement = func(id string, update bson.M) error {
 	coll, err := getAchievementCollection()
 	if err != nil {
 		return err
@@ -124,7 +197,7 @@ func UpdateAchievement(id string, update bson.M) error {
 }
 
 // Delete achievement berdasarkan ID
-func DeleteAchievement(id string) error {
+var DeleteAchievement = func(id string) error {
 	coll, err := getAchievementCollection()
 	if err != nil {
 		return err
@@ -142,8 +215,8 @@ func DeleteAchievement(id string) error {
 	return err
 }
 
-// Tambah attachment (nama file) ke array attachments
-func AddAchievementAttachment(id, filename string) error {
+// Tambah metadata attachment ke array attachments
+var AddAchievementAttachment = func(id string, att model.Attachment) error {
 	coll, err := getAchievementCollection()
 	if err != nil {
 		return err
@@ -157,9 +230,265 @@ func AddAchievementAttachment(id, filename string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	res, err := coll.UpdateOne(ctx,
+		bson.M{"_id": oid},
+		bson.M{
+			"$push": bson.M{"attachments": att},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Hapus satu attachment dari array attachments (format lama disimpan sebagai string)
+func RemoveAchievementAttachment(id string, att model.Attachment) error {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return err
+	}
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	var match interface{} = bson.M{"id": att.ID}
+	if att.Legacy {
+		match = att.Key
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	_, err = coll.UpdateOne(ctx,
 		bson.M{"_id": oid},
-		bson.M{"$push": bson.M{"attachments": filename}},
+		bson.M{
+			"$pull": bson.M{"attachments": match},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
 	)
 	return err
 }
+
+// Cari ID achievement yang cocok dengan filter jenis dan tag (tag harus dimiliki semua).
+// Hasilnya dipakai sebagai filter mongo_achievement_id di Postgre.
+func FindAchievementIDs(types, tags []string) ([]string, error) {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{}
+	if len(types) > 0 {
+		filter["achievementType"] = bson.M{"$in": types}
+	}
+	if len(tags) > 0 {
+		filter["tags"] = bson.M{"$all": tags}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cur, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	ids := []string{}
+	for cur.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID.Hex())
+	}
+	return ids, cur.Err()
+}
+
+// Ringkasan semua dokumen achievement (hanya _id, studentId, title, createdAt)
+// untuk rekonsiliasi dengan achievement_references di Postgre
+var GetAchievementSummaries = func() ([]model.AchievementMongo, error) {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "studentId": 1, "title": 1, "createdAt": 1})
+	cur, err := coll.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	list := []model.AchievementMongo{}
+	for cur.Next(ctx) {
+		var doc model.AchievementMongo
+		if err := cur.Decode(&doc); err != nil {
+			// dokumen rusak tetap dihitung sebagai ada berdasarkan _id-nya
+			oid, ok := cur.Current.Lookup("_id").ObjectIDOK()
+			if !ok {
+				continue
+			}
+			doc = model.AchievementMongo{ID: oid}
+		}
+		list = append(list, doc)
+	}
+	return list, cur.Err()
+}
+
+// SearchableDetailKeys adalah field details yang ikut diindeks full-text
+var SearchableDetailKeys = []string{
+	"competitionName", "organizer", "publicationTitle", "publisher",
+	"certificationName", "issuedBy", "organizationName", "companyName", "position",
+}
+
+// EnsureAchievementIndexes membuat index multikey dedupKeys untuk pencarian
+// kandidat duplikat secara global, dan text index untuk /achievements/search
+func EnsureAchievementIndexes() error {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	textKeys := bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "tags", Value: "text"}}
+	weights := bson.D{{Key: "title", Value: 10}, {Key: "tags", Value: 5}, {Key: "description", Value: 2}}
+	for _, k := range SearchableDetailKeys {
+		textKeys = append(textKeys, bson.E{Key: "details." + k, Value: "text"})
+		weights = append(weights, bson.E{Key: "details." + k, Value: 3})
+	}
+
+	_, err = coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "dedupKeys", Value: 1}}},
+		{
+			Keys: textKeys,
+			// teks campuran Indonesia/Inggris: tanpa stemming bahasa tertentu
+			Options: options.Index().
+				SetName("achievement_text").
+				SetWeights(weights).
+				SetDefaultLanguage("none").
+				SetLanguageOverride("textLanguage"),
+		},
+	})
+	return err
+}
+
+// Cari achievement dengan MongoDB text search, urut dari skor relevansi tertinggi.
+// Mengembalikan dokumen beserta skornya per ID (hex).
+var SearchAchievementsText = func(text string, limit int) ([]model.AchievementMongo, map[string]float64, error) {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}}).
+		SetLimit(int64(limit))
+
+	cur, err := coll.Find(ctx, bson.M{"$text": bson.M{"$search": text}}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.AchievementMongo
+	scores := map[string]float64{}
+	for cur.Next(ctx) {
+		var hit struct {
+			model.AchievementMongo `bson:",inline"`
+			Score                  float64 `bson:"score"`
+		}
+		if err := cur.Decode(&hit); err != nil {
+			continue
+		}
+		list = append(list, hit.AchievementMongo)
+		scores[hit.ID.Hex()] = hit.Score
+	}
+	return list, scores, cur.Err()
+}
+
+// Ambil achievement yang punya salah satu dedupKeys, kecuali excludeID
+var FindAchievementsByDedupKeys = func(keys []string, excludeID string, limit int) ([]model.AchievementMongo, error) {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{"dedupKeys": bson.M{"$in": keys}}
+	if oid, err := primitive.ObjectIDFromHex(excludeID); err == nil {
+		filter["_id"] = bson.M{"$ne": oid}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := coll.Find(ctx, filter, options.Find().SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.AchievementMongo
+	for cur.Next(ctx) {
+		var doc model.AchievementMongo
+		if err := cur.Decode(&doc); err != nil {
+			continue
+		}
+		list = append(list, doc)
+	}
+	return list, cur.Err()
+}
+
+// Ambil achievement yang belum punya dedupKeys (dokumen lama sebelum deteksi duplikat)
+func GetAchievementsWithoutDedupKeys(limit int) ([]model.AchievementMongo, error) {
+	coll, err := getAchievementCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cur, err := coll.Find(ctx, bson.M{"dedupKeys": bson.M{"$exists": false}}, options.Find().SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.AchievementMongo
+	for cur.Next(ctx) {
+		var doc model.AchievementMongo
+		if err := cur.Decode(&doc); err != nil {
+			// dokumen rusak tetap diberi kunci kosong agar tidak diproses ulang
+			if oid, ok := cur.Current.Lookup("_id").ObjectIDOK(); ok {
+				list = append(list, model.AchievementMongo{ID: oid})
+			}
+			continue
+		}
+		list = append(list, doc)
+	}
+	return list, cur.Err()
+}
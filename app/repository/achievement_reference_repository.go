@@ -2,17 +2,21 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"prestasi_backend/app/model"
 	"prestasi_backend/database"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // =====================================
 // ACHIEVEMENT_REFERENCES (Postgre)
 // =====================================
 
-// Insert reference baru (ketika mahasiswa membuat prestasi)
-func CreateAchievementReference(ref *model.AchievementReference) error {
+// Insert reference baru (ketika mahasiswa membuat prestasi) beserta riwayat awal
+var CreateAchievementReference = func(ref *model.AchievementReference, actorID string, note *string) error {
 	query := `
 		INSERT INTO achievement_references (
 			id, student_id, mongo_achievement_id, status,
@@ -24,7 +28,13 @@ func CreateAchievementReference(ref *model.AchievementReference) error {
 		        NOW(), NOW());
 	`
 
-	_, err := database.DB.Exec(
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
 		query,
 		ref.ID,
 		ref.StudentID,
@@ -35,14 +45,27 @@ func CreateAchievementReference(ref *model.AchievementReference) error {
 		ref.VerifiedBy,
 		ref.RejectionNote,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if err := insertStatusHistory(tx, ref.ID, nil, ref.Status, actorID, note); err != nil {
+		return err
+	}
+
+	if err := insertAchievementMembers(tx, ref); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // Ambil reference berdasarkan ID
-func GetAchievementReferenceByID(id string) (*model.AchievementReference, error) {
+var GetAchievementReferenceByID = func(id string) (*model.AchievementReference, error) {
 	query := `
 		SELECT id, student_id, mongo_achievement_id, status,
 		       submitted_at, verified_at, verified_by, rejection_note,
+		       points, scoring_rule_version, reviewer_id,
 		       created_at, updated_at
 		FROM achievement_references
 		WHERE id = $1;
@@ -50,7 +73,8 @@ func GetAchievementReferenceByID(id string) (*model.AchievementReference, error)
 
 	var ref model.AchievementReference
 	var submittedAt, verifiedAt sql.NullTime
-	var verifiedBy, rejectionNote sql.NullString
+	var verifiedBy, rejectionNote, reviewerID sql.NullString
+	var ruleVersion sql.NullInt64
 
 	err := database.DB.QueryRow(query, id).Scan(
 		&ref.ID,
@@ -61,6 +85,9 @@ func GetAchievementReferenceByID(id string) (*model.AchievementReference, error)
 		&verifiedAt,
 		&verifiedBy,
 		&rejectionNote,
+		&ref.Points,
+		&ruleVersion,
+		&reviewerID,
 		&ref.CreatedAt,
 		&ref.UpdatedAt,
 	)
@@ -84,155 +111,108 @@ func GetAchievementReferenceByID(id string) (*model.AchievementReference, error)
 		s := rejectionNote.String
 		ref.RejectionNote = &s
 	}
+	if ruleVersion.Valid {
+		v := int(ruleVersion.Int64)
+		ref.ScoringRuleVersion = &v
+	}
+	if reviewerID.Valid {
+		s := reviewerID.String
+		ref.ReviewerID = &s
+	}
 
 	return &ref, nil
 }
 
-// Ambil daftar reference milik 1 mahasiswa
-func GetAchievementReferencesByStudentID(studentID string) ([]model.AchievementReference, error) {
-	query := `
-		SELECT id, student_id, mongo_achievement_id, status,
-		       submitted_at, verified_at, verified_by, rejection_note,
-		       created_at, updated_at
-		FROM achievement_references
-		WHERE student_id = $1
-		  AND status <> 'deleted'
-		ORDER BY created_at DESC;
-	`
-
-	rows, err := database.DB.Query(query, studentID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var list []model.AchievementReference
-	for rows.Next() {
-		var ref model.AchievementReference
-		var submittedAt, verifiedAt sql.NullTime
-		var verifiedBy, rejectionNote sql.NullString
-
-		if err := rows.Scan(
-			&ref.ID,
-			&ref.StudentID,
-			&ref.MongoAchievementID,
-			&ref.Status,
-			&submittedAt,
-			&verifiedAt,
-			&verifiedBy,
-			&rejectionNote,
-			&ref.CreatedAt,
-			&ref.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-
-		if submittedAt.Valid {
-			t := submittedAt.Time
-			ref.SubmittedAt = &t
-		}
-		if verifiedAt.Valid {
-			t := verifiedAt.Time
-			ref.VerifiedAt = &t
-		}
-		if verifiedBy.Valid {
-			s := verifiedBy.String
-			ref.VerifiedBy = &s
-		}
-		if rejectionNote.Valid {
-			s := rejectionNote.String
-			ref.RejectionNote = &s
-		}
+// achievementReferenceList: kolom sama dengan scanAchievementReferences, di-join ke students untuk filter
+var achievementReferenceList = listSpec[model.AchievementReference]{
+	columns: `ar.id, ar.student_id, ar.mongo_achievement_id, ar.status,
+		ar.submitted_at, ar.verified_at, ar.verified_by, ar.rejection_note,
+		ar.points, ar.scoring_rule_version, ar.reviewer_id,
+		ar.created_at, ar.updated_at`,
+	from:     `achievement_references ar JOIN students s ON s.id = ar.student_id`,
+	idColumn: "ar.id",
+	sorts: map[string]sortField[model.AchievementReference]{
+		"created_at": {"ar.created_at", "timestamp", func(r model.AchievementReference) any { return r.CreatedAt }},
+		"updated_at": {"ar.updated_at", "timestamp", func(r model.AchievementReference) any { return r.UpdatedAt }},
+		"points":     {"ar.points", "int", func(r model.AchievementReference) any { return r.Points }},
+		"status":     {"ar.status", "text", func(r model.AchievementReference) any { return r.Status }},
+	},
+	defaultSort: "created_at",
+	id:          func(r model.AchievementReference) string { return r.ID },
+	scan:        scanAchievementReferences,
+}
 
-		list = append(list, ref)
-	}
-	return list, rows.Err()
+// List reference dengan filter, sort, dan paginasi. Status deleted hanya ikut
+// jika IncludeDeleted atau diminta eksplisit lewat Statuses.
+func ListAchievementReferences(f model.AchievementFilter, q model.ListQuery) ([]model.AchievementReference, model.PageInfo, error) {
+	return runList(achievementReferenceList, achievementReferenceWhere(f), q)
 }
 
-// Daftar semua reference (untuk admin)
-func GetAllAchievementReferences() ([]model.AchievementReference, error) {
-	query := `
-		SELECT id, student_id, mongo_achievement_id, status,
-		       submitted_at, verified_at, verified_by, rejection_note,
-		       created_at, updated_at
-		FROM achievement_references
-		ORDER BY created_at DESC;
-	`
+// Semua reference yang lolos filter tanpa paginasi. Dipakai bersama filter
+// MongoIDs yang jumlahnya sudah dibatasi (mis. hasil pencarian teks).
+var FilterAchievementReferences = func(f model.AchievementFilter) ([]model.AchievementReference, error) {
+	w := achievementReferenceWhere(f)
+	spec := achievementReferenceList
 
-	rows, err := database.DB.Query(query)
+	rows, err := database.DB.Query(`SELECT `+spec.columns+` FROM `+spec.from+w.sql(), w.args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var list []model.AchievementReference
-	for rows.Next() {
-		var ref model.AchievementReference
-		var submittedAt, verifiedAt sql.NullTime
-		var verifiedBy, rejectionNote sql.NullString
-
-		if err := rows.Scan(
-			&ref.ID,
-			&ref.StudentID,
-			&ref.MongoAchievementID,
-			&ref.Status,
-			&submittedAt,
-			&verifiedAt,
-			&verifiedBy,
-			&rejectionNote,
-			&ref.CreatedAt,
-			&ref.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
+	return spec.scan(rows)
+}
 
-		if submittedAt.Valid {
-			t := submittedAt.Time
-			ref.SubmittedAt = &t
-		}
-		if verifiedAt.Valid {
-			t := verifiedAt.Time
-			ref.VerifiedAt = &t
-		}
-		if verifiedBy.Valid {
-			s := verifiedBy.String
-			ref.VerifiedBy = &s
-		}
-		if rejectionNote.Valid {
-			s := rejectionNote.String
-			ref.RejectionNote = &s
-		}
+// achievementReferenceWhere menyusun klausa WHERE untuk AchievementFilter
+func achievementReferenceWhere(f model.AchievementFilter) *whereBuilder {
+	w := &whereBuilder{}
 
-		list = append(list, ref)
+	// scope dicek lewat keanggotaan tim sehingga prestasi tim ikut tampil
+	// untuk setiap anggota (beserta dosen wali / departemennya)
+	if f.StudentID != "" {
+		w.add(memberExists+" AND m.student_id = ?)", f.StudentID)
+	}
+	if f.AdvisorID != "" {
+		// termasuk pengajuan yang dipertahankan dosen ini setelah dosen wali mahasiswanya diganti
+		w.add("("+memberExists+" AND ms.advisor_id = ?) OR ar.reviewer_id = ?)", f.AdvisorID, f.AdvisorID)
+	}
+	if f.Department != "" {
+		w.add(memberExists+" AND LOWER(ms.program_study) = LOWER(?))", f.Department)
 	}
-	return list, rows.Err()
-}
 
-// Ambil daftar reference milik mahasiswa yang dibimbing dosen tertentu
-func GetAchievementReferencesByAdvisor(lecturerID string) ([]model.AchievementReference, error) {
-	// join students untuk filter advisor_id
-	query := `
-		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status,
-		       ar.submitted_at, ar.verified_at, ar.verified_by, ar.rejection_note,
-		       ar.created_at, ar.updated_at
-		FROM achievement_references ar
-		JOIN students s ON s.id = ar.student_id
-		WHERE s.advisor_id = $1
-		  AND ar.status <> 'deleted'
-		ORDER BY ar.created_at DESC;
-	`
+	if len(f.Statuses) > 0 {
+		w.add("ar.status = ANY(?)", pq.Array(f.Statuses))
+	} else if !f.IncludeDeleted {
+		w.add("ar.status <> ?", model.StatusDeleted)
+	}
 
-	rows, err := database.DB.Query(query, lecturerID)
-	if err != nil {
-		return nil, err
+	if f.MongoIDs != nil {
+		w.add("ar.mongo_achievement_id = ANY(?)", pq.Array(f.MongoIDs))
 	}
-	defer rows.Close()
+	if f.From != nil {
+		w.add("ar.created_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		w.add("ar.created_at < ?", *f.To)
+	}
+	if f.ProgramStudy != "" {
+		w.add("LOWER(s.program_study) = LOWER(?)", f.ProgramStudy)
+	}
+	if f.AcademicYear != "" {
+		w.add("s.academic_year = ?", f.AcademicYear)
+	}
+
+	return w
+}
 
+// scanAchievementReferences membaca seluruh baris hasil query reference
+func scanAchievementReferences(rows *sql.Rows) ([]model.AchievementReference, error) {
 	var list []model.AchievementReference
 	for rows.Next() {
 		var ref model.AchievementReference
 		var submittedAt, verifiedAt sql.NullTime
-		var verifiedBy, rejectionNote sql.NullString
+		var verifiedBy, rejectionNote, reviewerID sql.NullString
+		var ruleVersion sql.NullInt64
 
 		if err := rows.Scan(
 			&ref.ID,
@@ -243,6 +223,9 @@ func GetAchievementReferencesByAdvisor(lecturerID string) ([]model.AchievementRe
 			&verifiedAt,
 			&verifiedBy,
 			&rejectionNote,
+			&ref.Points,
+			&ruleVersion,
+			&reviewerID,
 			&ref.CreatedAt,
 			&ref.UpdatedAt,
 		); err != nil {
@@ -265,6 +248,14 @@ func GetAchievementReferencesByAdvisor(lecturerID string) ([]model.AchievementRe
 			s := rejectionNote.String
 			ref.RejectionNote = &s
 		}
+		if ruleVersion.Valid {
+			v := int(ruleVersion.Int64)
+			ref.ScoringRuleVersion = &v
+		}
+		if reviewerID.Valid {
+			s := reviewerID.String
+			ref.ReviewerID = &s
+		}
 
 		list = append(list, ref)
 	}
@@ -272,7 +263,7 @@ func GetAchievementReferencesByAdvisor(lecturerID string) ([]model.AchievementRe
 }
 
 // Mengecek apakah mahasiswa dibimbing oleh dosen wali tertentu
-func IsStudentAdvisedBy(lecturerID, studentID string) (bool, error) {
+var IsStudentAdvisedBy = func(lecturerID, studentID string) (bool, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM students
@@ -288,70 +279,254 @@ func IsStudentAdvisedBy(lecturerID, studentID string) (bool, error) {
 	return count > 0, nil
 }
 
-// Update status umum
-func UpdateAchievementStatus(id, status string) error {
+// ErrStatusConflict dikembalikan saat status reference sudah berubah
+// (misalnya diproses request lain) sehingga transisi tidak bisa dilakukan.
+var ErrStatusConflict = errors.New("status achievement sudah berubah")
+
+// transitionAchievementStatus mengubah status dari "from" ke "to" secara atomik
+// dan mencatat riwayatnya. extraSet berisi kolom tambahan dengan placeholder
+// mulai dari $4 sesuai urutan extraArgs.
+func transitionAchievementStatus(id, from, to, actorID string, note *string, extraSet string, extraArgs ...any) error {
+	return transitionAchievementStatusWith(nil, id, from, to, actorID, note, extraSet, extraArgs...)
+}
+
+// transitionAchievementStatusWith sama seperti transitionAchievementStatus, dengan
+// after dijalankan di transaksi yang sama setelah status berhasil diubah
+func transitionAchievementStatusWith(after func(tx *sql.Tx) error, id, from, to, actorID string, note *string, extraSet string, extraArgs ...any) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE achievement_references
 		SET status = $1,
-		    updated_at = NOW()
-		WHERE id = $2;
+		    updated_at = NOW()` + extraSet + `
+		WHERE id = $2
+		  AND status = $3;
 	`
-	_, err := database.DB.Exec(query, status, id)
+
+	args := append([]any{to, id, from}, extraArgs...)
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return ErrStatusConflict
+	}
+
+	if err := insertStatusHistory(tx, id, &from, to, actorID, note); err != nil {
+		return err
+	}
+
+	if after != nil {
+		if err := after(tx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertStatusHistory(tx *sql.Tx, refID string, from *string, to, actorID string, note *string) error {
+	var actor *string
+	if actorID != "" {
+		actor = &actorID
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO achievement_status_history (
+			id, achievement_ref_id, from_status, to_status, actor_id, note, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW());
+	`, uuid.NewString(), refID, from, to, actor, note)
 	return err
 }
 
-// SubmitAchievement
-func SubmitAchievementReference(id string) error {
-	now := time.Now()
+// SubmitAchievement: draft → submitted. Pengajuan ulang selalu ditinjau
+// dosen wali saat ini, bukan dosen wali lama yang mempertahankan pengajuan sebelumnya.
+func SubmitAchievementReference(id, actorID string) error {
+	return transitionAchievementStatus(
+		id, model.StatusDraft, model.StatusSubmitted, actorID, nil,
+		`,
+		    submitted_at = $4,
+		    reviewer_id = NULL`,
+		time.Now(),
+	)
+}
+
+// VerifyAchievement: submitted → verified, sekaligus menyimpan poin
+// beserta versi aturan skor yang dipakai menghitungnya. Poin adalah bagian
+// per anggota dan ikut dicatat pada setiap anggota tim yang sudah konfirmasi.
+func VerifyAchievementReference(id, verifierUserID string, points, ruleVersion int) error {
+	return transitionAchievementStatusWith(
+		func(tx *sql.Tx) error {
+			_, err := tx.Exec(`
+				UPDATE achievement_members
+				SET points = $2,
+				    verified_at = COALESCE(verified_at, NOW()),
+				    verified_by = COALESCE(verified_by, $3)
+				WHERE achievement_ref_id = $1
+				  AND status = 'confirmed';
+			`, id, points, verifierUserID)
+			return err
+		},
+		id, model.StatusSubmitted, model.StatusVerified, verifierUserID, nil,
+		`,
+		    verified_at = $4,
+		    verified_by = $5,
+		    points = $6,
+		    scoring_rule_version = $7`,
+		time.Now(), verifierUserID, points, ruleVersion,
+	)
+}
+
+// Ambil semua reference terverifikasi (untuk simulasi perubahan aturan skor)
+func GetVerifiedAchievementReferences() ([]model.AchievementReference, error) {
 	query := `
-		UPDATE achievement_references
-		SET status = 'submitted',
-		    submitted_at = $1,
-		    updated_at = NOW()
-		WHERE id = $2;
+		SELECT id, student_id, mongo_achievement_id, status,
+		       submitted_at, verified_at, verified_by, rejection_note,
+		       points, scoring_rule_version, reviewer_id,
+		       created_at, updated_at
+		FROM achievement_references
+		WHERE status = 'verified'
+		ORDER BY verified_at;
 	`
-	_, err := database.DB.Exec(query, now, id)
-	return err
+
+	rows, err := database.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAchievementReferences(rows)
+}
+
+// RejectAchievement: submitted → rejected. Verifikasi per anggota yang
+// sudah terlanjur dilakukan ikut dibatalkan.
+var RejectAchievementReference = func(id, verifierUserID, note string) error {
+	return transitionAchievementStatusWith(
+		resetMemberVerification(id),
+		id, model.StatusSubmitted, model.StatusRejected, verifierUserID, &note,
+		`,
+		    verified_at = $4,
+		    verified_by = $5,
+		    rejection_note = $6`,
+		time.Now(), verifierUserID, note,
+	)
+}
+
+// ReviseAchievement: rejected → draft (mahasiswa memperbaiki prestasi yang ditolak)
+func ReviseAchievementReference(id, actorID string, note *string) error {
+	return transitionAchievementStatus(id, model.StatusRejected, model.StatusDraft, actorID, note, "")
+}
+
+// Soft delete: status sekarang → deleted
+func SoftDeleteAchievementReference(id, from, actorID string) error {
+	return transitionAchievementStatus(id, from, model.StatusDeleted, actorID, nil, "")
+}
+
+// Reference yang dokumen MongoDB-nya hilang: status sekarang → deleted,
+// dengan catatan di riwayat bahwa penghapusan berasal dari rekonsiliasi
+func DeleteDanglingAchievementReference(id, from, actorID string) error {
+	note := "Dokumen MongoDB tidak ditemukan (rekonsiliasi)"
+	return transitionAchievementStatus(id, from, model.StatusDeleted, actorID, &note, "")
 }
 
-// VerifyAchievement
-func VerifyAchievementReference(id, verifierUserID string) error {
-	now := time.Now()
+// Semua reference termasuk yang sudah dihapus (untuk rekonsiliasi)
+var GetAllAchievementReferencesIncludingDeleted = func() ([]model.AchievementReference, error) {
 	query := `
-		UPDATE achievement_references
-		SET status = 'verified',
-		    verified_at = $1,
-		    verified_by = $2,
-		    updated_at = NOW()
-		WHERE id = $3;
+		SELECT id, student_id, mongo_achievement_id, status,
+		       submitted_at, verified_at, verified_by, rejection_note,
+		       points, scoring_rule_version, reviewer_id,
+		       created_at, updated_at
+		FROM achievement_references
+		ORDER BY created_at;
 	`
-	_, err := database.DB.Exec(query, now, verifierUserID, id)
-	return err
+
+	rows, err := database.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAchievementReferences(rows)
 }
 
-func RejectAchievementReference(id, verifierUserID, note string) error {
-	now := time.Now()
+// Ambil reference (selain yang sudah dihapus) berdasarkan daftar ID dokumen MongoDB
+var GetAchievementReferencesByMongoIDs = func(mongoIDs []string) ([]model.AchievementReference, error) {
+	if len(mongoIDs) == 0 {
+		return nil, nil
+	}
+
 	query := `
-		UPDATE achievement_references
-		SET status = 'rejected',
-		    verified_at = $1,
-		    verified_by = $2,
-		    rejection_note = $3,
-		    updated_at = NOW()
-		WHERE id = $4;
+		SELECT id, student_id, mongo_achievement_id, status,
+		       submitted_at, verified_at, verified_by, rejection_note,
+		       points, scoring_rule_version, reviewer_id,
+		       created_at, updated_at
+		FROM achievement_references
+		WHERE mongo_achievement_id = ANY($1)
+		  AND status <> 'deleted';
 	`
-	_, err := database.DB.Exec(query, now, verifierUserID, note, id)
-	return err
+
+	rows, err := database.DB.Query(query, pq.Array(mongoIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAchievementReferences(rows)
 }
 
-// Soft delete: status deleted
-func SoftDeleteAchievementReference(id string) error {
+// Riwayat transisi status satu reference, urut dari yang paling lama
+func GetAchievementStatusHistory(refID string) ([]model.AchievementStatusHistory, error) {
 	query := `
-		UPDATE achievement_references
-		SET status = 'deleted',
-		    updated_at = NOW()
-		WHERE id = $1;
+		SELECT id, achievement_ref_id, from_status, to_status, actor_id, note, created_at
+		FROM achievement_status_history
+		WHERE achievement_ref_id = $1
+		ORDER BY created_at, seq;
 	`
-	_, err := database.DB.Exec(query, id)
-	return err
-}
\ No newline at end of file
+
+	rows, err := database.DB.Query(query, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.AchievementStatusHistory
+	for rows.Next() {
+		var h model.AchievementStatusHistory
+		var from, actor, note sql.NullString
+
+		if err := rows.Scan(
+			&h.ID,
+			&h.AchievementRefID,
+			&from,
+			&h.ToStatus,
+			&actor,
+			&note,
+			&h.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if from.Valid {
+			v := from.String
+			h.FromStatus = &v
+		}
+		if actor.Valid {
+			v := actor.String
+			h.ActorID = &v
+		}
+		if note.Valid {
+			v := note.String
+			h.Note = &v
+		}
+
+		list = append(list, h)
+	}
+	return list, rows.Err()
+}
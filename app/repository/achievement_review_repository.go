@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =====================================
+// ACHIEVEMENT REVIEWS (MongoDB)
+// =====================================
+
+func getAchievementReviewCollection() (*mongo.Collection, error) {
+	if database.MongoDB == nil {
+		return nil, errors.New("MongoDB belum terkoneksi – panggil ConnectMongo() di main.go")
+	}
+	return database.MongoDB.Collection("achievement_reviews"), nil
+}
+
+// Simpan satu ronde review beserta snapshot dokumen
+func CreateAchievementReview(review *model.AchievementReview) error {
+	coll, err := getAchievementReviewCollection()
+	if err != nil {
+		return err
+	}
+
+	if review.ReviewedAt.IsZero() {
+		review.ReviewedAt = time.Now()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = coll.InsertOne(ctx, review)
+	return err
+}
+
+// Ambil semua ronde review satu achievement (urut ronde)
+func GetAchievementReviews(achievementID string) ([]model.AchievementReview, error) {
+	coll, err := getAchievementReviewCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "round", Value: 1}})
+	cur, err := coll.Find(ctx, bson.M{"achievementId": achievementID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.AchievementReview
+	for cur.Next(ctx) {
+		var r model.AchievementReview
+		if err := cur.Decode(&r); err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, cur.Err()
+}
+
+// Ambil review terakhir (nil jika belum pernah direview)
+func GetLatestAchievementReview(achievementID string) (*model.AchievementReview, error) {
+	coll, err := getAchievementReviewCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "round", Value: -1}})
+
+	var r model.AchievementReview
+	err = coll.FindOne(ctx, bson.M{"achievementId": achievementID}, opts).Decode(&r)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =====================================
+// ACHIEVEMENT TYPES (MongoDB)
+// =====================================
+
+func getAchievementTypeCollection() (*mongo.Collection, error) {
+	if database.MongoDB == nil {
+		return nil, errors.New("MongoDB belum terkoneksi – panggil ConnectMongo() di main.go")
+	}
+	return database.MongoDB.Collection("achievement_types"), nil
+}
+
+// Ambil semua jenis prestasi (opsional hanya yang aktif)
+func GetAchievementTypes(activeOnly bool) ([]model.AchievementType, error) {
+	coll, err := getAchievementTypeCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if activeOnly {
+		filter["active"] = true
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+	cur, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []model.AchievementType
+	for cur.Next(ctx) {
+		var t model.AchievementType
+		if err := cur.Decode(&t); err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, cur.Err()
+}
+
+// Ambil jenis prestasi berdasarkan code (mongo.ErrNoDocuments jika tidak ada)
+var GetAchievementTypeByCode = func(code string) (*model.AchievementType, error) {
+	coll, err := getAchievementTypeCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var t model.AchievementType
+	if err := coll.FindOne(ctx, bson.M{"code": code}).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Insert jenis prestasi baru
+func CreateAchievementType(t *model.AchievementType) error {
+	coll, err := getAchievementTypeCollection()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = coll.InsertOne(ctx, t)
+	return err
+}
+
+// Update skema jenis prestasi berdasarkan code
+func UpdateAchievementType(code string, t *model.AchievementType) error {
+	coll, err := getAchievementTypeCollection()
+	if err != nil {
+		return err
+	}
+
+	t.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := coll.UpdateOne(ctx, bson.M{"code": code}, bson.M{"$set": bson.M{
+		"name":        t.Name,
+		"description": t.Description,
+		"fields":      t.Fields,
+		"allowExtra":  t.AllowExtra,
+		"active":      t.Active,
+		"updatedAt":   t.UpdatedAt,
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Nonaktifkan jenis prestasi. Dokumen tetap ada agar prestasi lama masih bisa divalidasi.
+func DeactivateAchievementType(code string) error {
+	coll, err := getAchievementTypeCollection()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := coll.UpdateOne(ctx, bson.M{"code": code}, bson.M{"$set": bson.M{
+		"active":    false,
+		"updatedAt": time.Now(),
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Seed skema bawaan. Jenis yang sudah ada (mungkin sudah diubah admin) tidak ditimpa.
+func SeedAchievementTypes(types []model.AchievementType) error {
+	coll, err := getAchievementTypeCollection()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, t := range types {
+		t.CreatedAt = now
+		t.UpdatedAt = now
+
+		_, err := coll.UpdateOne(ctx,
+			bson.M{"code": t.Code},
+			bson.M{"$setOnInsert": t},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper: cek apakah error karena dokumen Mongo tidak ditemukan
+func IsNoDocuments(err error) bool {
+	return errors.Is(err, mongo.ErrNoDocuments)
+}
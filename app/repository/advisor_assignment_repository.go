@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// =====================================
+// ADVISOR_ASSIGNMENTS (Postgre)
+// =====================================
+
+// Riwayat dosen wali satu mahasiswa, terbaru lebih dulu
+var GetAdvisorAssignments = func(studentID string) ([]model.AdvisorAssignment, error) {
+	query := `
+		SELECT id, student_id, lecturer_id, effective_from, effective_to,
+		       assigned_by, reason, pending_reviews, created_at
+		FROM advisor_assignments
+		WHERE student_id = $1
+		ORDER BY effective_from DESC, created_at DESC;
+	`
+
+	rows, err := database.DB.Query(query, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.AdvisorAssignment
+	for rows.Next() {
+		var a model.AdvisorAssignment
+		var effectiveTo sql.NullTime
+		var assignedBy, reason, pending sql.NullString
+
+		if err := rows.Scan(
+			&a.ID,
+			&a.StudentID,
+			&a.LecturerID,
+			&a.EffectiveFrom,
+			&effectiveTo,
+			&assignedBy,
+			&reason,
+			&pending,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if effectiveTo.Valid {
+			a.EffectiveTo = &effectiveTo.Time
+		}
+		if assignedBy.Valid {
+			a.AssignedBy = &assignedBy.String
+		}
+		if reason.Valid {
+			a.Reason = &reason.String
+		}
+		if pending.Valid {
+			a.PendingReviews = &pending.String
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+// Jumlah pengajuan submitted milik mahasiswa yang akan ditinjau dosen wali saat ini
+var CountPendingReviews = func(studentIDs []string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM achievement_references
+		WHERE student_id = ANY($1)
+		  AND status = 'submitted'
+		  AND reviewer_id IS NULL;
+	`
+
+	var n int
+	err := database.DB.QueryRow(query, pq.Array(studentIDs)).Scan(&n)
+	return n, err
+}
+
+// Jumlah pengajuan submitted yang tetap ditinjau dosen ini setelah dosen wali
+// mahasiswanya diganti (pending_reviews = keep)
+var CountReviewsKeptBy = func(lecturerID string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM achievement_references
+		WHERE reviewer_id = $1
+		  AND status = 'submitted';
+	`
+
+	var n int
+	err := database.DB.QueryRow(query, lecturerID).Scan(&n)
+	return n, err
+}
+
+// Mengganti dosen wali beberapa mahasiswa dalam satu transaksi. Bila
+// releaseReviewer diisi, pengajuan yang sebelumnya dipertahankan dosen itu
+// dilepas sehingga ikut ditinjau dosen wali mahasiswanya saat ini.
+var ReassignAdvisors = func(assignments []model.AdvisorAssignment, releaseReviewer string) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range assignments {
+		if err := assignAdvisor(tx, &assignments[i]); err != nil {
+			return err
+		}
+	}
+
+	if releaseReviewer != "" {
+		_, err := tx.Exec(`
+			UPDATE achievement_references
+			SET reviewer_id = NULL
+			WHERE reviewer_id = $1
+			  AND status = 'submitted';
+		`, releaseReviewer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// assignAdvisor menutup penugasan yang masih berlaku, membuka penugasan baru,
+// lalu mengubah students.advisor_id. Dengan pending_reviews = keep, pengajuan
+// yang belum ditinjau di-pin ke dosen wali lama. Tidak mengubah apa pun bila
+// dosen walinya sama.
+func assignAdvisor(tx *sql.Tx, a *model.AdvisorAssignment) error {
+	var current sql.NullString
+	err := tx.QueryRow(`SELECT advisor_id FROM students WHERE id = $1 FOR UPDATE;`, a.StudentID).Scan(&current)
+	if err != nil {
+		return err
+	}
+	if current.String == a.LecturerID {
+		return nil
+	}
+
+	_, err = tx.Exec(`
+		UPDATE advisor_assignments
+		SET effective_to = $2
+		WHERE student_id = $1
+		  AND effective_to IS NULL;
+	`, a.StudentID, a.EffectiveFrom)
+	if err != nil {
+		return err
+	}
+
+	if err := insertAdvisorAssignment(tx, a); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE students
+		SET advisor_id = $2,
+		    updated_at = NOW()
+		WHERE id = $1;
+	`, a.StudentID, a.LecturerID)
+	if err != nil {
+		return err
+	}
+
+	if current.Valid && a.PendingReviews != nil && *a.PendingReviews == model.PendingReviewsKeep {
+		_, err = tx.Exec(`
+			UPDATE achievement_references
+			SET reviewer_id = $2
+			WHERE student_id = $1
+			  AND status = 'submitted'
+			  AND reviewer_id IS NULL;
+		`, a.StudentID, current.String)
+	}
+	return err
+}
+
+// insertAdvisorAssignment menyimpan satu baris riwayat dosen wali
+func insertAdvisorAssignment(ex execer, a *model.AdvisorAssignment) error {
+	query := `
+		INSERT INTO advisor_assignments (
+			id, student_id, lecturer_id, effective_from,
+			assigned_by, reason, pending_reviews, created_at
+		)
+		VALUES ($1, $2, $3, $4,
+		        $5, $6, $7, NOW());
+	`
+	_, err := ex.Exec(query, a.ID, a.StudentID, a.LecturerID, a.EffectiveFrom, a.AssignedBy, a.Reason, a.PendingReviews)
+	return err
+}
+
+// initialAdvisorAssignment adalah riwayat pertama untuk profil mahasiswa baru yang langsung punya dosen wali
+func initialAdvisorAssignment(s *model.Student) *model.AdvisorAssignment {
+	return &model.AdvisorAssignment{
+		ID:            uuid.NewString(),
+		StudentID:     s.ID,
+		LecturerID:    s.AdvisorID,
+		EffectiveFrom: time.Now(),
+	}
+}
@@ -1,14 +1,17 @@
 package repository
 
 import (
+	"database/sql"
 	"prestasi_backend/app/model"
 	"prestasi_backend/database"
+
+	"github.com/lib/pq"
 )
 
 // ambil semua dosen
 func GetAllLecturers() ([]model.Lecturer, error) {
 	query := `
-		SELECT id, user_id, lecturer_id, department, created_at
+		SELECT id, user_id, lecturer_id, department, deactivated_at, created_at
 		FROM lecturers
 		ORDER BY lecturer_id;
 	`
@@ -19,61 +22,183 @@ func GetAllLecturers() ([]model.Lecturer, error) {
 	}
 	defer rows.Close()
 
-	var list []model.Lecturer
-	for rows.Next() {
-		var l model.Lecturer
-		if err := rows.Scan(
-			&l.ID,
-			&l.UserID,
-			&l.LecturerID,
-			&l.Department,
-			&l.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		list = append(list, l)
-	}
-	return list, rows.Err()
+	return scanLecturers(rows)
 }
 
 // ambil dosen by ID
-func GetLecturerByID(id string) (*model.Lecturer, error) {
+var GetLecturerByID = func(id string) (*model.Lecturer, error) {
 	query := `
-		SELECT id, user_id, lecturer_id, department, created_at
+		SELECT id, user_id, lecturer_id, department, deactivated_at, created_at
 		FROM lecturers
 		WHERE id = $1;
 	`
-	var l model.Lecturer
-	err := database.DB.QueryRow(query, id).Scan(
-		&l.ID,
-		&l.UserID,
-		&l.LecturerID,
-		&l.Department,
-		&l.CreatedAt,
-	)
+	return scanLecturer(database.DB.QueryRow(query, id))
+}
+
+// mapping JWT → dosen
+var GetLecturerByUserID = func(userID string) (*model.Lecturer, error) {
+	query := `
+		SELECT id, user_id, lecturer_id, department, deactivated_at, created_at
+		FROM lecturers
+		WHERE user_id = $1;
+	`
+	return scanLecturer(database.DB.QueryRow(query, userID))
+}
+
+// ambil dosen berdasarkan daftar NIDN (untuk impor massal)
+var GetLecturersByLecturerIDs = func(lecturerIDs []string) ([]model.Lecturer, error) {
+	query := `
+		SELECT id, user_id, lecturer_id, department, deactivated_at, created_at
+		FROM lecturers
+		WHERE lecturer_id = ANY($1);
+	`
+
+	rows, err := database.DB.Query(query, pq.Array(lecturerIDs))
 	if err != nil {
 		return nil, err
 	}
-	return &l, nil
+	defer rows.Close()
+
+	return scanLecturers(rows)
 }
 
-// mapping JWT → dosen
-func GetLecturerByUserID(userID string) (*model.Lecturer, error) {
+// insertLecturer menyimpan profil dosen baru (di DB langsung atau dalam transaksi)
+func insertLecturer(ex execer, l *model.Lecturer) error {
+	query := `
+		INSERT INTO lecturers (id, user_id, lecturer_id, department, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW());
+	`
+	_, err := ex.Exec(query, l.ID, l.UserID, l.LecturerID, l.Department)
+	return err
+}
+
+// Buat profil dosen untuk user yang sudah ada
+func CreateLecturer(l *model.Lecturer) error {
+	return insertLecturer(database.DB, l)
+}
+
+// Update data profil dosen
+func UpdateLecturer(l *model.Lecturer) error {
+	return updateLecturer(database.DB, l)
+}
+
+func updateLecturer(ex execer, l *model.Lecturer) error {
+	query := `
+		UPDATE lecturers
+		SET lecturer_id = $1,
+		    department = $2,
+		    updated_at = NOW()
+		WHERE id = $3;
+	`
+	res, err := ex.Exec(query, l.LecturerID, l.Department, l.ID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Aktifkan / nonaktifkan profil dosen
+func SetLecturerActive(id string, active bool) error {
+	return setProfileActive("lecturers", id, active)
+}
+
+// Jumlah mahasiswa aktif yang masih dibimbing dosen
+var CountActiveAdvisees = func(lecturerID string) (int, error) {
+	query := `SELECT COUNT(*) FROM students WHERE advisor_id = $1 AND deactivated_at IS NULL;`
+
+	var n int
+	err := database.DB.QueryRow(query, lecturerID).Scan(&n)
+	return n, err
+}
+
+// ambil dosen dalam satu departemen (scope department)
+func GetLecturersByDepartment(department string) ([]model.Lecturer, error) {
 	query := `
-		SELECT id, user_id, lecturer_id, department, created_at
+		SELECT id, user_id, lecturer_id, department, deactivated_at, created_at
 		FROM lecturers
-		WHERE user_id = $1;
+		WHERE LOWER(department) = LOWER($1)
+		ORDER BY lecturer_id;
 	`
+
+	rows, err := database.DB.Query(query, department)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanLecturers(rows)
+}
+
+
+// scanLecturer membaca satu baris dosen (urutan kolom seperti query di atas)
+func scanLecturer(row rowScanner) (*model.Lecturer, error) {
 	var l model.Lecturer
-	err := database.DB.QueryRow(query, userID).Scan(
+	var deactivatedAt sql.NullTime
+
+	if err := row.Scan(
 		&l.ID,
 		&l.UserID,
 		&l.LecturerID,
 		&l.Department,
+		&deactivatedAt,
 		&l.CreatedAt,
-	)
-	if err != nil {
+	); err != nil {
 		return nil, err
 	}
+
+	if deactivatedAt.Valid {
+		l.DeactivatedAt = &deactivatedAt.Time
+	}
 	return &l, nil
-}
\ No newline at end of file
+}
+
+// scanLecturers membaca seluruh baris hasil query dosen
+func scanLecturers(rows *sql.Rows) ([]model.Lecturer, error) {
+	var list []model.Lecturer
+	for rows.Next() {
+		l, err := scanLecturer(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *l)
+	}
+	return list, rows.Err()
+}
+
+var lecturerList = listSpec[model.Lecturer]{
+	columns:  `id, user_id, lecturer_id, department, deactivated_at, created_at`,
+	from:     `lecturers`,
+	idColumn: "id",
+	sorts: map[string]sortField[model.Lecturer]{
+		"lecturer_id": {"lecturer_id", "text", func(l model.Lecturer) any { return l.LecturerID }},
+		"department":  {"department", "text", func(l model.Lecturer) any { return l.Department }},
+		"created_at":  {"created_at", "timestamp", func(l model.Lecturer) any { return l.CreatedAt }},
+	},
+	defaultSort: "lecturer_id",
+	id:          func(l model.Lecturer) string { return l.ID },
+	scan:        scanLecturers,
+}
+
+// List dosen dengan filter, sort, dan paginasi
+func ListLecturers(f model.LecturerFilter, q model.ListQuery) ([]model.Lecturer, model.PageInfo, error) {
+	w := &whereBuilder{}
+
+	if f.Department != "" {
+		w.add("LOWER(department) = LOWER(?)", f.Department)
+	}
+	if f.IsActive != nil {
+		if *f.IsActive {
+			w.add("deactivated_at IS NULL")
+		} else {
+			w.add("deactivated_at IS NOT NULL")
+		}
+	}
+	if f.Search != "" {
+		w.add("lecturer_id ILIKE ?", "%"+f.Search+"%")
+	}
+
+	return runList(lecturerList, w, q)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+)
+
+// ErrInvalidCursor dikembalikan saat cursor rusak atau dibuat untuk sort lain
+var ErrInvalidCursor = errors.New("cursor tidak valid")
+
+// ErrInvalidSort dikembalikan saat kolom sort tidak diizinkan untuk list tersebut
+var ErrInvalidSort = errors.New("sort tidak dikenal")
+
+// =====================================
+// SHARED LIST QUERY (Postgre)
+// =====================================
+
+// sortField memetakan nama sort di query string ke kolom SQL.
+// cast dipakai untuk membandingkan nilai cursor (text) dengan kolom.
+type sortField[T any] struct {
+	column string
+	cast   string
+	value  func(T) any
+}
+
+// listSpec mendeskripsikan satu list: kolom, sumber tabel, dan sort yang diizinkan
+type listSpec[T any] struct {
+	columns     string
+	from        string
+	idColumn    string
+	sorts       map[string]sortField[T]
+	defaultSort string
+	id          func(T) string
+	scan        func(*sql.Rows) ([]T, error)
+}
+
+// whereBuilder menyusun klausa WHERE dengan placeholder "?" yang
+// diubah menjadi $n sesuai urutan argumen
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+func (w *whereBuilder) add(cond string, args ...any) {
+	for _, a := range args {
+		w.args = append(w.args, a)
+		cond = strings.Replace(cond, "?", "$"+strconv.Itoa(len(w.args)), 1)
+	}
+	w.conds = append(w.conds, cond)
+}
+
+func (w *whereBuilder) sql() string {
+	if len(w.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conds, " AND ")
+}
+
+type listCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func cursorValue(v any) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case int:
+		return strconv.Itoa(t)
+	case string:
+		return t
+	}
+	return ""
+}
+
+// runList menjalankan COUNT dan SELECT ber-paginasi untuk spec yang diberikan.
+// Baris diambil limit+1 untuk mengetahui apakah masih ada halaman berikutnya.
+func runList[T any](spec listSpec[T], w *whereBuilder, q model.ListQuery) ([]T, model.PageInfo, error) {
+	sortKey := q.Sort
+	if sortKey == "" {
+		sortKey = spec.defaultSort
+	}
+	field, ok := spec.sorts[sortKey]
+	if !ok {
+		return nil, model.PageInfo{}, fmt.Errorf("%w: %s", ErrInvalidSort, sortKey)
+	}
+
+	order := strings.ToLower(q.Order)
+	if order != "asc" {
+		order = "desc"
+	}
+
+	info := model.PageInfo{Limit: q.Limit, Sort: sortKey, Order: order}
+
+	// cursor divalidasi sebelum menyentuh database
+	var cur listCursor
+	if q.Cursor != "" {
+		var err error
+		if cur, err = decodeCursor(q.Cursor); err != nil {
+			return nil, info, err
+		}
+		if cur.Sort != sortKey || cur.Order != order {
+			return nil, info, ErrInvalidCursor
+		}
+	}
+
+	if err := database.DB.QueryRow(`SELECT COUNT(*) FROM `+spec.from+w.sql(), w.args...).Scan(&info.Total); err != nil {
+		return nil, info, err
+	}
+
+	page := *w
+	offset := ""
+
+	if q.Cursor != "" {
+		cmp := "<"
+		if order == "asc" {
+			cmp = ">"
+		}
+		page.conds = append([]string(nil), w.conds...)
+		page.args = append([]any(nil), w.args...)
+		page.add("("+field.column+", "+spec.idColumn+") "+cmp+" (?::"+field.cast+", ?)", cur.Value, cur.ID)
+	} else {
+		if q.Page < 1 {
+			q.Page = 1
+		}
+		info.Page = q.Page
+		offset = " OFFSET " + strconv.Itoa((q.Page-1)*q.Limit)
+	}
+
+	query := `SELECT ` + spec.columns + ` FROM ` + spec.from + page.sql() +
+		` ORDER BY ` + field.column + ` ` + order + `, ` + spec.idColumn + ` ` + order +
+		` LIMIT ` + strconv.Itoa(q.Limit+1) + offset
+
+	rows, err := database.DB.Query(query, page.args...)
+	if err != nil {
+		return nil, info, err
+	}
+	defer rows.Close()
+
+	items, err := spec.scan(rows)
+	if err != nil {
+		return nil, info, err
+	}
+
+	if len(items) > q.Limit {
+		items = items[:q.Limit]
+		info.HasMore = true
+
+		last := items[len(items)-1]
+		info.NextCursor = encodeCursor(listCursor{
+			Sort:  sortKey,
+			Order: order,
+			Value: cursorValue(field.value(last)),
+			ID:    spec.id(last),
+		})
+	}
+
+	return items, info, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"prestasi_backend/app/model"
 	"prestasi_backend/database"
 )
@@ -37,7 +38,7 @@ func GetAllPermissions() ([]model.Permission, error) {
 }
 
 // GetPermissionByID mengambil izin berdasarkan UUID
-func GetPermissionByID(id string) (*model.Permission, error) {
+var GetPermissionByID = func(id string) (*model.Permission, error) {
 	query := `
 		SELECT id, name, resource, action, description
 		FROM permissions
@@ -78,4 +79,62 @@ func GetPermissionByName(name string) (*model.Permission, error) {
 		return nil, err
 	}
 	return &p, nil
-}
\ No newline at end of file
+}
+
+// CreatePermission menambahkan izin baru
+var CreatePermission = func(p *model.Permission) error {
+	query := `
+		INSERT INTO permissions (id, name, resource, action, description)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+	_, err := database.DB.Exec(query, p.ID, p.Name, p.Resource, p.Action, p.Description)
+	return err
+}
+
+// UpdatePermission memperbarui data izin
+var UpdatePermission = func(p *model.Permission) error {
+	query := `
+		UPDATE permissions
+		SET name = $1,
+		    resource = $2,
+		    action = $3,
+		    description = $4
+		WHERE id = $5;
+	`
+
+	res, err := database.DB.Exec(query, p.Name, p.Resource, p.Action, p.Description, p.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeletePermission menghapus izin beserta relasinya ke semua peran
+var DeletePermission = func(id string) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM role_permissions WHERE permission_id = $1;`, id); err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`DELETE FROM permissions WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
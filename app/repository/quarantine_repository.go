@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+)
+
+// =====================================
+// ATTACHMENT QUARANTINE (MongoDB)
+// =====================================
+
+// Catat file yang ditolak pemindai ke koleksi attachment_quarantine
+var InsertQuarantinedAttachment = func(q *model.QuarantinedAttachment) error {
+	if database.MongoDB == nil {
+		return errors.New("MongoDB belum terkoneksi – panggil ConnectMongo() di main.go")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	q.CreatedAt = time.Now()
+	_, err := database.MongoDB.Collection("attachment_quarantine").InsertOne(ctx, q)
+	return err
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+	"time"
+)
+
+// ErrRefreshTokenReused dikembalikan saat token yang sudah dirotasi dipakai lagi
+var ErrRefreshTokenReused = errors.New("refresh token sudah pernah dipakai")
+
+// =====================================
+// REFRESH TOKENS (Postgre)
+// =====================================
+
+// Simpan refresh token baru
+var CreateRefreshToken = func(t *model.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (
+			id, user_id, token_hash, family_id, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NOW());
+	`
+	_, err := database.DB.Exec(query, t.ID, t.UserID, t.TokenHash, t.FamilyID, t.ExpiresAt)
+	return err
+}
+
+// Ambil refresh token berdasarkan hash
+var GetRefreshTokenByHash = func(hash string) (*model.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, family_id, replaced_by,
+		       expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1;
+	`
+
+	var t model.RefreshToken
+	var replacedBy sql.NullString
+	var revokedAt sql.NullTime
+
+	err := database.DB.QueryRow(query, hash).Scan(
+		&t.ID,
+		&t.UserID,
+		&t.TokenHash,
+		&t.FamilyID,
+		&replacedBy,
+		&t.ExpiresAt,
+		&revokedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if replacedBy.Valid {
+		s := replacedBy.String
+		t.ReplacedBy = &s
+	}
+	if revokedAt.Valid {
+		rt := revokedAt.Time
+		t.RevokedAt = &rt
+	}
+	return &t, nil
+}
+
+// Rotasi: tandai token lama sudah dipakai dan simpan penggantinya dalam satu transaksi.
+// Jika token lama ternyata sudah dicabut (dipakai request lain), kembalikan ErrRefreshTokenReused.
+var RotateRefreshToken = func(oldID string, next *model.RefreshToken) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO refresh_tokens (
+			id, user_id, token_hash, family_id, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NOW());
+	`, next.ID, next.UserID, next.TokenHash, next.FamilyID, next.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`
+		UPDATE refresh_tokens
+		SET revoked_at = NOW(),
+		    replaced_by = $1
+		WHERE id = $2
+		  AND revoked_at IS NULL;
+	`, next.ID, oldID)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return ErrRefreshTokenReused
+	}
+
+	return tx.Commit()
+}
+
+// Cabut semua token dalam satu family (logout / deteksi reuse)
+var RevokeRefreshTokenFamily = func(familyID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE family_id = $1
+		  AND revoked_at IS NULL;
+	`
+	_, err := database.DB.Exec(query, familyID)
+	return err
+}
+
+// Cek apakah family sudah dicabut (logout / deteksi reuse). Token yang dicabut
+// tanpa pengganti hanya muncul dari RevokeRefreshTokenFamily / RevokeRefreshTokensByUser;
+// rotasi biasa selalu mengisi replaced_by.
+var IsRefreshTokenFamilyRevoked = func(familyID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM refresh_tokens
+			WHERE family_id = $1
+			  AND revoked_at IS NOT NULL
+			  AND replaced_by IS NULL
+		);
+	`
+
+	var revoked bool
+	err := database.DB.QueryRow(query, familyID).Scan(&revoked)
+	return revoked, err
+}
+
+// Cabut semua refresh token milik user
+var RevokeRefreshTokensByUser = func(userID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1
+		  AND revoked_at IS NULL;
+	`
+	_, err := database.DB.Exec(query, userID)
+	return err
+}
+
+// =====================================
+// REVOKED ACCESS TOKENS (Postgre)
+// =====================================
+
+// Catat jti access token yang dicabut sebelum kedaluwarsa
+var RevokeAccessToken = func(jti, userID string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_access_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (jti) DO NOTHING;
+	`
+	_, err := database.DB.Exec(query, jti, userID, expiresAt)
+	return err
+}
+
+// Cek apakah jti access token sudah dicabut
+var IsAccessTokenRevoked = func(jti string) (bool, error) {
+	query := `SELECT COUNT(*) FROM revoked_access_tokens WHERE jti = $1;`
+
+	var count int
+	if err := database.DB.QueryRow(query, jti).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Bersihkan catatan token yang sudah kedaluwarsa
+func PurgeExpiredTokens() error {
+	if _, err := database.DB.Exec(`DELETE FROM revoked_access_tokens WHERE expires_at < NOW();`); err != nil {
+		return err
+	}
+	_, err := database.DB.Exec(`DELETE FROM refresh_tokens WHERE expires_at < NOW();`)
+	return err
+}
@@ -35,14 +35,14 @@ func GetAchievementStats() ([]AchievementStats, error) {
 	return stats, nil
 }
 
-// GetStudentAchievementStats
+// GetStudentAchievementStats menghitung status prestasi mahasiswa, termasuk prestasi tim
 func GetStudentAchievementStats(studentID string) ([]AchievementStats, error) {
 	query := `
-		SELECT status, COUNT(*) as total
-		FROM achievement_references
-		WHERE student_id = $1
-		  AND status <> 'deleted'
-		GROUP BY status;
+		SELECT ar.status, COUNT(*) as total
+		FROM achievement_references ar
+		WHERE ` + memberExists + ` AND m.student_id = $1)
+		  AND ar.status <> 'deleted'
+		GROUP BY ar.status;
 	`
 
 	rows, err := database.DB.Query(query, studentID)
@@ -60,4 +60,46 @@ func GetStudentAchievementStats(studentID string) ([]AchievementStats, error) {
 		stats = append(stats, s)
 	}
 	return stats, nil
-}
\ No newline at end of file
+}
+
+// GetAdvisorAchievementStats menghitung status prestasi seluruh mahasiswa bimbingan dosen
+func GetAdvisorAchievementStats(lecturerID string) ([]AchievementStats, error) {
+	query := `
+		SELECT ar.status, COUNT(*) as total
+		FROM achievement_references ar
+		WHERE ` + memberExists + ` AND ms.advisor_id = $1)
+		  AND ar.status <> 'deleted'
+		GROUP BY ar.status;
+	`
+	return queryAchievementStats(query, lecturerID)
+}
+
+// GetDepartmentAchievementStats menghitung status prestasi mahasiswa satu program studi
+func GetDepartmentAchievementStats(department string) ([]AchievementStats, error) {
+	query := `
+		SELECT ar.status, COUNT(*) as total
+		FROM achievement_references ar
+		WHERE ` + memberExists + ` AND LOWER(ms.program_study) = LOWER($1))
+		  AND ar.status <> 'deleted'
+		GROUP BY ar.status;
+	`
+	return queryAchievementStats(query, department)
+}
+
+func queryAchievementStats(query string, args ...any) ([]AchievementStats, error) {
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []AchievementStats
+	for rows.Next() {
+		var s AchievementStats
+		if err := rows.Scan(&s.Status, &s.Total); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
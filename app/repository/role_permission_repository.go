@@ -1,6 +1,10 @@
 package repository
 
-import "prestasi_backend/database"
+import (
+	"database/sql"
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+)
 
 // ambil permissions by role ID
 var GetPermissionsByRoleID = func(roleID string) ([]string, error) {
@@ -28,8 +32,41 @@ var GetPermissionsByRoleID = func(roleID string) ([]string, error) {
 	return perms, rows.Err()
 }
 
+// ambil detail permission (bukan hanya nama) milik role
+var GetPermissionDetailsByRoleID = func(roleID string) ([]model.Permission, error) {
+	query := `
+		SELECT p.id, p.name, p.resource, p.action, p.description
+		FROM role_permissions rp
+		JOIN permissions p ON p.id = rp.permission_id
+		WHERE rp.role_id = $1
+		ORDER BY p.name;
+	`
+
+	rows, err := database.DB.Query(query, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.Permission
+	for rows.Next() {
+		var p model.Permission
+		if err := rows.Scan(
+			&p.ID,
+			&p.Name,
+			&p.Resource,
+			&p.Action,
+			&p.Description,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
 // Tambah relasi role-permission
-func AddPermissionToRole(roleID, permissionID string) error {
+var AddPermissionToRole = func(roleID, permissionID string) error {
 	query := `
 		INSERT INTO role_permissions (role_id, permission_id)
 		VALUES ($1, $2)
@@ -39,12 +76,20 @@ func AddPermissionToRole(roleID, permissionID string) error {
 	return err
 }
 
-// Hapus relasi role-permission
-func RemovePermissionFromRole(roleID, permissionID string) error {
+// Hapus relasi role-permission, sql.ErrNoRows bila relasinya tidak ada
+var RemovePermissionFromRole = func(roleID, permissionID string) error {
 	query := `
 		DELETE FROM role_permissions
 		WHERE role_id = $1 AND permission_id = $2;
 	`
-	_, err := database.DB.Exec(query, roleID, permissionID)
-	return err
+	res, err := database.DB.Exec(query, roleID, permissionID)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
@@ -1,12 +1,13 @@
 package repository
 
 import (
+	"database/sql"
 	"prestasi_backend/app/model"
 	"prestasi_backend/database"
 )
 
 // GetAllRoles mengambil semua peran yang ada
-func GetAllRoles() ([]model.Role, error) {
+var GetAllRoles = func() ([]model.Role, error) {
 	query := `
 		SELECT id, name, description, created_at
 		FROM roles
@@ -35,7 +36,7 @@ func GetAllRoles() ([]model.Role, error) {
 	return list, rows.Err()
 }
 
-func GetRoleByID(id string) (*model.Role, error) {
+var GetRoleByID = func(id string) (*model.Role, error) {
 	query := `
 		SELECT id, name, description, created_at
 		FROM roles
@@ -73,4 +74,69 @@ func GetRoleByName(name string) (*model.Role, error) {
 		return nil, err
 	}
 	return &r, nil
-}
\ No newline at end of file
+}
+
+// CreateRole menambahkan peran baru
+var CreateRole = func(r *model.Role) error {
+	query := `
+		INSERT INTO roles (id, name, description, created_at)
+		VALUES ($1, $2, $3, NOW());
+	`
+	_, err := database.DB.Exec(query, r.ID, r.Name, r.Description)
+	return err
+}
+
+// UpdateRole memperbarui nama dan deskripsi peran
+var UpdateRole = func(r *model.Role) error {
+	query := `
+		UPDATE roles
+		SET name = $1,
+		    description = $2
+		WHERE id = $3;
+	`
+
+	res, err := database.DB.Exec(query, r.Name, r.Description, r.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteRole menghapus peran beserta relasi permission-nya
+var DeleteRole = func(id string) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM role_permissions WHERE role_id = $1;`, id); err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`DELETE FROM roles WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// CountUsersByRole menghitung jumlah user yang masih memakai peran tertentu
+var CountUsersByRole = func(roleID string) (int, error) {
+	query := `SELECT COUNT(*) FROM users WHERE role_id = $1;`
+
+	var count int
+	err := database.DB.QueryRow(query, roleID).Scan(&count)
+	return count, err
+}
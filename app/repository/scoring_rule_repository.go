@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/database"
+
+	"github.com/google/uuid"
+)
+
+// =====================================
+// SCORING RULES (Postgre)
+// =====================================
+
+const scoringRuleColumns = `
+	id, version, description, rules, is_active, created_by, created_at, activated_at
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanScoringRule(row rowScanner) (*model.ScoringRule, error) {
+	var r model.ScoringRule
+	var rules []byte
+	var createdBy sql.NullString
+	var activatedAt sql.NullTime
+
+	if err := row.Scan(
+		&r.ID,
+		&r.Version,
+		&r.Description,
+		&rules,
+		&r.IsActive,
+		&createdBy,
+		&r.CreatedAt,
+		&activatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rules, &r.Rules); err != nil {
+		return nil, err
+	}
+	if createdBy.Valid {
+		s := createdBy.String
+		r.CreatedBy = &s
+	}
+	if activatedAt.Valid {
+		t := activatedAt.Time
+		r.ActivatedAt = &t
+	}
+	return &r, nil
+}
+
+// Ambil semua versi aturan skor (terbaru dulu)
+func GetScoringRules() ([]model.ScoringRule, error) {
+	rows, err := database.DB.Query(`SELECT ` + scoringRuleColumns + ` FROM scoring_rules ORDER BY version DESC;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.ScoringRule
+	for rows.Next() {
+		r, err := scanScoringRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *r)
+	}
+	return list, rows.Err()
+}
+
+// Ambil aturan skor berdasarkan nomor versi
+func GetScoringRuleByVersion(version int) (*model.ScoringRule, error) {
+	row := database.DB.QueryRow(`SELECT `+scoringRuleColumns+` FROM scoring_rules WHERE version = $1;`, version)
+	return scanScoringRule(row)
+}
+
+// Ambil aturan skor yang sedang aktif
+var GetActiveScoringRule = func() (*model.ScoringRule, error) {
+	row := database.DB.QueryRow(`SELECT ` + scoringRuleColumns + ` FROM scoring_rules WHERE is_active = TRUE;`)
+	return scanScoringRule(row)
+}
+
+// Simpan versi baru (belum aktif). Nomor versi = versi terakhir + 1.
+func CreateScoringRule(r *model.ScoringRule) error {
+	rules, err := json.Marshal(r.Rules)
+	if err != nil {
+		return err
+	}
+
+	r.ID = uuid.NewString()
+
+	query := `
+		INSERT INTO scoring_rules (id, version, description, rules, is_active, created_by, created_at)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, FALSE, $4, NOW()
+		FROM scoring_rules
+		RETURNING version, created_at;
+	`
+	return database.DB.QueryRow(query, r.ID, r.Description, rules, r.CreatedBy).Scan(&r.Version, &r.CreatedAt)
+}
+
+// Aktifkan satu versi dan nonaktifkan versi lain dalam satu transaksi
+func ActivateScoringRule(version int) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE scoring_rules SET is_active = FALSE WHERE is_active = TRUE;`); err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`
+		UPDATE scoring_rules
+		SET is_active = TRUE,
+		    activated_at = NOW()
+		WHERE version = $1;
+	`, version)
+	if err != nil {
+		return err
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
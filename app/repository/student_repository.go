@@ -4,13 +4,15 @@ import (
 	"database/sql"
 	"prestasi_backend/app/model"
 	"prestasi_backend/database"
+
+	"github.com/lib/pq"
 )
 
 // ambil semua mahasiswa
 func GetAllStudents() ([]model.Student, error) {
 	query := `
 		SELECT id, user_id, student_id, program_study,
-		       academic_year, advisor_id, created_at
+		       academic_year, advisor_id, deactivated_at, created_at
 		FROM students
 		ORDER BY student_id;
 	`
@@ -21,118 +23,142 @@ func GetAllStudents() ([]model.Student, error) {
 	}
 	defer rows.Close()
 
-	var list []model.Student
-	for rows.Next() {
-		var s model.Student
-		var advisor sql.NullString
-
-		if err := rows.Scan(
-			&s.ID,
-			&s.UserID,
-			&s.StudentID,
-			&s.ProgramStudy,
-			&s.AcademicYear,
-			&advisor,
-			&s.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-
-		if advisor.Valid {
-			s.AdvisorID = advisor.String
-		} else {
-			s.AdvisorID = ""
-		}
-
-		list = append(list, s)
-	}
-	return list, rows.Err()
+	return scanStudents(rows)
 }
 
 // ambil mahasiswa by ID
-func GetStudentByID(id string) (*model.Student, error) {
+var GetStudentByID = func(id string) (*model.Student, error) {
 	query := `
 		SELECT id, user_id, student_id, program_study,
-		       academic_year, advisor_id, created_at
+		       academic_year, advisor_id, deactivated_at, created_at
 		FROM students
 		WHERE id = $1;
 	`
 
-	var s model.Student
-	var advisor sql.NullString
-
-	err := database.DB.QueryRow(query, id).Scan(
-		&s.ID,
-		&s.UserID,
-		&s.StudentID,
-		&s.ProgramStudy,
-		&s.AcademicYear,
-		&advisor,
-		&s.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	if advisor.Valid {
-		s.AdvisorID = advisor.String
-	} else {
-		s.AdvisorID = ""
-	}
-
-	return &s, nil
+	return scanStudent(database.DB.QueryRow(query, id))
 }
 
 // ambil mahasiswa berdasarkan user_id (mapping dari JWT user)
-func GetStudentByUserID(userID string) (*model.Student, error) {
+var GetStudentByUserID = func(userID string) (*model.Student, error) {
 	query := `
 		SELECT id, user_id, student_id, program_study,
-		       academic_year, advisor_id, created_at
+		       academic_year, advisor_id, deactivated_at, created_at
 		FROM students
 		WHERE user_id = $1;
 	`
 
-	var s model.Student
-	var advisor sql.NullString
+	return scanStudent(database.DB.QueryRow(query, userID))
+}
 
-	err := database.DB.QueryRow(query, userID).Scan(
-		&s.ID,
-		&s.UserID,
-		&s.StudentID,
-		&s.ProgramStudy,
-		&s.AcademicYear,
-		&advisor,
-		&s.CreatedAt,
-	)
+// ambil mahasiswa berdasarkan daftar NIM (untuk impor massal)
+var GetStudentsByStudentIDs = func(studentIDs []string) ([]model.Student, error) {
+	query := `
+		SELECT id, user_id, student_id, program_study,
+		       academic_year, advisor_id, deactivated_at, created_at
+		FROM students
+		WHERE student_id = ANY($1);
+	`
+
+	rows, err := database.DB.Query(query, pq.Array(studentIDs))
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if advisor.Valid {
-		s.AdvisorID = advisor.String
-	} else {
-		s.AdvisorID = ""
+	return scanStudents(rows)
+}
+
+// insertStudent menyimpan profil mahasiswa baru beserta riwayat dosen wali
+// pertamanya (bila ada). Dipanggil di dalam transaksi.
+func insertStudent(ex execer, s *model.Student) error {
+	query := `
+		INSERT INTO students (
+			id, user_id, student_id, program_study,
+			academic_year, advisor_id, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4,
+		        $5, $6, NOW(), NOW());
+	`
+	var advisor *string
+	if s.AdvisorID != "" {
+		advisor = &s.AdvisorID
 	}
+	if _, err := ex.Exec(query, s.ID, s.UserID, s.StudentID, s.ProgramStudy, s.AcademicYear, advisor); err != nil {
+		return err
+	}
+	if advisor != nil {
+		return insertAdvisorAssignment(ex, initialAdvisorAssignment(s))
+	}
+	return nil
+}
 
-	return &s, nil
+// Buat profil mahasiswa untuk user yang sudah ada
+func CreateStudent(s *model.Student) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertStudent(tx, s); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// set dosen wali
-func SetStudentAdvisor(studentID, lecturerID string) error {
+// Update data profil mahasiswa (kecuali dosen wali)
+func UpdateStudent(s *model.Student) error {
+	return updateStudent(database.DB, s)
+}
+
+func updateStudent(ex execer, s *model.Student) error {
 	query := `
 		UPDATE students
-		SET advisor_id = $1
-		WHERE id = $2;
+		SET student_id = $1,
+		    program_study = $2,
+		    academic_year = $3,
+		    updated_at = NOW()
+		WHERE id = $4;
 	`
-	_, err := database.DB.Exec(query, lecturerID, studentID)
-	return err
+	res, err := ex.Exec(query, s.StudentID, s.ProgramStudy, s.AcademicYear, s.ID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Aktifkan / nonaktifkan profil mahasiswa
+func SetStudentActive(id string, active bool) error {
+	return setProfileActive("students", id, active)
+}
+
+// setProfileActive mengisi atau mengosongkan deactivated_at pada tabel profil.
+// Waktu nonaktif pertama dipertahankan bila profil dinonaktifkan ulang.
+func setProfileActive(table, id string, active bool) error {
+	query := `
+		UPDATE ` + table + `
+		SET deactivated_at = CASE WHEN $2 THEN NULL ELSE COALESCE(deactivated_at, NOW()) END,
+		    updated_at = NOW()
+		WHERE id = $1;
+	`
+	res, err := database.DB.Exec(query, id, active)
+	if err != nil {
+		return err
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
 // ambil mahasiswa bimbingan dosen wali
-func GetStudentsByAdvisor(lecturerID string) ([]model.Student, error) {
+var GetStudentsByAdvisor = func(lecturerID string) ([]model.Student, error) {
 	query := `
 		SELECT id, user_id, student_id, program_study,
-		       academic_year, advisor_id, created_at
+		       academic_year, advisor_id, deactivated_at, created_at
 		FROM students
 		WHERE advisor_id = $1
 		ORDER BY student_id;
@@ -144,30 +170,107 @@ func GetStudentsByAdvisor(lecturerID string) ([]model.Student, error) {
 	}
 	defer rows.Close()
 
+	return scanStudents(rows)
+}
+
+// ambil mahasiswa berdasarkan program studi (scope department)
+func GetStudentsByProgramStudy(programStudy string) ([]model.Student, error) {
+	query := `
+		SELECT id, user_id, student_id, program_study,
+		       academic_year, advisor_id, deactivated_at, created_at
+		FROM students
+		WHERE LOWER(program_study) = LOWER($1)
+		ORDER BY student_id;
+	`
+
+	rows, err := database.DB.Query(query, programStudy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanStudents(rows)
+}
+
+
+// scanStudent membaca satu baris mahasiswa (urutan kolom seperti query di atas)
+func scanStudent(row rowScanner) (*model.Student, error) {
+	var s model.Student
+	var advisor sql.NullString
+	var deactivatedAt sql.NullTime
+
+	if err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.StudentID,
+		&s.ProgramStudy,
+		&s.AcademicYear,
+		&advisor,
+		&deactivatedAt,
+		&s.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if advisor.Valid {
+		s.AdvisorID = advisor.String
+	}
+	if deactivatedAt.Valid {
+		s.DeactivatedAt = &deactivatedAt.Time
+	}
+	return &s, nil
+}
+
+// scanStudents membaca seluruh baris hasil query mahasiswa
+func scanStudents(rows *sql.Rows) ([]model.Student, error) {
 	var list []model.Student
 	for rows.Next() {
-		var s model.Student
-		var advisor sql.NullString
-
-		if err := rows.Scan(
-			&s.ID,
-			&s.UserID,
-			&s.StudentID,
-			&s.ProgramStudy,
-			&s.AcademicYear,
-			&advisor,
-			&s.CreatedAt,
-		); err != nil {
+		s, err := scanStudent(rows)
+		if err != nil {
 			return nil, err
 		}
+		list = append(list, *s)
+	}
+	return list, rows.Err()
+}
 
-		if advisor.Valid {
-			s.AdvisorID = advisor.String
+var studentList = listSpec[model.Student]{
+	columns:  `id, user_id, student_id, program_study, academic_year, advisor_id, deactivated_at, created_at`,
+	from:     `students`,
+	idColumn: "id",
+	sorts: map[string]sortField[model.Student]{
+		"student_id":    {"student_id", "text", func(s model.Student) any { return s.StudentID }},
+		"academic_year": {"academic_year", "text", func(s model.Student) any { return s.AcademicYear }},
+		"created_at":    {"created_at", "timestamp", func(s model.Student) any { return s.CreatedAt }},
+	},
+	defaultSort: "student_id",
+	id:          func(s model.Student) string { return s.ID },
+	scan:        scanStudents,
+}
+
+// List mahasiswa dengan filter, sort, dan paginasi
+func ListStudents(f model.StudentFilter, q model.ListQuery) ([]model.Student, model.PageInfo, error) {
+	w := &whereBuilder{}
+
+	if f.AdvisorID != "" {
+		w.add("advisor_id = ?", f.AdvisorID)
+	}
+	if f.ProgramStudy != "" {
+		w.add("LOWER(program_study) = LOWER(?)", f.ProgramStudy)
+	}
+	if f.AcademicYear != "" {
+		w.add("academic_year = ?", f.AcademicYear)
+	}
+	if f.IsActive != nil {
+		if *f.IsActive {
+			w.add("deactivated_at IS NULL")
 		} else {
-			s.AdvisorID = ""
+			w.add("deactivated_at IS NOT NULL")
 		}
-
-		list = append(list, s)
 	}
-	return list, rows.Err()
-}
\ No newline at end of file
+	if f.Search != "" {
+		w.add("student_id ILIKE ?", "%"+f.Search+"%")
+	}
+
+	return runList(studentList, w, q)
+}
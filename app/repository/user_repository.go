@@ -2,15 +2,19 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"prestasi_backend/app/model"
 	"prestasi_backend/database"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // Ambil semua user
 func GetAllUsers() ([]model.User, error) {
 	query := `
 		SELECT id, username, email, password_hash, full_name,
-		       role_id, is_active, created_at, updated_at
+		       role_id, is_active, token_version, created_at, updated_at
 		FROM users
 		ORDER BY created_at DESC;
 	`
@@ -21,32 +25,14 @@ func GetAllUsers() ([]model.User, error) {
 	}
 	defer rows.Close()
 
-	var list []model.User
-	for rows.Next() {
-		var u model.User
-		if err := rows.Scan(
-			&u.ID,
-			&u.Username,
-			&u.Email,
-			&u.PasswordHash, // password_hash
-			&u.FullName,
-			&u.RoleID,
-			&u.IsActive,
-			&u.CreatedAt,
-			&u.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		list = append(list, u)
-	}
-	return list, rows.Err()
+	return scanUsers(rows)
 }
 
 // Ambil user berdasarkan ID
-func GetUserByID(id string) (*model.User, error) {
+var GetUserByID = func(id string) (*model.User, error) {
 	query := `
 		SELECT id, username, email, password_hash, full_name,
-		       role_id, is_active, created_at, updated_at
+		       role_id, is_active, token_version, created_at, updated_at
 		FROM users
 		WHERE id = $1;
 	`
@@ -60,6 +46,7 @@ func GetUserByID(id string) (*model.User, error) {
 		&u.FullName,
 		&u.RoleID,
 		&u.IsActive,
+		&u.TokenVersion,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
@@ -73,7 +60,7 @@ func GetUserByID(id string) (*model.User, error) {
 var GetUserByUsername = func(username string) (*model.User, error) {
 	query := `
 		SELECT id, username, email, password_hash, full_name,
-		       role_id, is_active, created_at, updated_at
+		       role_id, is_active, token_version, created_at, updated_at
 		FROM users
 		WHERE username = $1;
 	`
@@ -87,6 +74,7 @@ var GetUserByUsername = func(username string) (*model.User, error) {
 		&u.FullName,
 		&u.RoleID,
 		&u.IsActive,
+		&u.TokenVersion,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
@@ -96,8 +84,114 @@ var GetUserByUsername = func(username string) (*model.User, error) {
 	return &u, nil
 }
 
-// Buat user baru
-func CreateUser(u *model.User) error {
+// Ambil user yang cocok dengan salah satu ID, username, atau email (case-insensitive).
+// Dipakai impor massal untuk mencocokkan baris file dengan akun yang sudah ada.
+var GetUsersByIdentity = func(ids, usernames, emails []string) ([]model.User, error) {
+	query := `
+		SELECT id, username, email, password_hash, full_name,
+		       role_id, is_active, token_version, created_at, updated_at
+		FROM users
+		WHERE id = ANY($1)
+		   OR username = ANY($2)
+		   OR LOWER(email) = ANY($3);
+	`
+
+	rows, err := database.DB.Query(query, pq.Array(ids), pq.Array(usernames), pq.Array(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUsers(rows)
+}
+
+// execer dipenuhi *sql.DB maupun *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// Buat user baru beserta profil mahasiswa/dosennya (opsional) dalam satu transaksi
+func CreateUserWithProfile(u *model.User, student *model.Student, lecturer *model.Lecturer) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertUser(tx, u); err != nil {
+		return err
+	}
+	if student != nil {
+		if err := insertStudent(tx, student); err != nil {
+			return err
+		}
+	}
+	if lecturer != nil {
+		if err := insertLecturer(tx, lecturer); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Tulis seluruh perubahan impor massal dalam satu transaksi; satu baris gagal
+// membatalkan semuanya
+var ApplyUserImport = func(ops []model.UserImportOp) error {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range ops {
+		if err := applyUserImportOp(tx, &ops[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func applyUserImportOp(tx *sql.Tx, op *model.UserImportOp) error {
+	if op.NewUser {
+		if err := insertUser(tx, &op.User); err != nil {
+			return err
+		}
+	} else if err := updateUserIdentity(tx, &op.User); err != nil {
+		return err
+	}
+
+	switch {
+	case op.Student != nil && op.NewProfile:
+		return insertStudent(tx, op.Student)
+	case op.Student != nil:
+		if err := updateStudent(tx, op.Student); err != nil {
+			return err
+		}
+		if op.Advisor != nil {
+			return assignAdvisor(tx, op.Advisor)
+		}
+	case op.Lecturer != nil && op.NewProfile:
+		return insertLecturer(tx, op.Lecturer)
+	case op.Lecturer != nil:
+		return updateLecturer(tx, op.Lecturer)
+	}
+	return nil
+}
+
+// updateUserIdentity hanya mengubah nama dan email (password & status tidak disentuh)
+func updateUserIdentity(ex execer, u *model.User) error {
+	query := `
+		UPDATE users
+		SET full_name = $1,
+		    email = $2,
+		    updated_at = NOW()
+		WHERE id = $3;
+	`
+	_, err := ex.Exec(query, u.FullName, u.Email, u.ID)
+	return err
+}
+
+func insertUser(ex execer, u *model.User) error {
 	query := `
 		INSERT INTO users (
 			id, username, email, password_hash, full_name,
@@ -106,7 +200,7 @@ func CreateUser(u *model.User) error {
 		VALUES ($1, $2, $3, $4, $5,
 		        $6, $7, NOW(), NOW());
 	`
-	_, err := database.DB.Exec(
+	_, err := ex.Exec(
 		query,
 		u.ID,
 		u.Username,
@@ -120,7 +214,7 @@ func CreateUser(u *model.User) error {
 }
 
 // Update data user (kecuali role_id)
-func UpdateUser(u *model.User) error {
+var UpdateUser = func(u *model.User) error {
 	query := `
 		UPDATE users
 		SET username = $1,
@@ -165,6 +259,7 @@ func UpdateUserRole(userID, roleID string) error {
 	query := `
 		UPDATE users
 		SET role_id = $1,
+		    token_version = token_version + 1,
 		    updated_at = NOW()
 		WHERE id = $2;
 	`
@@ -172,7 +267,100 @@ func UpdateUserRole(userID, roleID string) error {
 	return err
 }
 
+// Naikkan token_version agar semua access token lama user ditolak
+var BumpUserTokenVersion = func(userID string) error {
+	query := `
+		UPDATE users
+		SET token_version = token_version + 1,
+		    updated_at = NOW()
+		WHERE id = $1;
+	`
+	_, err := database.DB.Exec(query, userID)
+	return err
+}
+
+// Ambil status sesi user (aktif & token_version) untuk validasi token
+var GetUserTokenState = func(userID string) (isActive bool, tokenVersion int, err error) {
+	query := `SELECT is_active, token_version FROM users WHERE id = $1;`
+
+	err = database.DB.QueryRow(query, userID).Scan(&isActive, &tokenVersion)
+	return isActive, tokenVersion, err
+}
+
 // Helper: cek apakah error karena row tidak ditemukan
 func IsNoRows(err error) bool {
 	return err == sql.ErrNoRows
 }
+
+// Helper: cek apakah error karena melanggar constraint unique (Postgre maupun index unique Mongo)
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return true
+	}
+	return mongo.IsDuplicateKeyError(err)
+}
+
+// Helper: cek apakah error karena melanggar constraint CHECK Postgre
+func IsCheckViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23514"
+}
+
+
+// scanUsers membaca seluruh baris hasil query user
+func scanUsers(rows *sql.Rows) ([]model.User, error) {
+	var list []model.User
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(
+			&u.ID,
+			&u.Username,
+			&u.Email,
+			&u.PasswordHash, // password_hash
+			&u.FullName,
+			&u.RoleID,
+			&u.IsActive,
+			&u.TokenVersion,
+			&u.CreatedAt,
+			&u.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
+
+var userList = listSpec[model.User]{
+	columns: `id, username, email, password_hash, full_name,
+		role_id, is_active, token_version, created_at, updated_at`,
+	from:     `users`,
+	idColumn: "id",
+	sorts: map[string]sortField[model.User]{
+		"created_at": {"created_at", "timestamp", func(u model.User) any { return u.CreatedAt }},
+		"username":   {"username", "text", func(u model.User) any { return u.Username }},
+		"full_name":  {"full_name", "text", func(u model.User) any { return u.FullName }},
+	},
+	defaultSort: "created_at",
+	id:          func(u model.User) string { return u.ID },
+	scan:        scanUsers,
+}
+
+// List user dengan filter, sort, dan paginasi
+func ListUsers(f model.UserFilter, q model.ListQuery) ([]model.User, model.PageInfo, error) {
+	w := &whereBuilder{}
+
+	if f.RoleID != "" {
+		w.add("role_id = ?", f.RoleID)
+	}
+	if f.IsActive != nil {
+		w.add("is_active = ?", *f.IsActive)
+	}
+	if f.Search != "" {
+		w.add("(username ILIKE ? OR email ILIKE ? OR full_name ILIKE ?)",
+			"%"+f.Search+"%", "%"+f.Search+"%", "%"+f.Search+"%")
+	}
+
+	return runList(userList, w, q)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Jumlah maksimal item per batch review
+const maxBatchReviewItems = 200
+
+// ReviewBatch memproses setiap item secara terpisah dengan pemeriksaan yang
+// sama seperti endpoint tunggal. Kegagalan satu item tidak menghentikan item lain.
+func ReviewBatch(lecturer *model.Lecturer, userID string, req model.AchievementBatchReviewRequest) []model.AchievementBatchReviewResult {
+	results := make([]model.AchievementBatchReviewResult, 0, len(req.Items))
+	seen := map[string]bool{}
+
+	for _, item := range req.Items {
+		res := model.AchievementBatchReviewResult{ID: item.ID, Decision: item.Decision, Status: 200}
+		if res.Decision == "" {
+			res.Decision = req.Decision
+		}
+		note := item.Note
+		if note == "" {
+			note = req.Note
+		}
+
+		switch {
+		case item.ID == "":
+			res.Status, res.Error = 400, "id wajib diisi"
+		case seen[item.ID]:
+			res.Status, res.Error = 400, "Achievement ganda dalam satu batch"
+		case res.Decision != "verify" && res.Decision != "reject":
+			res.Status, res.Error = 400, "decision harus 'verify' atau 'reject'"
+		case res.Decision == "reject" && note == "":
+			res.Status, res.Error = 400, "Alasan penolakan wajib diisi"
+		default:
+			// route batch tidak lewat AchievementAccess, reference diambil per item
+			ref, err := repository.GetAchievementReferenceByID(item.ID)
+			switch {
+			case repository.IsNoRows(err):
+				res.Status, res.Error = 404, "Achievement tidak ditemukan"
+			case err != nil:
+				res.Status, res.Error = 500, "Gagal mengambil data achievement"
+			case res.Decision == "verify":
+				score, status, msg := verifyAchievement(lecturer, userID, ref)
+				res.Status, res.Error = status, msg
+				if msg == "" {
+					res.Points = &score.Points
+				}
+			default:
+				res.Status, res.Error = rejectAchievement(lecturer, userID, ref, note)
+			}
+		}
+
+		seen[item.ID] = true
+		if res.Error == "" {
+			res.Status, res.Success = 200, true
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// ==================================================================
+// BATCH REVIEW
+// ==================================================================
+
+// AchievementBatchReview godoc
+// @Summary      Verifikasi / Tolak Prestasi Massal (Dosen)
+// @Description  Dosen wali memverifikasi atau menolak banyak prestasi sekaligus (maks 200). Setiap item diperiksa seperti endpoint tunggal (mahasiswa bimbingan & status submitted) dan hasilnya dilaporkan per item; item yang gagal tidak menggagalkan batch.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.AchievementBatchReviewRequest true "Daftar keputusan"
+// @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Router       /achievements/batch-review [post]
+func AchievementBatchReview(c *fiber.Ctx) error {
+	userID := c.Locals("userId").(string)
+
+	var req model.AchievementBatchReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+	if len(req.Items) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "items wajib diisi"})
+	}
+	if len(req.Items) > maxBatchReviewItems {
+		return c.Status(400).JSON(fiber.Map{"error": "Maksimal 200 item per batch"})
+	}
+
+	lecturer, err := repository.GetLecturerByUserID(userID)
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "Hanya dosen wali yang dapat memverifikasi"})
+	}
+
+	results := ReviewBatch(lecturer, userID, req)
+
+	succeeded := 0
+	for _, r := range results {
+		if r.Success {
+			succeeded++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"summary": fiber.Map{
+			"total":     len(results),
+			"succeeded": succeeded,
+			"failed":    len(results) - succeeded,
+		},
+		"data": results,
+	})
+}
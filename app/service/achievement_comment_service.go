@@ -0,0 +1,252 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Panjang maksimal isi komentar
+const maxCommentLength = 5000
+
+// BuildCommentThreads menyusun komentar datar (urut waktu) menjadi pohon
+// balasan. Komentar dengan parent yang tidak ada diperlakukan sebagai akar.
+func BuildCommentThreads(flat []model.AchievementComment) []model.AchievementComment {
+	children := map[string][]int{}
+	index := make(map[string]int, len(flat))
+	for i, cm := range flat {
+		index[cm.ID] = i
+	}
+
+	var roots []int
+	for i, cm := range flat {
+		if cm.ParentID != nil {
+			if _, ok := index[*cm.ParentID]; ok {
+				children[*cm.ParentID] = append(children[*cm.ParentID], i)
+				continue
+			}
+		}
+		roots = append(roots, i)
+	}
+
+	var build func(i int) model.AchievementComment
+	build = func(i int) model.AchievementComment {
+		cm := flat[i]
+		cm.Replies = nil
+		for _, child := range children[cm.ID] {
+			cm.Replies = append(cm.Replies, build(child))
+		}
+		return cm
+	}
+
+	threads := make([]model.AchievementComment, 0, len(roots))
+	for _, i := range roots {
+		threads = append(threads, build(i))
+	}
+	return threads
+}
+
+// canVerifyAchievements true bila user punya permission achievement:verify dengan scope apa pun
+func canVerifyAchievements(claims *model.JWTClaims) bool {
+	return policy.Can(claims, "verify", "achievement") != policy.ScopeNone
+}
+
+// recordRejectionComment mencatat alasan penolakan ke thread komentar.
+// Bersifat best-effort seperti recordAchievementReview.
+func recordRejectionComment(refID, reviewerID, note string) {
+	err := repository.CreateAchievementComment(&model.AchievementComment{
+		AchievementRefID: refID,
+		AuthorID:         reviewerID,
+		Kind:             model.CommentKindRejection,
+		Body:             note,
+	})
+	if err != nil {
+		log.Println("⚠️ gagal mencatat komentar penolakan:", err)
+	}
+}
+
+// ==================================================================
+// LIST COMMENTS
+// ==================================================================
+
+// AchievementComments godoc
+// @Summary      Thread Komentar Prestasi
+// @Description  Menampilkan komentar, permintaan klarifikasi, balasan, dan catatan penolakan dalam bentuk thread. Hak akses sama dengan detail prestasi.
+// @Tags         Achievement Comment
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Achievement Reference ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/comments [get]
+func AchievementComments(c *fiber.Ctx) error {
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	flat, err := repository.GetAchievementComments(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil komentar"})
+	}
+
+	open := 0
+	for _, cm := range flat {
+		if cm.Kind == model.CommentKindClarification && cm.ResolvedAt == nil {
+			open++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success":             true,
+		"count":               len(flat),
+		"open_clarifications": open,
+		"data":                BuildCommentThreads(flat),
+	})
+}
+
+// ==================================================================
+// CREATE COMMENT
+// ==================================================================
+
+// AchievementCommentCreate godoc
+// @Summary      Tulis Komentar
+// @Description  Menulis komentar atau balasan pada prestasi. Dosen/verifikator dapat meminta klarifikasi (kind=clarification) pada prestasi yang sedang diajukan tanpa menolaknya. Komentar dapat merujuk attachment prestasi.
+// @Tags         Achievement Comment
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                           true  "Achievement Reference ID"
+// @Param        request  body  model.AchievementCommentRequest  true  "Isi komentar"
+// @Success      201  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/comments [post]
+func AchievementCommentCreate(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	var req model.AchievementCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Body == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Isi komentar wajib diisi"})
+	}
+	if len(req.Body) > maxCommentLength {
+		return c.Status(400).JSON(fiber.Map{"error": "Isi komentar terlalu panjang"})
+	}
+
+	if req.Kind == "" {
+		req.Kind = model.CommentKindComment
+	}
+	if req.Kind != model.CommentKindComment && req.Kind != model.CommentKindClarification {
+		return c.Status(400).JSON(fiber.Map{"error": "Jenis komentar tidak dikenal: " + req.Kind})
+	}
+
+	ref := c.Locals("achievement").(*model.AchievementReference)
+	if ref.Status == model.StatusDeleted {
+		return c.Status(400).JSON(fiber.Map{"error": "Prestasi sudah dihapus"})
+	}
+
+	if req.Kind == model.CommentKindClarification {
+		if !canVerifyAchievements(claims) {
+			return c.Status(403).JSON(fiber.Map{"error": "Hanya dosen/verifikator yang dapat meminta klarifikasi"})
+		}
+		if ref.Status != model.StatusSubmitted {
+			return c.Status(400).JSON(fiber.Map{"error": "Klarifikasi hanya dapat diminta untuk prestasi berstatus submitted"})
+		}
+		if req.ParentID != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Permintaan klarifikasi tidak boleh berupa balasan"})
+		}
+	}
+
+	if req.ParentID != nil {
+		parent, err := repository.GetAchievementCommentByID(*req.ParentID)
+		if err != nil || parent.AchievementRefID != ref.ID {
+			return c.Status(400).JSON(fiber.Map{"error": "Komentar yang dibalas tidak ditemukan"})
+		}
+	}
+
+	if len(req.AttachmentIDs) > 0 {
+		doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+		}
+		for _, id := range req.AttachmentIDs {
+			if findAttachment(doc, id) == nil {
+				return c.Status(400).JSON(fiber.Map{"error": "Attachment tidak ditemukan: " + id})
+			}
+		}
+	}
+
+	cm := model.AchievementComment{
+		AchievementRefID: ref.ID,
+		ParentID:         req.ParentID,
+		AuthorID:         claims.UserID,
+		Kind:             req.Kind,
+		Body:             req.Body,
+		AttachmentIDs:    req.AttachmentIDs,
+	}
+	if err := repository.CreateAchievementComment(&cm); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan komentar"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Komentar berhasil ditambahkan",
+		"data":    cm,
+	})
+}
+
+// ==================================================================
+// RESOLVE CLARIFICATION
+// ==================================================================
+
+// AchievementCommentResolve godoc
+// @Summary      Tutup Permintaan Klarifikasi
+// @Description  Menandai permintaan klarifikasi sudah terjawab. Hanya dapat dilakukan oleh dosen/verifikator yang berhak atas prestasi ini.
+// @Tags         Achievement Comment
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path  string  true  "Achievement Reference ID"
+// @Param        commentId  path  string  true  "Comment ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      409  {object} map[string]interface{}
+// @Router       /achievements/{id}/comments/{commentId}/resolve [post]
+func AchievementCommentResolve(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	ref := c.Locals("achievement").(*model.AchievementReference)
+	if !canVerifyAchievements(claims) {
+		return c.Status(403).JSON(fiber.Map{"error": "Hanya dosen/verifikator yang dapat menutup klarifikasi"})
+	}
+
+	cm, err := repository.GetAchievementCommentByID(c.Params("commentId"))
+	if err != nil || cm.AchievementRefID != ref.ID {
+		return c.Status(404).JSON(fiber.Map{"error": "Komentar tidak ditemukan"})
+	}
+
+	if err := repository.ResolveAchievementClarification(cm.ID, claims.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(409).JSON(fiber.Map{"error": "Komentar bukan permintaan klarifikasi yang masih terbuka"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menutup klarifikasi"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Klarifikasi ditandai selesai",
+	})
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// Skor minimal agar prestasi lain dianggap kemungkinan duplikat
+	duplicateThreshold = 0.6
+	// Jumlah maksimal kecocokan yang dilaporkan
+	maxDuplicateMatches = 10
+	// Batas kandidat dari indeks global per pemeriksaan
+	duplicateCandidateLimit = 200
+)
+
+// Field details yang berisi nama kegiatan / tanggal kegiatan, berurutan menurut prioritas
+var (
+	dedupEventFields = []string{"competitionName", "publicationTitle", "certificationName", "organizationName", "companyName"}
+	dedupDateFields  = []string{"eventDate", "publishedDate", "periodStart"}
+)
+
+// normalizeDedupText menurunkan huruf dan mengganti tanda baca dengan spasi tunggal
+func normalizeDedupText(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		} else {
+			space = true
+		}
+	}
+	return b.String()
+}
+
+func firstDetail(doc *model.AchievementMongo, fields []string) string {
+	for _, f := range fields {
+		if s, ok := doc.Details[f].(string); ok && strings.TrimSpace(s) != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func dedupEvent(doc *model.AchievementMongo) string {
+	return normalizeDedupText(firstDetail(doc, dedupEventFields))
+}
+
+// dedupDate mengambil bagian YYYY-MM-DD (tanggal juga bisa dikirim RFC3339)
+func dedupDate(doc *model.AchievementMongo) string {
+	d := firstDetail(doc, dedupDateFields)
+	if len(d) > 10 {
+		d = d[:10]
+	}
+	return d
+}
+
+// DedupKeys menyusun kunci indeks global untuk mencari kandidat duplikat:
+// judul ternormalisasi, nama kegiatan + tanggal, dan hash setiap lampiran.
+func DedupKeys(doc *model.AchievementMongo) []string {
+	keys := []string{}
+	if t := normalizeDedupText(doc.Title); t != "" {
+		keys = append(keys, "t:"+t)
+	}
+	if e := dedupEvent(doc); e != "" {
+		keys = append(keys, "e:"+e+"|"+dedupDate(doc))
+	}
+	for _, att := range doc.Attachments {
+		if att.SHA256 != "" {
+			keys = append(keys, "h:"+att.SHA256)
+		}
+	}
+	return keys
+}
+
+// textSimilarity adalah koefisien Dice atas himpunan kata (0..1)
+func textSimilarity(a, b string) float64 {
+	a, b = normalizeDedupText(a), normalizeDedupText(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	wa := map[string]bool{}
+	for _, w := range strings.Fields(a) {
+		wa[w] = true
+	}
+	wb := map[string]bool{}
+	for _, w := range strings.Fields(b) {
+		wb[w] = true
+	}
+
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(wa)+len(wb))
+}
+
+// ScoreDuplicate menilai kemiripan dua prestasi (0..1) beserta alasannya.
+// Judul berbobot 0.4, nama kegiatan 0.3 dan tanggal 0.3; lampiran dengan
+// hash identik hampir pasti duplikat sehingga skornya minimal 0.9.
+func ScoreDuplicate(doc, other *model.AchievementMongo) (float64, []string) {
+	reasons := []string{}
+
+	hashes := map[string]bool{}
+	for _, att := range doc.Attachments {
+		if att.SHA256 != "" {
+			hashes[att.SHA256] = true
+		}
+	}
+	sameAttachment := false
+	for _, att := range other.Attachments {
+		if hashes[att.SHA256] {
+			sameAttachment = true
+			break
+		}
+	}
+	if sameAttachment {
+		reasons = append(reasons, "same_attachment")
+	}
+
+	title := textSimilarity(doc.Title, other.Title)
+	if title >= 0.8 {
+		reasons = append(reasons, "similar_title")
+	}
+
+	event := textSimilarity(dedupEvent(doc), dedupEvent(other))
+	if event >= 0.8 {
+		reasons = append(reasons, "same_event")
+	}
+
+	date := 0.0
+	if d := dedupDate(doc); d != "" && d == dedupDate(other) {
+		date = 1
+		reasons = append(reasons, "same_date")
+	}
+
+	score := 0.4*title + 0.3*event + 0.3*date
+	if sameAttachment {
+		score = 0.9 + 0.1*score
+	}
+	return math.Round(score*100) / 100, reasons
+}
+
+// FindDuplicates membandingkan prestasi dengan prestasi lain milik mahasiswa
+// yang sama dan kandidat dari indeks global, lalu mengembalikan yang belum
+// dihapus dengan skor ≥ duplicateThreshold, urut dari yang paling mirip.
+func FindDuplicates(doc *model.AchievementMongo) ([]model.DuplicateMatch, error) {
+	selfID := doc.ID.Hex()
+	candidates := map[string]*model.AchievementMongo{}
+
+	own, err := repository.GetAchievementsByStudentID(doc.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range own {
+		candidates[own[i].ID.Hex()] = &own[i]
+	}
+
+	global, err := repository.FindAchievementsByDedupKeys(DedupKeys(doc), selfID, duplicateCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range global {
+		candidates[global[i].ID.Hex()] = &global[i]
+	}
+	delete(candidates, selfID)
+
+	scored := map[string]model.DuplicateMatch{}
+	ids := []string{}
+	for id, other := range candidates {
+		score, reasons := ScoreDuplicate(doc, other)
+		if score < duplicateThreshold {
+			continue
+		}
+		scored[id] = model.DuplicateMatch{
+			AchievementID: id,
+			StudentID:     other.StudentID,
+			Title:         other.Title,
+			Score:         score,
+			Reasons:       reasons,
+		}
+		ids = append(ids, id)
+	}
+
+	// hanya prestasi yang reference-nya masih aktif (bukan deleted)
+	refs, err := repository.GetAchievementReferencesByMongoIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []model.DuplicateMatch{}
+	for _, ref := range refs {
+		m, ok := scored[ref.MongoAchievementID]
+		if !ok {
+			continue
+		}
+		m.ReferenceID = ref.ID
+		m.Status = ref.Status
+		m.URL = "/achievements/" + ref.ID
+		matches = append(matches, m)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ReferenceID < matches[j].ReferenceID
+	})
+	if len(matches) > maxDuplicateMatches {
+		matches = matches[:maxDuplicateMatches]
+	}
+	return matches, nil
+}
+
+// recordDuplicateCheck memperbarui dedupKeys dokumen lalu menyimpan hasil
+// pemeriksaan duplikat agar terlihat oleh dosen verifikator. Kegagalan hanya
+// dicatat di log karena pemeriksaan ini tidak boleh menggagalkan create/submit.
+func recordDuplicateCheck(doc *model.AchievementMongo) []model.DuplicateMatch {
+	matches, err := FindDuplicates(doc)
+	if err != nil {
+		log.Println("⚠️ gagal memeriksa duplikat achievement:", err)
+		return nil
+	}
+
+	check := model.DuplicateCheck{CheckedAt: time.Now(), Matches: matches}
+	doc.DedupKeys = DedupKeys(doc)
+	doc.Duplicates = &check
+
+	if err := repository.UpdateAchievement(doc.ID.Hex(), bson.M{"dedupKeys": doc.DedupKeys, "duplicates": check}); err != nil {
+		log.Println("⚠️ gagal menyimpan hasil pemeriksaan duplikat:", err)
+	}
+	return matches
+}
+
+// BackfillDedupKeys mengisi dedupKeys dokumen lama agar ikut terindeks.
+// Dokumen diproses per batch sampai tidak ada lagi yang belum punya kunci.
+func BackfillDedupKeys() (int, error) {
+	total := 0
+	for {
+		docs, err := repository.GetAchievementsWithoutDedupKeys(500)
+		if err != nil {
+			return total, err
+		}
+		if len(docs) == 0 {
+			return total, nil
+		}
+
+		for i := range docs {
+			if err := repository.UpdateAchievement(docs[i].ID.Hex(), bson.M{"dedupKeys": DedupKeys(&docs[i])}); err != nil {
+				return total, err
+			}
+			total++
+		}
+	}
+}
+
+// ==================================================================
+// DUPLICATE CHECK
+// ==================================================================
+
+// AchievementDuplicates godoc
+// @Summary      Kemungkinan Duplikat Prestasi
+// @Description  Membandingkan judul, nama kegiatan, tanggal dan hash lampiran dengan prestasi lain yang belum dihapus (milik mahasiswa yang sama maupun mahasiswa lain). Mengembalikan skor kemiripan 0..1 dan tautan ke prestasi yang cocok.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Achievement Reference ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /achievements/{id}/duplicates [get]
+func AchievementDuplicates(c *fiber.Ctx) error {
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+	}
+
+	matches, err := FindDuplicates(doc)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa duplikat"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(matches),
+		"data":    matches,
+	})
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Batas jumlah anggota tim, sama dengan batas details.teamSize
+const maxTeamMembers = 50
+
+// NormalizeTeamMembers menyusun anggota tim dari input request. Pemilik selalu
+// ikut menjadi anggota; jika tidak ada ketua yang dipilih, pemilik menjadi ketua.
+func NormalizeTeamMembers(ownerID string, inputs []model.AchievementMemberInput) ([]model.AchievementMember, []model.FieldError) {
+	var errs []model.FieldError
+	members := []model.AchievementMember{{StudentID: ownerID, Role: model.MemberRoleMember}}
+	seen := map[string]bool{ownerID: true}
+	leader := -1
+
+	for i, in := range inputs {
+		path := fmt.Sprintf("members[%d]", i)
+
+		role := in.Role
+		if role == "" {
+			role = model.MemberRoleMember
+		}
+		if role != model.MemberRoleLeader && role != model.MemberRoleMember {
+			errs = append(errs, model.FieldError{Field: path + ".role", Message: "harus 'ketua' atau 'anggota'"})
+			continue
+		}
+
+		if in.StudentID == "" {
+			errs = append(errs, model.FieldError{Field: path + ".student_id", Message: "wajib diisi"})
+			continue
+		}
+
+		idx := -1
+		if in.StudentID == ownerID {
+			idx = 0
+		} else if seen[in.StudentID] {
+			errs = append(errs, model.FieldError{Field: path + ".student_id", Message: "anggota ganda"})
+			continue
+		} else {
+			seen[in.StudentID] = true
+			members = append(members, model.AchievementMember{StudentID: in.StudentID, Role: model.MemberRoleMember})
+			idx = len(members) - 1
+		}
+
+		if role == model.MemberRoleLeader {
+			if leader >= 0 && leader != idx {
+				errs = append(errs, model.FieldError{Field: path + ".role", Message: "ketua tim hanya boleh satu"})
+				continue
+			}
+			leader = idx
+		}
+	}
+
+	if len(members) > maxTeamMembers {
+		errs = append(errs, model.FieldError{Field: "members", Message: fmt.Sprintf("maksimal %d anggota", maxTeamMembers)})
+	}
+
+	if leader < 0 {
+		leader = 0
+	}
+	members[leader].Role = model.MemberRoleLeader
+
+	return members, errs
+}
+
+// ComputeTeamPoints menghitung poin per anggota. Jumlah anggota yang sudah
+// konfirmasi dipakai sebagai ukuran tim bila lebih besar dari details.teamSize.
+func ComputeTeamPoints(rule *model.ScoringRule, doc *model.AchievementMongo, confirmed int) model.ScoreBreakdown {
+	if size, ok := toFloat(doc.Details["teamSize"]); confirmed > 1 && (!ok || float64(confirmed) > size) {
+		scored := *doc
+		scored.Details = make(map[string]interface{}, len(doc.Details)+1)
+		for k, v := range doc.Details {
+			scored.Details[k] = v
+		}
+		scored.Details["teamSize"] = confirmed
+		return ComputeAchievementPoints(rule, &scored)
+	}
+	return ComputeAchievementPoints(rule, doc)
+}
+
+// validateMemberStudents memastikan setiap anggota adalah mahasiswa yang terdaftar
+func validateMemberStudents(members []model.AchievementMember) []model.FieldError {
+	var errs []model.FieldError
+	for _, m := range members {
+		if _, err := repository.GetStudentByID(m.StudentID); err != nil {
+			errs = append(errs, model.FieldError{Field: "members", Message: "mahasiswa " + m.StudentID + " tidak ditemukan"})
+		}
+	}
+	return errs
+}
+
+// countConfirmedMembers menghitung anggota yang sudah konfirmasi
+func countConfirmedMembers(members []model.AchievementMember) int {
+	n := 0
+	for _, m := range members {
+		if m.Status == model.MemberConfirmed {
+			n++
+		}
+	}
+	return n
+}
+
+// ==================================================================
+// LIST TEAM MEMBERS
+// ==================================================================
+
+// AchievementMembers godoc
+// @Summary      Anggota Tim Prestasi
+// @Description  Menampilkan anggota tim prestasi beserta peran (ketua/anggota), status konfirmasi dan verifikasi per anggota.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Achievement Reference ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /achievements/{id}/members [get]
+func AchievementMembers(c *fiber.Ctx) error {
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	members, err := repository.GetAchievementMembers(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil anggota tim"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(members),
+		"data":    members,
+	})
+}
+
+// ==================================================================
+// UPDATE TEAM MEMBERS
+// ==================================================================
+
+// AchievementMembersUpdate godoc
+// @Summary      Atur Anggota Tim Prestasi
+// @Description  Pemilik prestasi mengganti susunan tim saat status 'draft' atau 'rejected'. Anggota baru harus mengonfirmasi keikutsertaannya sebelum prestasi bisa disubmit. Anggota yang pernah menolak lalu dicantumkan lagi diundang ulang (kembali pending).
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                          true  "Achievement Reference ID"
+// @Param        request  body  model.AchievementMembersRequest true  "Anggota Tim"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Router       /achievements/{id}/members [put]
+func AchievementMembersUpdate(c *fiber.Ctx) error {
+	var req model.AchievementMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	if ref.Status != model.StatusDraft && ref.Status != model.StatusRejected {
+		return c.Status(400).JSON(fiber.Map{"error": "Anggota tim hanya dapat diubah saat draft atau rejected"})
+	}
+
+	members, fieldErrs := NormalizeTeamMembers(ref.StudentID, req.Members)
+	if len(fieldErrs) == 0 {
+		fieldErrs = validateMemberStudents(members)
+	}
+	if len(fieldErrs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
+	}
+
+	if err := repository.ReplaceAchievementMembers(ref.ID, ref.StudentID, members); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan anggota tim"})
+	}
+
+	saved, err := repository.GetAchievementMembers(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil anggota tim"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Anggota tim berhasil diperbarui",
+		"data":    saved,
+	})
+}
+
+// ==================================================================
+// CONFIRM / DECLINE PARTICIPATION
+// ==================================================================
+
+// AchievementMemberRespond godoc
+// @Summary      Konfirmasi Keikutsertaan Tim
+// @Description  Mahasiswa yang dicantumkan sebagai anggota tim mengonfirmasi (confirm) atau menolak (decline) keikutsertaannya. Hanya saat prestasi berstatus 'draft' atau 'rejected'.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  string                                 true  "Achievement Reference ID"
+// @Param        request  body  model.AchievementMemberResponseRequest true  "Keputusan"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Router       /achievements/{id}/members/respond [post]
+func AchievementMemberRespond(c *fiber.Ctx) error {
+	var req model.AchievementMemberResponseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	var status string
+	switch req.Decision {
+	case "confirm":
+		status = model.MemberConfirmed
+	case "decline":
+		status = model.MemberDeclined
+	default:
+		return c.Status(400).JSON(fiber.Map{"error": "decision harus 'confirm' atau 'decline'"})
+	}
+
+	student, err := repository.GetStudentByUserID(c.Locals("userId").(string))
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "Hanya mahasiswa yang dapat mengonfirmasi keikutsertaan"})
+	}
+
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	if ref.StudentID == student.ID {
+		return c.Status(400).JSON(fiber.Map{"error": "Pemilik prestasi otomatis menjadi anggota tim"})
+	}
+	if ref.Status != model.StatusDraft && ref.Status != model.StatusRejected {
+		return c.Status(400).JSON(fiber.Map{"error": "Keikutsertaan hanya dapat diubah saat draft atau rejected"})
+	}
+
+	if err := repository.RespondAchievementMember(ref.ID, student.ID, status); err != nil {
+		if errors.Is(err, repository.ErrMemberNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Anda bukan anggota tim prestasi ini"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan konfirmasi"})
+	}
+
+	msg := "Keikutsertaan dikonfirmasi"
+	if status == model.MemberDeclined {
+		msg = "Keikutsertaan ditolak"
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": msg,
+	})
+}
+
+// ==================================================================
+// VERIFY ONE MEMBER
+// ==================================================================
+
+// AchievementMemberVerify godoc
+// @Summary      Verifikasi Anggota Tim (Dosen Wali Anggota)
+// @Description  Dosen wali seorang anggota memverifikasi bagian anggota tersebut pada prestasi tim berstatus 'submitted'. Bagian pemilik yang dipertahankan dosen wali lama (pending_reviews=keep) diverifikasi dosen tersebut, bukan dosen wali barunya. Setelah seluruh anggota yang konfirmasi terverifikasi, prestasi otomatis berstatus 'verified'. Verifikasi sekaligus untuk satu tim tetap bisa lewat /achievements/{id}/verify.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path  string  true  "Achievement Reference ID"
+// @Param        studentId  path  string  true  "Student ID anggota"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /achievements/{id}/members/{studentId}/verify [post]
+func AchievementMemberVerify(c *fiber.Ctx) error {
+	studentID := c.Params("studentId")
+	userID := c.Locals("userId").(string)
+
+	lecturer, err := repository.GetLecturerByUserID(userID)
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "Hanya dosen wali yang dapat memverifikasi"})
+	}
+
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	// bagian pemilik mengikuti reviewer_id seperti verify/reject tunggal & batch
+	ok, err := isAchievementReviewer(lecturer.ID, ref, studentID)
+	if err != nil || !ok {
+		return c.Status(403).JSON(fiber.Map{"error": "Mahasiswa bukan bimbingan anda"})
+	}
+
+	if ref.Status != model.StatusSubmitted {
+		return c.Status(400).JSON(fiber.Map{"error": "Hanya status submitted yang bisa diverifikasi"})
+	}
+
+	members, err := repository.GetAchievementMembers(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil anggota tim"})
+	}
+
+	rule, err := repository.GetActiveScoringRule()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Aturan skor aktif belum dikonfigurasi"})
+	}
+
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+	}
+
+	score := ComputeTeamPoints(rule, doc, countConfirmedMembers(members))
+
+	finalized, err := repository.VerifyAchievementMember(ref.ID, studentID, userID, score.Points, rule.Version)
+	if err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return c.Status(409).JSON(fiber.Map{"error": "Status achievement sudah berubah, muat ulang data"})
+		}
+		if errors.Is(err, repository.ErrMemberNotFound) {
+			return c.Status(400).JSON(fiber.Map{"error": "Anggota tidak terkonfirmasi atau sudah diverifikasi"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memverifikasi anggota tim"})
+	}
+
+	if finalized {
+		if err := repository.UpdateAchievement(ref.MongoAchievementID, bson.M{"points": score.Points}); err != nil {
+			log.Println("⚠️ gagal menyalin poin ke MongoDB:", err)
+		}
+		recordAchievementReview(ref, model.StatusVerified, userID, "")
+	}
+
+	msg := "Anggota tim berhasil diverifikasi"
+	if finalized {
+		msg = "Seluruh anggota tim terverifikasi, achievement berstatus verified"
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": msg,
+		"data": fiber.Map{
+			"points":    score.Points,
+			"score":     score,
+			"finalized": finalized,
+		},
+	})
+}
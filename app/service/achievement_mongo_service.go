@@ -1,9 +1,11 @@
 package service
 
 import (
-	"time"
+	"errors"
+	"log"
 
 	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
 	"prestasi_backend/app/repository"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,87 +19,191 @@ import (
 
 // AchievementList godoc
 // @Summary      Lihat Daftar Prestasi
-// @Description  Menampilkan daftar prestasi berdasarkan Role (Mahasiswa lihat punya sendiri, Dosen lihat bimbingan, Admin lihat semua).
+// @Description  Menampilkan daftar prestasi sesuai scope permission achievement:read (own, advisees, department, all), dengan paginasi (page/limit atau cursor), filter, dan sorting. Prestasi terhapus hanya tampil untuk scope all dengan ?status=deleted.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        page           query  int     false  "Nomor halaman (default 1)"
+// @Param        limit          query  int     false  "Jumlah per halaman (default 20, maks 100)"
+// @Param        cursor         query  string  false  "next_cursor dari response sebelumnya"
+// @Param        sort           query  string  false  "created_at | updated_at | points | status"
+// @Param        order          query  string  false  "asc | desc"
+// @Param        status         query  string  false  "Daftar status dipisah koma"
+// @Param        type           query  string  false  "Daftar jenis prestasi dipisah koma"
+// @Param        tags           query  string  false  "Daftar tag dipisah koma (harus dimiliki semua)"
+// @Param        from           query  string  false  "Dibuat sejak (YYYY-MM-DD)"
+// @Param        to             query  string  false  "Dibuat sampai (YYYY-MM-DD)"
+// @Param        program_study  query  string  false  "Program studi mahasiswa"
+// @Param        academic_year  query  string  false  "Angkatan mahasiswa, mis. 2021/2022"
 // @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
 // @Failure      403  {object} map[string]interface{}
 // @Failure      500  {object} map[string]interface{}
 // @Router       /achievements [get]
 func AchievementList(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	q := parseListQuery(c)
+	scope := policy.Can(claims, "read", "achievement")
 
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	filter, status, msg := achievementFilterFromQuery(c, scope == policy.ScopeAll)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
 
-	// ================================================================
-	// ADMIN
-	// ================================================================
-	if role == "Admin" {
-		refs, err := repository.GetAllAchievementReferences()
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil achievement"})
-		}
+	empty, status, msg := scopeAchievementFilter(claims, scope, &filter)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+	if empty {
+		return buildAchievementResponse(c, nil, singlePage(0, q))
+	}
 
-		return buildAchievementResponse(c, refs)
+	refs, info, err := repository.ListAchievementReferences(filter, q)
+	if err != nil {
+		return listError(c, err, "Gagal mengambil achievement")
 	}
 
-	// DOSEN WALI
-	if role == "Dosen Wali" {
-		lecturer, err := repository.GetLecturerByUserID(userID)
+	return buildAchievementResponse(c, refs, info)
+}
+
+// scopeAchievementFilter mengisi field scope filter (StudentID, AdvisorID,
+// Department) sesuai scope permission achievement:read. empty bernilai true
+// bila dosen belum punya profil sehingga tidak ada prestasi yang terlihat.
+func scopeAchievementFilter(claims *model.JWTClaims, scope policy.Scope, f *model.AchievementFilter) (empty bool, status int, msg string) {
+	switch scope {
+	case policy.ScopeAll:
+		// tanpa batasan tambahan
+
+	case policy.ScopeDepartment:
+		lecturer, err := repository.GetLecturerByUserID(claims.UserID)
+		if err != nil {
+			return true, 0, ""
+		}
+		f.Department = lecturer.Department
+
+	case policy.ScopeAdvisees:
+		lecturer, err := repository.GetLecturerByUserID(claims.UserID)
 		if err != nil {
-			return c.JSON(fiber.Map{"success": true, "count": 0, "data": []any{}})
+			return true, 0, ""
 		}
+		f.AdvisorID = lecturer.ID
 
-		refs, err := repository.GetAchievementReferencesByAdvisor(lecturer.ID)
+	case policy.ScopeOwn:
+		student, err := repository.GetStudentByUserID(claims.UserID)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil achievement dosen wali"})
+			return false, 404, "Data mahasiswa tidak ditemukan"
 		}
+		f.StudentID = student.ID
 
-		// ⬅ RETURN DI SINI (WAJIB)
-		return buildAchievementResponse(c, refs)
+	default:
+		return false, 403, "Forbidden: tidak punya akses membaca prestasi"
 	}
+	return false, 0, ""
+}
 
-	// MAHASISWA
-	if role == "Mahasiswa" {
-		student, err := repository.GetStudentByUserID(userID)
-		if err != nil {
-			return c.Status(404).JSON(fiber.Map{"error": "Data mahasiswa tidak ditemukan"})
+// achievementFilterFromQuery membaca filter list prestasi dari query string.
+// Filter type/tags dijalankan di Mongo lalu hasil ID-nya diteruskan ke Postgre.
+func achievementFilterFromQuery(c *fiber.Ctx, allowDeleted bool) (model.AchievementFilter, int, string) {
+	var f model.AchievementFilter
+
+	for _, st := range queryList(c, "status") {
+		switch st {
+		case model.StatusDraft, model.StatusSubmitted, model.StatusVerified, model.StatusRejected:
+		case model.StatusDeleted:
+			if !allowDeleted {
+				return f, 403, "Tidak boleh melihat prestasi yang sudah dihapus"
+			}
+		default:
+			return f, 400, "Status tidak dikenal: " + st
 		}
+		f.Statuses = append(f.Statuses, st)
+	}
 
-		refs, err := repository.GetAchievementReferencesByStudentID(student.ID)
+	var err error
+	if f.From, err = queryDate(c, "from", false); err != nil {
+		return f, 400, err.Error()
+	}
+	if f.To, err = queryDate(c, "to", true); err != nil {
+		return f, 400, err.Error()
+	}
+
+	f.ProgramStudy = c.Query("program_study")
+	f.AcademicYear = c.Query("academic_year")
+
+	types, tags := queryList(c, "type"), queryList(c, "tags")
+	if len(types) > 0 || len(tags) > 0 {
+		ids, err := repository.FindAchievementIDs(types, tags)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil achievement mahasiswa"})
+			return f, 500, "Gagal memfilter achievement (MongoDB)"
 		}
-
-		return buildAchievementResponse(c, refs)
+		f.MongoIDs = ids
 	}
 
-	return c.Status(403).JSON(fiber.Map{"error": "Role tidak dikenali"})
+	return f, 0, ""
 }
 
 // Join Postgre + Mongo lalu format JSON response
-func buildAchievementResponse(c *fiber.Ctx, refs []model.AchievementReference) error {
-	var results []fiber.Map
+func buildAchievementResponse(c *fiber.Ctx, refs []model.AchievementReference, info model.PageInfo) error {
+	items, issues, err := JoinAchievements(refs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Gagal mengambil data achievement (MongoDB)",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"count":      len(items),
+		"data":       items,
+		"issues":     issues,
+		"pagination": info,
+	})
+}
+
+// JoinAchievements memuat dokumen MongoDB untuk semua reference dalam satu batch
+// dan mengembalikannya sesuai urutan reference. Reference yang dokumennya hilang,
+// rusak, atau ID-nya tidak valid tetap muncul dengan Issue terisi.
+func JoinAchievements(refs []model.AchievementReference) ([]model.AchievementListItem, []model.AchievementIssue, error) {
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.MongoAchievementID
+	}
+
+	found, failed, err := repository.GetAchievementsByIDs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]model.AchievementListItem, 0, len(refs))
+	issues := []model.AchievementIssue{}
 
 	for _, ref := range refs {
-		doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
-		if err != nil {
-			continue
+		item := model.AchievementListItem{Reference: ref}
+
+		if doc, ok := found[ref.MongoAchievementID]; ok {
+			item.Achievement = doc
+		} else {
+			issue := model.AchievementIssue{
+				ReferenceID:        ref.ID,
+				MongoAchievementID: ref.MongoAchievementID,
+				Problem:            model.AchievementIssueMissing,
+			}
+			if ferr, ok := failed[ref.MongoAchievementID]; ok {
+				issue.Problem = model.AchievementIssueCorrupt
+				if errors.Is(ferr, repository.ErrInvalidAchievementID) {
+					issue.Problem = model.AchievementIssueInvalidID
+				}
+				issue.Detail = ferr.Error()
+			}
+			item.Issue = &issue
+			issues = append(issues, issue)
 		}
 
-		results = append(results, fiber.Map{
-			"reference":   ref,
-			"achievement": doc,
-		})
+		items = append(items, item)
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"count":   len(results),
-		"data":    results,
-	})
+	return items, issues, nil
 }
 
 // ==================================================================
@@ -106,29 +212,33 @@ func buildAchievementResponse(c *fiber.Ctx, refs []model.AchievementReference) e
 
 // AchievementDetail godoc
 // @Summary      Detail Prestasi
-// @Description  Melihat detail lengkap satu prestasi berdasarkan ID Reference.
+// @Description  Melihat detail lengkap satu prestasi berdasarkan ID Reference. Setiap attachment dilengkapi URL unduhan dan URL preview (thumbnail gambar / halaman pertama PDF) bertanda tangan.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Achievement Reference ID"
 // @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
 // @Failure      404  {object}  map[string]interface{}
 // @Router       /achievements/{id} [get]
 func AchievementDetail(c *fiber.Ctx) error {
-	id := c.Params("id")
+	ref := c.Locals("achievement").(*model.AchievementReference)
 
-	ref, err := repository.GetAchievementReferenceByID(id)
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{
-			"error": "Achievement tidak ditemukan",
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Gagal mengambil data achievement (MongoDB)",
 		})
 	}
 
-	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	// URL unduhan & preview bertanda tangan agar bukti bisa langsung ditampilkan
+	doc.Attachments = withAttachmentURLs(ref.ID, doc.Attachments)
+
+	ref.Members, err = repository.GetAchievementMembers(ref.ID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
-			"error": "Gagal mengambil data achievement (MongoDB)",
+			"error": "Gagal mengambil anggota tim",
 		})
 	}
 
@@ -147,7 +257,7 @@ func AchievementDetail(c *fiber.Ctx) error {
 
 // AchievementCreate godoc
 // @Summary      Input Prestasi Baru
-// @Description  Mahasiswa menginput data prestasi baru (Status awal: Draft). Disimpan ke MongoDB & PostgreSQL.
+// @Description  Mahasiswa menginput data prestasi baru (Status awal: Draft). Pemilik prestasi diambil dari token; Admin (achievement:create:all) dapat membuat atas nama mahasiswa lewat on_behalf_of dan tercatat di riwayat status. Details divalidasi terhadap skema jenis prestasi (lihat /achievement-types); kesalahan dikembalikan per field. Prestasi tim dapat menyertakan members; anggota selain pembuat harus mengonfirmasi keikutsertaan sebelum submit. Response menyertakan kemungkinan duplikat. Disimpan ke MongoDB & PostgreSQL.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
@@ -155,6 +265,7 @@ func AchievementDetail(c *fiber.Ctx) error {
 // @Param        request body model.AchievementCreateRequest true "Data Prestasi"
 // @Success      201  {object} map[string]interface{}
 // @Failure      400  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
 // @Router       /achievements [post]
 func AchievementCreate(c *fiber.Ctx) error {
 
@@ -163,55 +274,127 @@ func AchievementCreate(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
 	}
 
-	if req.StudentID == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "student_id wajib diisi"})
+	claims := c.Locals("user").(*model.JWTClaims)
+	student, status, msg := resolveAchievementOwner(claims, req.OnBehalfOf)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	// Validasi details terhadap skema jenis prestasi
+	achievementType, err := repository.GetAchievementTypeByCode(req.AchievementType)
+	if err != nil {
+		if repository.IsNoDocuments(err) {
+			return c.Status(400).JSON(fiber.Map{"error": "Jenis prestasi tidak dikenal"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil jenis prestasi"})
+	}
+	if !achievementType.Active {
+		return c.Status(400).JSON(fiber.Map{"error": "Jenis prestasi sudah tidak aktif"})
+	}
+
+	details, fieldErrs := ValidateAchievementDetails(achievementType, req.Details)
+	if req.Title == "" {
+		fieldErrs = append([]model.FieldError{{Field: "title", Message: "wajib diisi"}}, fieldErrs...)
+	}
+
+	// anggota tim (opsional); pemilik menjadi ketua bila tidak ada ketua lain
+	members, memberErrs := NormalizeTeamMembers(student.ID, req.Members)
+	if len(memberErrs) == 0 && len(members) > 1 {
+		memberErrs = validateMemberStudents(members[1:])
+	}
+	fieldErrs = append(fieldErrs, memberErrs...)
+
+	if len(fieldErrs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
 	}
 
-	// Insert ke MongoDB
 	doc := model.AchievementMongo{
-		StudentID:       req.StudentID,
+		StudentID:       student.ID,
 		AchievementType: req.AchievementType,
 		Title:           req.Title,
 		Description:     req.Description,
-		Details:         req.Details,
+		Details:         details,
 		Tags:            req.Tags,
-		Points:          req.Points,
 	}
+	doc.DedupKeys = DedupKeys(&doc)
 
-	mongoID, err := repository.CreateAchievement(&doc)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan achievement ke MongoDB"})
+	ref := model.AchievementReference{
+		ID:        uuid.NewString(),
+		StudentID: student.ID,
+		Status:    model.StatusDraft,
+		Members:   members,
 	}
 
-	// Insert ke Postgre
-	ref := model.AchievementReference{
-		ID:                 uuid.NewString(),
-		StudentID:          req.StudentID,
-		MongoAchievementID: mongoID,
-		Status:             "draft",
+	// Insert ke MongoDB lalu Postgre; dokumen MongoDB dihapus lagi bila Postgre gagal
+	// pembuatan atas nama mahasiswa dicatat pada riwayat status awal
+	var note *string
+	if req.OnBehalfOf != "" {
+		n := "on_behalf_of: dibuat oleh " + claims.RoleName + " atas nama mahasiswa " + student.StudentID
+		note = &n
 	}
 
-	if err := repository.CreateAchievementReference(&ref); err != nil {
+	if err := CreateAchievementRecord(&doc, &ref, claims.UserID, note); err != nil {
+		if errors.Is(err, ErrAchievementDocument) {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan achievement ke MongoDB"})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan reference achievement"})
 	}
 
+	// peringatan dini bila prestasi yang sama sudah pernah diinput
+	duplicates := recordDuplicateCheck(&doc)
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Achievement berhasil dibuat",
 		"data": fiber.Map{
 			"reference":   ref,
 			"achievement": doc,
+			"duplicates":  duplicates,
 		},
 	})
 }
 
+// resolveAchievementOwner menentukan mahasiswa pemilik prestasi baru. Tanpa
+// onBehalfOf pemiliknya adalah mahasiswa dari token; onBehalfOf hanya boleh
+// dipakai pemegang achievement:create:all (Admin).
+func resolveAchievementOwner(claims *model.JWTClaims, onBehalfOf string) (*model.Student, int, string) {
+	if onBehalfOf != "" {
+		if policy.Can(claims, "create", "achievement") != policy.ScopeAll {
+			return nil, 403, "Tidak boleh membuat prestasi atas nama mahasiswa lain"
+		}
+		student, err := repository.GetStudentByID(onBehalfOf)
+		if err != nil {
+			if repository.IsNoRows(err) {
+				return nil, 400, "Mahasiswa on_behalf_of tidak ditemukan"
+			}
+			return nil, 500, "Gagal mengambil data mahasiswa"
+		}
+		if student.DeactivatedAt != nil {
+			return nil, 400, "Profil mahasiswa on_behalf_of sudah nonaktif"
+		}
+		return student, 0, ""
+	}
+
+	student, err := repository.GetStudentByUserID(claims.UserID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return nil, 403, "Hanya mahasiswa yang dapat membuat prestasi; admin gunakan on_behalf_of"
+		}
+		return nil, 500, "Gagal mengambil data mahasiswa"
+	}
+	if student.DeactivatedAt != nil {
+		return nil, 403, "Profil mahasiswa sudah nonaktif"
+	}
+	return student, 0, ""
+}
+
 // ==================================================================
 // UPDATE ACHIEVEMENT
 // ==================================================================
 
 // AchievementUpdate godoc
 // @Summary      Update Data Prestasi
-// @Description  Mengubah data prestasi di MongoDB. Hanya bisa dilakukan jika status masih 'draft'.
+// @Description  Mengubah data prestasi di MongoDB. Hanya untuk status 'draft' dan minimal satu field diisi; prestasi 'rejected' harus dibuka dulu lewat POST /achievements/{id}/revise (409).
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
@@ -220,40 +403,61 @@ func AchievementCreate(c *fiber.Ctx) error {
 // @Param        request body  model.AchievementUpdateRequest true "Data Update"
 // @Success      200     {object} map[string]interface{}
 // @Failure      400     {object} map[string]interface{}
+// @Failure      403     {object} map[string]interface{}
+// @Failure      409     {object} map[string]interface{}
 // @Router       /achievements/{id} [put]
 func AchievementUpdate(c *fiber.Ctx) error {
-	id := c.Params("id")
-
 	var req model.AchievementUpdateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
 	}
 
-	ref, err := repository.GetAchievementReferenceByID(id)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Achievement tidak ditemukan"})
-	}
+	ref := c.Locals("achievement").(*model.AchievementReference)
 
-	if ref.Status != "draft" {
+	// prestasi yang ditolak harus dibuka kembali lewat /revise agar transisi
+	// status dan history-nya tercatat terpisah dari perubahan isi
+	if ref.Status == model.StatusRejected {
+		return c.Status(409).JSON(fiber.Map{"error": "Achievement ditolak, buka revisi lewat POST /achievements/{id}/revise terlebih dulu"})
+	}
+	if ref.Status != model.StatusDraft {
 		return c.Status(400).JSON(fiber.Map{"error": "Hanya achievement draft yang dapat diupdate"})
 	}
 
 	update := bson.M{}
 
+	// details divalidasi terhadap skema jenis prestasi dokumen ini
+	if req.Details != nil {
+		current, err := repository.GetAchievementByID(ref.MongoAchievementID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+		}
+
+		achievementType, err := repository.GetAchievementTypeByCode(current.AchievementType)
+		if err != nil {
+			if repository.IsNoDocuments(err) {
+				return c.Status(400).JSON(fiber.Map{"error": "Jenis prestasi tidak dikenal"})
+			}
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil jenis prestasi"})
+		}
+
+		details, fieldErrs := ValidateAchievementDetails(achievementType, req.Details)
+		if len(fieldErrs) > 0 {
+			return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
+		}
+		update["details"] = details
+	}
+
 	if req.Title != "" {
 		update["title"] = req.Title
 	}
 	if req.Description != "" {
 		update["description"] = req.Description
 	}
-	if req.Details != nil {
-		update["details"] = req.Details
-	}
 	if req.Tags != nil {
 		update["tags"] = req.Tags
 	}
-	if req.Points != 0 {
-		update["points"] = req.Points
+	if len(update) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Tidak ada data yang diubah"})
 	}
 
 	if err := repository.UpdateAchievement(ref.MongoAchievementID, update); err != nil {
@@ -272,7 +476,7 @@ func AchievementUpdate(c *fiber.Ctx) error {
 
 // AchievementDelete godoc
 // @Summary      Hapus Prestasi (Soft Delete)
-// @Description  Menghapus prestasi. Mahasiswa hanya bisa hapus 'draft'. Admin bisa hapus kapan saja.
+// @Description  Menghapus prestasi. Scope achievement:delete:all bisa hapus kapan saja, scope lain hanya 'draft' yang terjangkau.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
@@ -282,41 +486,29 @@ func AchievementUpdate(c *fiber.Ctx) error {
 // @Failure      403  {object} map[string]interface{}
 // @Router       /achievements/{id} [delete]
 func AchievementDelete(c *fiber.Ctx) error {
-	refID := c.Params("id")
-	userID := c.Locals("userId").(string)
-	role := c.Locals("role").(string)
-
-	// Get reference
-	ref, err := repository.GetAchievementReferenceByID(refID)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Data achievement tidak ditemukan"})
+	claims := c.Locals("user").(*model.JWTClaims)
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	// ========= POLICY VALIDATION =========
+	// hak akses sudah dicek AchievementAccess("delete"); selain scope all
+	// hanya prestasi draft yang boleh dihapus
+	privileged := policy.Can(claims, "delete", "achievement") == policy.ScopeAll
+	if !privileged && ref.Status != model.StatusDraft {
+		return c.Status(403).JSON(fiber.Map{
+			"error": "Hanya prestasi berstatus draft yang boleh dihapus",
+		})
 	}
 
-	// ========= ROLE VALIDATION =========
-	switch role {
-	case "Admin":
-		// Admin bebas — tidak dicek status
-
-	case "Mahasiswa":
-		student, _ := repository.GetStudentByUserID(userID)
-		if student == nil || student.ID != ref.StudentID {
-			return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh menghapus prestasi orang lain"})
-		}
-
-		if ref.Status != "draft" {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Mahasiswa hanya boleh menghapus prestasi berstatus draft",
-			})
-		}
-
-	default:
-		// Dosen atau role lain tidak boleh hapus
-		return c.Status(403).JSON(fiber.Map{"error": "Role ini tidak diizinkan melakukan hapus"})
+	if !CanTransitionAchievement(ref.Status, model.StatusDeleted, privileged) {
+		return c.Status(400).JSON(fiber.Map{"error": "Prestasi sudah dihapus"})
 	}
 
 	// ============ Soft delete Postgre ============
-	err = repository.SoftDeleteAchievementReference(refID)
+	err := repository.SoftDeleteAchievementReference(ref.ID, ref.Status, claims.UserID)
 	if err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return c.Status(409).JSON(fiber.Map{"error": "Status achievement sudah berubah, muat ulang data"})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus achievement"})
 	}
 
@@ -332,7 +524,7 @@ func AchievementDelete(c *fiber.Ctx) error {
 
 // AchievementSubmit godoc
 // @Summary      Ajukan Prestasi (Submit)
-// @Description  Mengubah status prestasi dari 'draft' menjadi 'submitted' agar bisa diverifikasi dosen.
+// @Description  Mengubah status prestasi dari 'draft' menjadi 'submitted' agar bisa diverifikasi dosen. Prestasi tim hanya bisa disubmit setelah semua anggota mengonfirmasi atau menolak. Kemungkinan duplikat dicatat pada dokumen (field duplicates) untuk dosen verifikator.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
@@ -354,10 +546,7 @@ func AchievementSubmit(c *fiber.Ctx) error {
 	}
 
 	// ambil reference prestasi
-	ref, err := repository.GetAchievementReferenceByID(achievementID)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Achievement tidak ditemukan"})
-	}
+	ref := c.Locals("achievement").(*model.AchievementReference)
 
 	// validasi kepemilikan
 	if ref.StudentID != student.ID {
@@ -365,19 +554,44 @@ func AchievementSubmit(c *fiber.Ctx) error {
 	}
 
 	// validasi status
-	if ref.Status != "draft" {
+	if !CanTransitionAchievement(ref.Status, model.StatusSubmitted, false) {
 		return c.Status(400).JSON(fiber.Map{"error": "Hanya status draft yang bisa submit"})
 	}
 
+	// prestasi tim hanya bisa diajukan setelah semua anggota merespons
+	members, err := repository.GetAchievementMembers(ref.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil anggota tim"})
+	}
+	for _, m := range members {
+		if m.Status == model.MemberPending {
+			return c.Status(400).JSON(fiber.Map{"error": "Masih ada anggota tim yang belum mengonfirmasi keikutsertaan"})
+		}
+	}
+
 	// update status → submitted
-	err = repository.SubmitAchievementReference(achievementID)
+	err = repository.SubmitAchievementReference(achievementID, userID)
 	if err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return c.Status(409).JSON(fiber.Map{"error": "Status achievement sudah berubah, muat ulang data"})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal submit achievement"})
 	}
 
+	// hasil pemeriksaan duplikat disimpan di dokumen untuk dosen verifikator
+	var duplicates []model.DuplicateMatch
+	if doc, err := repository.GetAchievementByID(ref.MongoAchievementID); err != nil {
+		log.Println("⚠️ gagal mengambil dokumen untuk pemeriksaan duplikat:", err)
+	} else {
+		duplicates = recordDuplicateCheck(doc)
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "Achievement berhasil dikirim untuk diverifikasi",
+		"data": fiber.Map{
+			"duplicates": duplicates,
+		},
 	})
 }
 
@@ -387,7 +601,7 @@ func AchievementSubmit(c *fiber.Ctx) error {
 
 // AchievementVerify godoc
 // @Summary      Verifikasi Prestasi (Dosen)
-// @Description  Dosen menyetujui prestasi mahasiswa bimbingannya. Status berubah jadi 'verified'.
+// @Description  Dosen menyetujui prestasi mahasiswa bimbingannya. Hanya status 'submitted' yang bisa diverifikasi; status berubah jadi 'verified' dan poin dihitung dengan aturan skor aktif. Untuk prestasi tim, dosen wali pemilik memverifikasi sekaligus seluruh anggota yang sudah konfirmasi.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
@@ -397,7 +611,7 @@ func AchievementSubmit(c *fiber.Ctx) error {
 // @Failure      403  {object} map[string]interface{}
 // @Router       /achievements/{id}/verify [post]
 func AchievementVerify(c *fiber.Ctx) error {
-	achievementID := c.Params("id")
+	ref := c.Locals("achievement").(*model.AchievementReference)
 	userID := c.Locals("userId").(string)
 
 	// ambil dosen via user id
@@ -406,28 +620,74 @@ func AchievementVerify(c *fiber.Ctx) error {
 		return c.Status(403).JSON(fiber.Map{"error": "Hanya dosen wali yang dapat memverifikasi"})
 	}
 
-	// ambil reference
-	ref, err := repository.GetAchievementReferenceByID(achievementID)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Achievement tidak ditemukan"})
+	score, status, msg := verifyAchievement(lecturer, userID, ref)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
 	}
 
-	// cek apakah mahasiswa tersebut memang bimbingannya
-	ok, err := repository.IsStudentAdvisedBy(lecturer.ID, ref.StudentID)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Achievement berhasil diverifikasi",
+		"data": fiber.Map{
+			"points": score.Points,
+			"score":  score,
+		},
+	})
+}
+
+// verifyAchievement menjalankan verifikasi satu prestasi oleh dosen wali.
+// Dipakai endpoint tunggal maupun batch; mengembalikan status HTTP dan
+// pesan error bila gagal.
+func verifyAchievement(lecturer *model.Lecturer, userID string, ref *model.AchievementReference) (model.ScoreBreakdown, int, string) {
+	var score model.ScoreBreakdown
+
+	// cek apakah dosen ini peninjau pengajuan (dosen wali, atau dosen wali lama yang mempertahankannya)
+	ok, err := isAchievementReviewer(lecturer.ID, ref, ref.StudentID)
 	if err != nil || !ok {
-		return c.Status(403).JSON(fiber.Map{"error": "Mahasiswa bukan bimbingan anda"})
+		return score, 403, "Mahasiswa bukan bimbingan anda"
 	}
 
+	// validasi status
+	if !CanTransitionAchievement(ref.Status, model.StatusVerified, false) {
+		return score, 400, "Hanya status submitted yang bisa diverifikasi"
+	}
+
+	// hitung poin dengan aturan skor aktif; poin dari klien tidak pernah dipakai
+	rule, err := repository.GetActiveScoringRule()
+	if err != nil {
+		return score, 500, "Aturan skor aktif belum dikonfigurasi"
+	}
+
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		return score, 500, "Gagal mengambil data achievement (MongoDB)"
+	}
+
+	members, err := repository.GetAchievementMembers(ref.ID)
+	if err != nil {
+		return score, 500, "Gagal mengambil anggota tim"
+	}
+
+	// poin per anggota; untuk tim dibagi sesuai team_split aturan skor
+	score = ComputeTeamPoints(rule, doc, countConfirmedMembers(members))
+
 	// update → verified
-	err = repository.VerifyAchievementReference(achievementID, userID)
+	err = repository.VerifyAchievementReference(ref.ID, userID, score.Points, rule.Version)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal memverifikasi achievement"})
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return score, 409, "Status achievement sudah berubah, muat ulang data"
+		}
+		return score, 500, "Gagal memverifikasi achievement"
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Achievement berhasil diverifikasi",
-	})
+	// salinan poin di MongoDB hanya untuk tampilan; sumber kebenaran ada di Postgre
+	if err := repository.UpdateAchievement(ref.MongoAchievementID, bson.M{"points": score.Points}); err != nil {
+		log.Println("⚠️ gagal menyalin poin ke MongoDB:", err)
+	}
+
+	recordAchievementReview(ref, model.StatusVerified, userID, "")
+
+	return score, 0, ""
 }
 
 // ==================================================================
@@ -436,7 +696,7 @@ func AchievementVerify(c *fiber.Ctx) error {
 
 // AchievementReject godoc
 // @Summary      Tolak Prestasi (Dosen)
-// @Description  Dosen menolak prestasi mahasiswa. Wajib menyertakan alasan penolakan.
+// @Description  Dosen menolak prestasi mahasiswa berstatus 'submitted'. Wajib menyertakan alasan penolakan.
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
@@ -447,7 +707,7 @@ func AchievementVerify(c *fiber.Ctx) error {
 // @Failure      400     {object} map[string]interface{}
 // @Router       /achievements/{id}/reject [post]
 func AchievementReject(c *fiber.Ctx) error {
-	achievementID := c.Params("id")
+	ref := c.Locals("achievement").(*model.AchievementReference)
 	userID := c.Locals("userId").(string)
 
 	var req model.AchievementRejectRequest
@@ -460,25 +720,40 @@ func AchievementReject(c *fiber.Ctx) error {
 		return c.Status(403).JSON(fiber.Map{"error": "Hanya dosen wali yang dapat menolak"})
 	}
 
-	ref, err := repository.GetAchievementReferenceByID(achievementID)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Achievement tidak ditemukan"})
+	if status, msg := rejectAchievement(lecturer, userID, ref, req.Note); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
 	}
 
-	ok, err := repository.IsStudentAdvisedBy(lecturer.ID, ref.StudentID)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Achievement berhasil ditolak",
+	})
+}
+
+// rejectAchievement menjalankan penolakan satu prestasi oleh dosen wali.
+// Dipakai endpoint tunggal maupun batch.
+func rejectAchievement(lecturer *model.Lecturer, userID string, ref *model.AchievementReference, note string) (int, string) {
+	ok, err := isAchievementReviewer(lecturer.ID, ref, ref.StudentID)
 	if err != nil || !ok {
-		return c.Status(403).JSON(fiber.Map{"error": "Mahasiswa bukan bimbingan anda"})
+		return 403, "Mahasiswa bukan bimbingan anda"
 	}
 
-	err = repository.RejectAchievementReference(achievementID, userID, req.Note)
+	if !CanTransitionAchievement(ref.Status, model.StatusRejected, false) {
+		return 400, "Hanya status submitted yang bisa ditolak"
+	}
+
+	err = repository.RejectAchievementReference(ref.ID, userID, note)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menolak achievement"})
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return 409, "Status achievement sudah berubah, muat ulang data"
+		}
+		return 500, "Gagal menolak achievement"
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"message": "Achievement berhasil ditolak",
-	})
+	recordAchievementReview(ref, model.StatusRejected, userID, note)
+	recordRejectionComment(ref.ID, userID, note)
+
+	return 0, ""
 }
 
 // ==================================================================
@@ -487,96 +762,33 @@ func AchievementReject(c *fiber.Ctx) error {
 
 // AchievementHistory godoc
 // @Summary      History Status Prestasi
-// @Description  Melihat riwayat kapan prestasi disubmit, diverifikasi, atau ditolak.
+// @Description  Melihat timeline lengkap transisi status prestasi (siapa, kapan, dari-ke status, dan catatan).
 // @Tags         Achievement
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Achievement ID"
 // @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
 // @Router       /achievements/{id}/history [get]
 func AchievementHistory(c *fiber.Ctx) error {
-	achievementID := c.Params("id")
+	ref := c.Locals("achievement").(*model.AchievementReference)
 
-	ref, err := repository.GetAchievementReferenceByID(achievementID)
+	timeline, err := repository.GetAchievementStatusHistory(ref.ID)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Achievement tidak ditemukan"})
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil riwayat status"})
+	}
+	if timeline == nil {
+		timeline = []model.AchievementStatusHistory{}
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data": fiber.Map{
-			"status":         ref.Status,
-			"submitted_at":   ref.SubmittedAt,
-			"verified_at":    ref.VerifiedAt,
-			"verified_by":    ref.VerifiedBy,
-			"rejection_note": ref.RejectionNote,
+			"status":   ref.Status,
+			"timeline": timeline,
 		},
 	})
 }
-
-// ==================================================================
-// UPLOAD ATTACHMENT
-// ==================================================================
-
-// AchievementUploadAttachment godoc
-// @Summary      Upload Bukti (File)
-// @Description  Upload file sertifikat/bukti prestasi. Format file bisa gambar/PDF.
-// @Tags         Achievement
-// @Accept       multipart/form-data
-// @Produce      json
-// @Security     BearerAuth
-// @Param        id    path      string  true  "Achievement ID"
-// @Param        file  formData  file    true  "File Attachment"
-// @Success      200   {object} map[string]interface{}
-// @Failure      400   {object} map[string]interface{}
-// @Router       /achievements/{id}/attachments [post]
-func AchievementUploadAttachment(c *fiber.Ctx) error {
-	refID := c.Params("id")
-	userID := c.Locals("userId").(string)
-	role := c.Locals("role").(string)
-
-	// Ambil reference dari Postgre
-	ref, err := repository.GetAchievementReferenceByID(refID)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Data achievement tidak ditemukan"})
-	}
-
-	// Cek hak upload
-	switch role {
-	case "Admin":
-		// boleh semua
-	case "Mahasiswa":
-		student, _ := repository.GetStudentByUserID(userID)
-		if student == nil || student.ID != ref.StudentID {
-			return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengupload file ke prestasi orang lain"})
-		}
-	default:
-		return c.Status(403).JSON(fiber.Map{"error": "Role ini tidak diperbolehkan upload attachment"})
-	}
-
-	// Ambil file upload
-	f, err := c.FormFile("file")
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "File tidak ditemukan"})
-	}
-
-	filename := time.Now().Format("20060102_150405") + "_" + f.Filename
-	path := "./uploads/" + filename
-
-	if err := c.SaveFile(f, path); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan file"})
-	}
-
-	// Tambah attachment ke dokumen Mongo
-	err = repository.AddAchievementAttachment(ref.MongoAchievementID, filename)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan attachment"})
-	}
-
-	return c.JSON(fiber.Map{
-		"success":  true,
-		"message":  "Attachment berhasil ditambahkan",
-		"filename": filename,
-	})
-}
@@ -7,30 +7,36 @@ import (
 )
 
 func SubmitAchievement(data model.AchievementMongo) (string, error) {
-	// 1. Validasi Input (Cara Mengakses Map)
-	
+	// 1. Validasi Input terhadap skema jenis prestasi
+
 	// Cek apakah 'Details' ada isinya
 	if data.Details == nil {
 		return "", errors.New("details tidak boleh kosong")
 	}
 
-	// Ambil value 'competitionName' dari map
-	compName, ok := data.Details["competitionName"].(string) // Type assertion ke string
-	if !ok || compName == "" {
-		return "", errors.New("nama kompetisi tidak boleh kosong")
+	// Data lama tanpa jenis dianggap kompetisi
+	code := data.AchievementType
+	if code == "" {
+		code = "competition"
+	}
+
+	achievementType, err := repository.GetAchievementTypeByCode(code)
+	if err != nil {
+		if repository.IsNoDocuments(err) {
+			return "", errors.New("jenis prestasi tidak dikenal: " + code)
+		}
+		return "", err
 	}
 
-	// Ambil value 'rank' dari map
-	rank, ok := data.Details["rank"].(int) // Type assertion ke int
-	if !ok || rank < 1 {
-		// Note: Kalau di JSON rank seringkali terbaca float64, 
-		// jadi handle kalau perlu, tapi untuk unit test manual 'int' aman.
-		return "", errors.New("ranking tidak valid")
+	// Validator menangani angka JSON (float64) maupun int
+	details, fieldErrs := ValidateAchievementDetails(achievementType, data.Details)
+	if len(fieldErrs) > 0 {
+		return "", &ValidationError{Fields: fieldErrs}
 	}
+	data.AchievementType = code
+	data.Details = details
 
 	// 2. Panggil Repository
-	// Sesuaikan apakah repository minta pointer (&data) atau value (data)
-	// Berdasarkan chat sebelumnya repo kamu minta Value (tanpa bintang), jadi:
 	id, err := repository.CreateAchievement(&data)
 	if err != nil {
 		return "", err
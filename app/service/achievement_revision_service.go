@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"reflect"
+	"sort"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ==================================================================
+// REVISE (REJECTED → DRAFT)
+// ==================================================================
+
+// AchievementRevise godoc
+// @Summary      Revisi Prestasi yang Ditolak
+// @Description  Membuka kembali prestasi berstatus 'rejected' menjadi 'draft' agar bisa diperbaiki dan disubmit ulang. Catatan penolakan ronde sebelumnya tetap tersimpan di history.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  string                          true   "Achievement ID"
+// @Param        request body  model.AchievementReviseRequest  false  "Catatan revisi"
+// @Success      200     {object} map[string]interface{}
+// @Failure      400     {object} map[string]interface{}
+// @Failure      403     {object} map[string]interface{}
+// @Failure      404     {object} map[string]interface{}
+// @Router       /achievements/{id}/revise [post]
+func AchievementRevise(c *fiber.Ctx) error {
+	achievementID := c.Params("id")
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	var req model.AchievementReviseRequest
+	_ = c.BodyParser(&req)
+
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	if !CanTransitionAchievement(ref.Status, model.StatusDraft, false) {
+		return c.Status(400).JSON(fiber.Map{"error": "Hanya prestasi berstatus rejected yang bisa direvisi"})
+	}
+
+	var note *string
+	if req.Note != "" {
+		note = &req.Note
+	}
+
+	if err := repository.ReviseAchievementReference(achievementID, claims.UserID, note); err != nil {
+		if errors.Is(err, repository.ErrStatusConflict) {
+			return c.Status(409).JSON(fiber.Map{"error": "Status achievement sudah berubah, muat ulang data"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuka revisi achievement"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Achievement kembali ke draft, silakan perbaiki lalu submit ulang",
+	})
+}
+
+// ==================================================================
+// REVIEW ROUNDS
+// ==================================================================
+
+// AchievementReviews godoc
+// @Summary      Riwayat Ronde Review
+// @Description  Menampilkan setiap ronde review (verifikasi/penolakan) beserta catatan dan snapshot dokumen saat direview.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Achievement ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/reviews [get]
+func AchievementReviews(c *fiber.Ctx) error {
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	reviews, err := repository.GetAchievementReviews(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil riwayat review"})
+	}
+	if reviews == nil {
+		reviews = []model.AchievementReview{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(reviews),
+		"data":    reviews,
+	})
+}
+
+// ==================================================================
+// DIFF SINCE LAST REVIEW
+// ==================================================================
+
+// AchievementDiff godoc
+// @Summary      Perubahan Sejak Review Terakhir
+// @Description  Membandingkan dokumen prestasi saat ini dengan snapshot pada review terakhir, per field (termasuk per key di details).
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Achievement ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/diff [get]
+func AchievementDiff(c *fiber.Ctx) error {
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	last, err := repository.GetLatestAchievementReview(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil review terakhir"})
+	}
+	if last == nil {
+		return c.JSON(fiber.Map{
+			"success": true,
+			"message": "Achievement belum pernah direview",
+			"data": fiber.Map{
+				"last_review": nil,
+				"changes":     []model.AchievementFieldChange{},
+			},
+		})
+	}
+
+	current, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"last_review": fiber.Map{
+				"round":       last.Round,
+				"decision":    last.Decision,
+				"note":        last.Note,
+				"reviewer_id": last.ReviewerID,
+				"reviewed_at": last.ReviewedAt,
+			},
+			"changes": DiffAchievement(&last.Snapshot, current),
+		},
+	})
+}
+
+// DiffAchievement membandingkan dua versi dokumen prestasi. Details
+// dibandingkan per key sehingga perubahan terlihat sebagai "details.<key>".
+func DiffAchievement(before, after *model.AchievementMongo) []model.AchievementFieldChange {
+	changes := []model.AchievementFieldChange{}
+
+	add := func(field string, b, a interface{}) {
+		if !reflect.DeepEqual(b, a) {
+			changes = append(changes, model.AchievementFieldChange{Field: field, Before: b, After: a})
+		}
+	}
+
+	add("achievementType", before.AchievementType, after.AchievementType)
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("tags", normalizeEmpty(before.Tags), normalizeEmpty(after.Tags))
+	add("attachments", normalizeEmpty(before.Attachments), normalizeEmpty(after.Attachments))
+	add("points", before.Points, after.Points)
+
+	keys := map[string]bool{}
+	for k := range before.Details {
+		keys[k] = true
+	}
+	for k := range after.Details {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		add("details."+k, before.Details[k], after.Details[k])
+	}
+
+	return changes
+}
+
+// normalizeEmpty menyamakan slice nil dan slice kosong agar tidak dianggap berubah
+func normalizeEmpty(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.Len() == 0 {
+		return nil
+	}
+	return v
+}
+
+// recordAchievementReview menyimpan snapshot dokumen pada saat review.
+// Status sudah berpindah ketika fungsi ini dipanggil, jadi kegagalan hanya dicatat ke log.
+func recordAchievementReview(ref *model.AchievementReference, decision, reviewerID, note string) {
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		log.Println("⚠️ gagal mengambil dokumen untuk snapshot review:", err)
+		return
+	}
+
+	previous, err := repository.GetAchievementReviews(ref.MongoAchievementID)
+	if err != nil {
+		log.Println("⚠️ gagal menghitung ronde review:", err)
+		return
+	}
+
+	review := model.AchievementReview{
+		AchievementID: ref.MongoAchievementID,
+		ReferenceID:   ref.ID,
+		Round:         len(previous) + 1,
+		Decision:      decision,
+		ReviewerID:    reviewerID,
+		Note:          note,
+		Snapshot:      *doc,
+	}
+
+	if err := repository.CreateAchievementReview(&review); err != nil {
+		log.Println("⚠️ gagal menyimpan snapshot review:", err)
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Error tiap langkah saga pembuatan prestasi
+var (
+	ErrAchievementDocument  = errors.New("gagal menyimpan achievement ke MongoDB")
+	ErrAchievementReference = errors.New("gagal menyimpan reference achievement")
+)
+
+// CreateAchievementRecord menyimpan prestasi ke dua store sebagai saga:
+// dokumen MongoDB dibuat lebih dulu, lalu reference Postgre. Jika langkah
+// kedua gagal, dokumen MongoDB dihapus kembali (kompensasi). Kompensasi yang
+// gagal hanya dicatat; dokumen yatimnya akan ditemukan oleh RunReconciliation.
+// Pemilik dokumen selalu disamakan dengan ref.StudentID; note dicatat pada
+// riwayat status awal (mis. pembuatan atas nama mahasiswa).
+func CreateAchievementRecord(doc *model.AchievementMongo, ref *model.AchievementReference, actorID string, note *string) error {
+	doc.StudentID = ref.StudentID
+
+	mongoID, err := repository.CreateAchievement(doc)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAchievementDocument, err)
+	}
+	ref.MongoAchievementID = mongoID
+	if oid, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+		doc.ID = oid
+	}
+
+	if err := repository.CreateAchievementReference(ref, actorID, note); err != nil {
+		if derr := repository.DeleteAchievement(mongoID); derr != nil {
+			log.Println("⚠️ kompensasi gagal, dokumen achievement yatim:", mongoID, derr)
+		}
+		return fmt.Errorf("%w: %v", ErrAchievementReference, err)
+	}
+
+	return nil
+}
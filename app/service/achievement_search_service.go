@@ -0,0 +1,293 @@
+package service
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// Batas dokumen hasil text search yang diproses per pencarian
+	maxSearchCandidates = 1000
+	// Panjang maksimal cuplikan highlight (rune)
+	searchSnippetLength = 160
+	// Jumlah maksimal nilai facet tag
+	maxTagFacets = 20
+)
+
+// searchTerms mengambil kata dari query text search MongoDB: tanda kutip
+// frasa diabaikan dan kata yang dinegasikan (-kata) tidak di-highlight
+func searchTerms(query string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, tok := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.HasPrefix(tok, "-") {
+			continue
+		}
+		for _, w := range strings.FieldsFunc(tok, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			w = strings.ToLower(w)
+			if !seen[w] {
+				seen[w] = true
+				terms = append(terms, w)
+			}
+		}
+	}
+	return terms
+}
+
+// highlightPattern mencocokkan kata utuh (tanpa membedakan huruf besar/kecil)
+func highlightPattern(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// HighlightText meng-escape teks lalu membungkus kata yang cocok dengan
+// <mark>…</mark>. Teks yang lebih panjang dari maxLen rune dipotong menjadi
+// cuplikan di sekitar kecocokan pertama. ok bernilai false bila tidak ada yang cocok.
+func HighlightText(text string, terms []string, maxLen int) (string, bool) {
+	re := highlightPattern(terms)
+	if re == nil {
+		return "", false
+	}
+	matches := re.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	start, end := 0, len(text)
+	if utf8.RuneCountInString(text) > maxLen {
+		// mulai sedikit sebelum kecocokan pertama, dibulatkan ke batas rune
+		before := []rune(text[:matches[0][0]])
+		from := max(0, len(before)-maxLen/4)
+		start = len(string(before[:from]))
+
+		rest := []rune(text[start:])
+		end = start + len(string(rest[:min(len(rest), maxLen)]))
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		if m[0] < start {
+			continue
+		}
+		if m[1] > end {
+			break
+		}
+		b.WriteString(html.EscapeString(text[pos:m[0]]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[m[0]:m[1]]))
+		b.WriteString("</mark>")
+		pos = m[1]
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// searchHighlights menyusun highlight per field yang cocok
+func searchHighlights(doc *model.AchievementMongo, terms []string) map[string]string {
+	out := map[string]string{}
+	add := func(field, text string) {
+		if h, ok := HighlightText(text, terms, searchSnippetLength); ok {
+			out[field] = h
+		}
+	}
+
+	add("title", doc.Title)
+	add("description", doc.Description)
+	add("tags", strings.Join(doc.Tags, ", "))
+	for _, k := range repository.SearchableDetailKeys {
+		if s, ok := doc.Details[k].(string); ok {
+			add("details."+k, s)
+		}
+	}
+	return out
+}
+
+// facetCounts mengurutkan facet dari jumlah terbanyak, lalu nilainya
+func facetCounts(counts map[string]int, limit int) []model.FacetCount {
+	list := make([]model.FacetCount, 0, len(counts))
+	for v, n := range counts {
+		list = append(list, model.FacetCount{Value: v, Count: n})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Value < list[j].Value
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// RunAchievementSearch menjalankan text search di MongoDB lalu membatasi
+// hasilnya dengan filter Postgre (scope, status, tanggal, dsb.). Hasil urut
+// menurut skor relevansi; facet dihitung dari seluruh hasil yang terlihat.
+func RunAchievementSearch(query string, f model.AchievementFilter) ([]model.AchievementSearchHit, model.SearchFacets, error) {
+	facets := model.SearchFacets{Types: []model.FacetCount{}, Tags: []model.FacetCount{}, Statuses: []model.FacetCount{}}
+
+	docs, scores, err := repository.SearchAchievementsText(query, maxSearchCandidates)
+	if err != nil {
+		return nil, facets, err
+	}
+
+	// filter type/tags dari query string (f.MongoIDs) diiriskan dengan hasil pencarian
+	var allowed map[string]bool
+	if f.MongoIDs != nil {
+		allowed = make(map[string]bool, len(f.MongoIDs))
+		for _, id := range f.MongoIDs {
+			allowed[id] = true
+		}
+	}
+
+	byID := make(map[string]*model.AchievementMongo, len(docs))
+	ids := []string{}
+	for i := range docs {
+		id := docs[i].ID.Hex()
+		if allowed != nil && !allowed[id] {
+			continue
+		}
+		byID[id] = &docs[i]
+		ids = append(ids, id)
+	}
+
+	hits := []model.AchievementSearchHit{}
+	if len(ids) == 0 {
+		return hits, facets, nil
+	}
+
+	f.MongoIDs = ids
+	refs, err := repository.FilterAchievementReferences(f)
+	if err != nil {
+		return nil, facets, err
+	}
+
+	terms := searchTerms(query)
+	types, tags, statuses := map[string]int{}, map[string]int{}, map[string]int{}
+
+	for _, ref := range refs {
+		doc, ok := byID[ref.MongoAchievementID]
+		if !ok {
+			continue
+		}
+		hits = append(hits, model.AchievementSearchHit{
+			Reference:   ref,
+			Achievement: doc,
+			Score:       scores[ref.MongoAchievementID],
+			Highlights:  searchHighlights(doc, terms),
+		})
+
+		types[doc.AchievementType]++
+		statuses[ref.Status]++
+		for _, t := range doc.Tags {
+			tags[t]++
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Reference.CreatedAt.After(hits[j].Reference.CreatedAt)
+	})
+
+	facets.Types = facetCounts(types, 0)
+	facets.Tags = facetCounts(tags, maxTagFacets)
+	facets.Statuses = facetCounts(statuses, 0)
+	return hits, facets, nil
+}
+
+// ==================================================================
+// SEARCH ACHIEVEMENTS
+// ==================================================================
+
+// AchievementSearch godoc
+// @Summary      Cari Prestasi (Full-Text)
+// @Description  Pencarian full-text atas judul, deskripsi, tag dan field details utama (nama kompetisi, penerbit, organisasi, dsb.). Hasil diurutkan menurut relevansi, dilengkapi highlight (<mark>) dan jumlah per jenis, tag dan status. Hasil dibatasi scope achievement:read (own, advisees, department, all).
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q              query  string  true   "Kata kunci (mendukung \"frasa\" dan -kata)"
+// @Param        page           query  int     false  "Nomor halaman (default 1)"
+// @Param        limit          query  int     false  "Jumlah per halaman (default 20, maks 100)"
+// @Param        status         query  string  false  "Daftar status dipisah koma"
+// @Param        type           query  string  false  "Daftar jenis prestasi dipisah koma"
+// @Param        tags           query  string  false  "Daftar tag dipisah koma (harus dimiliki semua)"
+// @Param        from           query  string  false  "Dibuat sejak (YYYY-MM-DD)"
+// @Param        to             query  string  false  "Dibuat sampai (YYYY-MM-DD)"
+// @Param        program_study  query  string  false  "Program studi mahasiswa"
+// @Param        academic_year  query  string  false  "Angkatan mahasiswa, mis. 2021/2022"
+// @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Router       /achievements/search [get]
+func AchievementSearch(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if len(searchTerms(query)) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Parameter q wajib diisi"})
+	}
+
+	claims := c.Locals("user").(*model.JWTClaims)
+	q := parseListQuery(c)
+	scope := policy.Can(claims, "read", "achievement")
+
+	filter, status, msg := achievementFilterFromQuery(c, scope == policy.ScopeAll)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	empty, status, msg := scopeAchievementFilter(claims, scope, &filter)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	hits := []model.AchievementSearchHit{}
+	facets := model.SearchFacets{Types: []model.FacetCount{}, Tags: []model.FacetCount{}, Statuses: []model.FacetCount{}}
+	if !empty {
+		var err error
+		if hits, facets, err = RunAchievementSearch(query, filter); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mencari achievement"})
+		}
+	}
+
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	info := model.PageInfo{Total: len(hits), Limit: q.Limit, Page: q.Page, Sort: "score", Order: "desc"}
+
+	from := min((q.Page-1)*q.Limit, len(hits))
+	to := min(from+q.Limit, len(hits))
+	info.HasMore = to < len(hits)
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"count":      to - from,
+		"data":       hits[from:to],
+		"facets":     facets,
+		"pagination": info,
+	})
+}
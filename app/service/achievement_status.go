@@ -0,0 +1,36 @@
+package service
+
+import "prestasi_backend/app/model"
+
+// ==================================================================
+// ACHIEVEMENT STATE MACHINE
+// ==================================================================
+
+// achievementTransitions mendaftar transisi status yang sah:
+// draft → submitted → verified/rejected, dan rejected → draft untuk revisi.
+// Transisi ke deleted diatur terpisah di CanTransitionAchievement.
+var achievementTransitions = map[string][]string{
+	model.StatusDraft:     {model.StatusSubmitted},
+	model.StatusSubmitted: {model.StatusVerified, model.StatusRejected},
+	model.StatusRejected:  {model.StatusDraft},
+}
+
+// CanTransitionAchievement mengecek apakah status boleh berpindah dari "from" ke "to".
+// privileged menandai aktor dengan scope penuh (admin) yang boleh menghapus
+// prestasi pada status apa pun; selain itu hanya draft yang boleh dihapus.
+func CanTransitionAchievement(from, to string, privileged bool) bool {
+	if from == model.StatusDeleted {
+		return false
+	}
+
+	if to == model.StatusDeleted {
+		return privileged || from == model.StatusDraft
+	}
+
+	for _, next := range achievementTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
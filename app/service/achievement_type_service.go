@@ -0,0 +1,206 @@
+package service
+
+import (
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ==================================================================
+// LIST ACHIEVEMENT TYPES
+// ==================================================================
+
+// AchievementTypeList godoc
+// @Summary      Daftar Jenis Prestasi
+// @Description  Menampilkan skema details per jenis prestasi untuk merender form di frontend. Gunakan ?all=true untuk menyertakan jenis nonaktif.
+// @Tags         Achievement Type
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        all  query     bool  false  "Sertakan jenis nonaktif"
+// @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /achievement-types [get]
+func AchievementTypeList(c *fiber.Ctx) error {
+	activeOnly := !c.QueryBool("all")
+
+	types, err := repository.GetAchievementTypes(activeOnly)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil jenis prestasi"})
+	}
+	if types == nil {
+		types = []model.AchievementType{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    types,
+	})
+}
+
+// ==================================================================
+// DETAIL ACHIEVEMENT TYPE
+// ==================================================================
+
+// AchievementTypeDetail godoc
+// @Summary      Detail Jenis Prestasi
+// @Description  Menampilkan skema details untuk satu jenis prestasi.
+// @Tags         Achievement Type
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code  path      string  true  "Kode jenis prestasi"
+// @Success      200   {object} map[string]interface{}
+// @Failure      404   {object} map[string]interface{}
+// @Router       /achievement-types/{code} [get]
+func AchievementTypeDetail(c *fiber.Ctx) error {
+	t, err := repository.GetAchievementTypeByCode(c.Params("code"))
+	if err != nil {
+		if repository.IsNoDocuments(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Jenis prestasi tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil jenis prestasi"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    t,
+	})
+}
+
+// ==================================================================
+// CREATE ACHIEVEMENT TYPE
+// ==================================================================
+
+// AchievementTypeCreate godoc
+// @Summary      Buat Jenis Prestasi
+// @Description  Admin menambahkan jenis prestasi baru beserta definisi field details.
+// @Tags         Achievement Type
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.AchievementTypeRequest true "Skema Jenis Prestasi"
+// @Success      201  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      409  {object} map[string]interface{}
+// @Router       /achievement-types [post]
+func AchievementTypeCreate(c *fiber.Ctx) error {
+	var req model.AchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	if errs := ValidateAchievementTypeDefinition(&req); len(errs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Skema tidak valid", "fields": errs})
+	}
+
+	t := model.AchievementType{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Fields:      req.Fields,
+		AllowExtra:  req.AllowExtra,
+		Active:      req.Active == nil || *req.Active,
+	}
+	if t.Fields == nil {
+		t.Fields = []model.AchievementField{}
+	}
+
+	if err := repository.CreateAchievementType(&t); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Kode jenis prestasi sudah dipakai"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan jenis prestasi"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Jenis prestasi berhasil dibuat",
+		"data":    t,
+	})
+}
+
+// ==================================================================
+// UPDATE ACHIEVEMENT TYPE
+// ==================================================================
+
+// AchievementTypeUpdate godoc
+// @Summary      Update Jenis Prestasi
+// @Description  Admin mengubah skema jenis prestasi. Kode tidak bisa diubah; skema baru berlaku untuk create/update berikutnya.
+// @Tags         Achievement Type
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code    path  string                       true  "Kode jenis prestasi"
+// @Param        request body  model.AchievementTypeRequest true  "Skema Jenis Prestasi"
+// @Success      200     {object} map[string]interface{}
+// @Failure      400     {object} map[string]interface{}
+// @Failure      404     {object} map[string]interface{}
+// @Router       /achievement-types/{code} [put]
+func AchievementTypeUpdate(c *fiber.Ctx) error {
+	code := c.Params("code")
+
+	var req model.AchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+	req.Code = code
+
+	if errs := ValidateAchievementTypeDefinition(&req); len(errs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Skema tidak valid", "fields": errs})
+	}
+
+	t := model.AchievementType{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		Fields:      req.Fields,
+		AllowExtra:  req.AllowExtra,
+		Active:      req.Active == nil || *req.Active,
+	}
+	if t.Fields == nil {
+		t.Fields = []model.AchievementField{}
+	}
+
+	if err := repository.UpdateAchievementType(code, &t); err != nil {
+		if repository.IsNoDocuments(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Jenis prestasi tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengupdate jenis prestasi"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Jenis prestasi berhasil diupdate",
+	})
+}
+
+// ==================================================================
+// DEACTIVATE ACHIEVEMENT TYPE
+// ==================================================================
+
+// AchievementTypeDelete godoc
+// @Summary      Nonaktifkan Jenis Prestasi
+// @Description  Admin menonaktifkan jenis prestasi. Prestasi lama tetap divalidasi dengan skema ini, tetapi jenis ini tidak bisa dipakai untuk prestasi baru.
+// @Tags         Achievement Type
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code  path      string  true  "Kode jenis prestasi"
+// @Success      200   {object} map[string]interface{}
+// @Failure      404   {object} map[string]interface{}
+// @Router       /achievement-types/{code} [delete]
+func AchievementTypeDelete(c *fiber.Ctx) error {
+	if err := repository.DeactivateAchievementType(c.Params("code")); err != nil {
+		if repository.IsNoDocuments(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Jenis prestasi tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menonaktifkan jenis prestasi"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Jenis prestasi berhasil dinonaktifkan",
+	})
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"prestasi_backend/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Level prestasi yang dipakai bersama oleh skema bawaan
+var achievementLevels = []string{"international", "national", "regional", "campus"}
+
+var validFieldTypes = map[string]bool{
+	model.FieldTypeString:  true,
+	model.FieldTypeNumber:  true,
+	model.FieldTypeInteger: true,
+	model.FieldTypeBoolean: true,
+	model.FieldTypeDate:    true,
+	model.FieldTypeArray:   true,
+}
+
+var typeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func floatPtr(v float64) *float64 { return &v }
+
+// DefaultAchievementTypes adalah skema awal yang di-seed saat aplikasi start.
+// Setelah ada di database, admin bebas mengubahnya lewat /achievement-types.
+func DefaultAchievementTypes() []model.AchievementType {
+	level := model.AchievementField{Name: "level", Label: "Tingkat", Type: model.FieldTypeString, Required: true, Enum: achievementLevels}
+
+	return []model.AchievementType{
+		{
+			Code:        "competition",
+			Name:        "Kompetisi",
+			Description: "Lomba atau kejuaraan akademik maupun non-akademik",
+			Active:      true,
+			Fields: []model.AchievementField{
+				{Name: "competitionName", Label: "Nama Kompetisi", Type: model.FieldTypeString, Required: true, MaxLength: 200},
+				level,
+				{Name: "rank", Label: "Peringkat", Type: model.FieldTypeInteger, Required: true, Min: floatPtr(1)},
+				{Name: "teamSize", Label: "Jumlah Anggota Tim", Type: model.FieldTypeInteger, Min: floatPtr(1), Max: floatPtr(50)},
+				{Name: "organizer", Label: "Penyelenggara", Type: model.FieldTypeString, MaxLength: 200},
+				{Name: "eventDate", Label: "Tanggal Kegiatan", Type: model.FieldTypeDate},
+			},
+		},
+		{
+			Code:        "publication",
+			Name:        "Publikasi",
+			Description: "Artikel jurnal, prosiding konferensi, atau buku",
+			Active:      true,
+			Fields: []model.AchievementField{
+				{Name: "publicationTitle", Label: "Judul Publikasi", Type: model.FieldTypeString, Required: true, MaxLength: 300},
+				{Name: "publicationType", Label: "Jenis Publikasi", Type: model.FieldTypeString, Required: true, Enum: []string{"journal", "conference", "book"}},
+				{Name: "publisher", Label: "Penerbit", Type: model.FieldTypeString, Required: true, MaxLength: 200},
+				level,
+				{Name: "authors", Label: "Penulis", Type: model.FieldTypeArray},
+				{Name: "issn", Label: "ISSN/ISBN", Type: model.FieldTypeString, MaxLength: 30},
+				{Name: "publishedDate", Label: "Tanggal Terbit", Type: model.FieldTypeDate},
+			},
+		},
+		{
+			Code:        "organization",
+			Name:        "Organisasi",
+			Description: "Kepengurusan organisasi kemahasiswaan",
+			Active:      true,
+			Fields: []model.AchievementField{
+				{Name: "organizationName", Label: "Nama Organisasi", Type: model.FieldTypeString, Required: true, MaxLength: 200},
+				{Name: "position", Label: "Jabatan", Type: model.FieldTypeString, Required: true, MaxLength: 100},
+				level,
+				{Name: "periodStart", Label: "Mulai Menjabat", Type: model.FieldTypeDate, Required: true},
+				{Name: "periodEnd", Label: "Selesai Menjabat", Type: model.FieldTypeDate},
+			},
+		},
+		{
+			Code:        "certification",
+			Name:        "Sertifikasi",
+			Description: "Sertifikat kompetensi atau keahlian profesional",
+			Active:      true,
+			Fields: []model.AchievementField{
+				{Name: "certificationName", Label: "Nama Sertifikasi", Type: model.FieldTypeString, Required: true, MaxLength: 200},
+				{Name: "issuedBy", Label: "Lembaga Penerbit", Type: model.FieldTypeString, Required: true, MaxLength: 200},
+				{Name: "certificationNumber", Label: "Nomor Sertifikat", Type: model.FieldTypeString, MaxLength: 100},
+				level,
+				{Name: "validUntil", Label: "Berlaku Hingga", Type: model.FieldTypeDate},
+			},
+		},
+		{
+			Code:        "internship",
+			Name:        "Magang",
+			Description: "Program magang atau kerja praktik",
+			Active:      true,
+			Fields: []model.AchievementField{
+				{Name: "companyName", Label: "Nama Perusahaan", Type: model.FieldTypeString, Required: true, MaxLength: 200},
+				{Name: "position", Label: "Posisi", Type: model.FieldTypeString, Required: true, MaxLength: 100},
+				level,
+				{Name: "periodStart", Label: "Mulai Magang", Type: model.FieldTypeDate, Required: true},
+				{Name: "periodEnd", Label: "Selesai Magang", Type: model.FieldTypeDate},
+			},
+		},
+		{
+			Code:        "other",
+			Name:        "Lainnya",
+			Description: "Prestasi lain di luar kategori di atas",
+			Active:      true,
+			AllowExtra:  true,
+			Fields:      []model.AchievementField{level},
+		},
+	}
+}
+
+// ValidateAchievementTypeDefinition memeriksa skema yang dikirim admin sebelum disimpan.
+func ValidateAchievementTypeDefinition(req *model.AchievementTypeRequest) []model.FieldError {
+	errs := []model.FieldError{}
+
+	if !typeCodePattern.MatchString(req.Code) {
+		errs = append(errs, model.FieldError{Field: "code", Message: "wajib diisi, huruf kecil/angka/underscore dan diawali huruf"})
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		errs = append(errs, model.FieldError{Field: "name", Message: "wajib diisi"})
+	}
+
+	seen := map[string]bool{}
+	for i, f := range req.Fields {
+		path := fmt.Sprintf("fields[%d]", i)
+
+		if f.Name == "" {
+			errs = append(errs, model.FieldError{Field: path + ".name", Message: "wajib diisi"})
+		} else if seen[f.Name] {
+			errs = append(errs, model.FieldError{Field: path + ".name", Message: "duplikat: " + f.Name})
+		}
+		seen[f.Name] = true
+
+		if !validFieldTypes[f.Type] {
+			errs = append(errs, model.FieldError{Field: path + ".type", Message: "tipe tidak dikenal: " + f.Type})
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			errs = append(errs, model.FieldError{Field: path + ".min", Message: "min lebih besar dari max"})
+		}
+		if f.Pattern != "" {
+			if _, err := regexp.Compile(f.Pattern); err != nil {
+				errs = append(errs, model.FieldError{Field: path + ".pattern", Message: "regex tidak valid"})
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateAchievementDetails memvalidasi details terhadap skema jenis prestasi.
+// Angka dari JSON (float64) dinormalisasi: field integer disimpan sebagai int64.
+func ValidateAchievementDetails(t *model.AchievementType, details map[string]interface{}) (map[string]interface{}, []model.FieldError) {
+	errs := []model.FieldError{}
+	out := map[string]interface{}{}
+
+	known := map[string]bool{}
+	for _, f := range t.Fields {
+		known[f.Name] = true
+		path := "details." + f.Name
+
+		raw, present := details[f.Name]
+		if !present || raw == nil || raw == "" {
+			if f.Required {
+				errs = append(errs, model.FieldError{Field: path, Message: "wajib diisi"})
+			}
+			continue
+		}
+
+		value, msg := validateFieldValue(f, raw)
+		if msg != "" {
+			errs = append(errs, model.FieldError{Field: path, Message: msg})
+			continue
+		}
+		out[f.Name] = value
+	}
+
+	extra := make([]string, 0)
+	for k := range details {
+		if !known[k] {
+			extra = append(extra, k)
+		}
+	}
+	sort.Strings(extra)
+
+	for _, k := range extra {
+		if !t.AllowExtra {
+			errs = append(errs, model.FieldError{Field: "details." + k, Message: "field tidak dikenal untuk jenis " + t.Code})
+			continue
+		}
+		out[k] = details[k]
+	}
+
+	return out, errs
+}
+
+func validateFieldValue(f model.AchievementField, raw interface{}) (interface{}, string) {
+	switch f.Type {
+	case model.FieldTypeString:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, "harus berupa teks"
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			if f.Required {
+				return nil, "wajib diisi"
+			}
+			return s, ""
+		}
+		if f.MaxLength > 0 && len([]rune(s)) > f.MaxLength {
+			return nil, fmt.Sprintf("maksimal %d karakter", f.MaxLength)
+		}
+		if len(f.Enum) > 0 && !containsString(f.Enum, s) {
+			return nil, "harus salah satu dari: " + strings.Join(f.Enum, ", ")
+		}
+		if f.Pattern != "" {
+			if re, err := regexp.Compile(f.Pattern); err == nil && !re.MatchString(s) {
+				return nil, "format tidak sesuai"
+			}
+		}
+		return s, ""
+
+	case model.FieldTypeNumber, model.FieldTypeInteger:
+		n, ok := toFloat(raw)
+		if !ok {
+			return nil, "harus berupa angka"
+		}
+		if f.Type == model.FieldTypeInteger && n != math.Trunc(n) {
+			return nil, "harus bilangan bulat"
+		}
+		if f.Min != nil && n < *f.Min {
+			return nil, fmt.Sprintf("minimal %v", *f.Min)
+		}
+		if f.Max != nil && n > *f.Max {
+			return nil, fmt.Sprintf("maksimal %v", *f.Max)
+		}
+		if f.Type == model.FieldTypeInteger {
+			return int64(n), ""
+		}
+		return n, ""
+
+	case model.FieldTypeBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, "harus true atau false"
+		}
+		return b, ""
+
+	case model.FieldTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, "harus berupa tanggal (YYYY-MM-DD)"
+		}
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return nil, "format tanggal harus YYYY-MM-DD"
+			}
+		}
+		return s, ""
+
+	case model.FieldTypeArray:
+		items, ok := toStringSlice(raw)
+		if !ok {
+			return nil, "harus berupa daftar teks"
+		}
+		if f.Required && len(items) == 0 {
+			return nil, "wajib diisi"
+		}
+		return items, ""
+	}
+
+	return nil, "tipe field tidak dikenal"
+}
+
+// toFloat menerima angka dari JSON (float64), BSON (int32/int64) maupun literal Go (int)
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toStringSlice(v interface{}) ([]string, bool) {
+	var items []interface{}
+	switch list := v.(type) {
+	case []string:
+		return list, true
+	case []interface{}:
+		items = list
+	case primitive.A:
+		items = list
+	default:
+		return nil, false
+	}
+
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, s)
+	}
+	return out, true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationError membawa daftar kesalahan per field agar bisa dikembalikan ke klien
+type ValidationError struct {
+	Fields []model.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return "validasi gagal: " + strings.Join(parts, "; ")
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+// ==================================================================
+// PENGGANTIAN DOSEN WALI (dipakai StudentSetAdvisor & LecturerReassignAdvisees)
+// ==================================================================
+
+// checkNewAdvisor memastikan dosen wali tujuan ada dan masih aktif
+func checkNewAdvisor(lecturerID string) (*model.Lecturer, int, string) {
+	if strings.TrimSpace(lecturerID) == "" {
+		return nil, 400, "Dosen wali baru wajib diisi"
+	}
+
+	lect, err := repository.GetLecturerByID(lecturerID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return nil, 400, "Dosen wali tidak ditemukan"
+		}
+		return nil, 500, "Gagal mengambil data dosen"
+	}
+	if lect.DeactivatedAt != nil {
+		return nil, 400, "Dosen wali sudah nonaktif"
+	}
+	return lect, 0, ""
+}
+
+// advisorChangeOptions adalah opsi yang sama untuk setiap mahasiswa dalam satu penggantian
+type advisorChangeOptions struct {
+	pendingReviews string
+	effectiveFrom  time.Time
+	reason         *string
+	assignedBy     *string
+}
+
+// parseAdvisorChangeOptions memvalidasi pending_reviews dan effective_date dari request
+func parseAdvisorChangeOptions(pendingReviews, effectiveDate, reason, actorID string) (advisorChangeOptions, string) {
+	opts := advisorChangeOptions{effectiveFrom: time.Now()}
+
+	switch pendingReviews {
+	case "", model.PendingReviewsTransfer, model.PendingReviewsKeep:
+		opts.pendingReviews = pendingReviews
+	default:
+		return opts, "pending_reviews harus transfer atau keep"
+	}
+
+	if effectiveDate != "" {
+		d, err := time.ParseInLocation("2006-01-02", effectiveDate, time.Local)
+		if err != nil {
+			return opts, "Format effective_date harus YYYY-MM-DD"
+		}
+		if d.After(opts.effectiveFrom) {
+			return opts, "effective_date tidak boleh di masa depan"
+		}
+		opts.effectiveFrom = d
+	}
+
+	if reason = strings.TrimSpace(reason); reason != "" {
+		opts.reason = &reason
+	}
+	if actorID != "" {
+		opts.assignedBy = &actorID
+	}
+	return opts, ""
+}
+
+// assignment menyusun penugasan baru untuk satu mahasiswa. Pilihan
+// pending_reviews hanya dicatat bila ada dosen wali lama yang digantikan.
+func (o advisorChangeOptions) assignment(studentID, lecturerID string, hasPrevious bool) model.AdvisorAssignment {
+	a := model.AdvisorAssignment{
+		ID:            uuid.NewString(),
+		StudentID:     studentID,
+		LecturerID:    lecturerID,
+		EffectiveFrom: o.effectiveFrom,
+		AssignedBy:    o.assignedBy,
+		Reason:        o.reason,
+	}
+	if hasPrevious && o.pendingReviews != "" {
+		p := o.pendingReviews
+		a.PendingReviews = &p
+	}
+	return a
+}
+
+// pendingReviewsConflict adalah response 409 bila masih ada pengajuan yang
+// belum ditinjau tetapi pending_reviews belum dipilih
+func pendingReviewsConflict(c *fiber.Ctx, pending int) error {
+	return c.Status(409).JSON(fiber.Map{
+		"error":           fmt.Sprintf("Masih ada %d pengajuan prestasi yang belum ditinjau, pilih pending_reviews: transfer atau keep", pending),
+		"pending_reviews": pending,
+	})
+}
+
+// reassignAdvisors menulis penugasan baru dan memetakan error ke status HTTP
+func reassignAdvisors(assignments []model.AdvisorAssignment, releaseReviewer string) (int, string) {
+	err := repository.ReassignAdvisors(assignments, releaseReviewer)
+	switch {
+	case err == nil:
+		return 0, ""
+	case repository.IsCheckViolation(err):
+		return 400, "effective_date tidak boleh sebelum penugasan dosen wali saat ini"
+	case repository.IsNoRows(err):
+		return 404, "Mahasiswa tidak ditemukan"
+	}
+	return 500, "Gagal mengganti dosen wali"
+}
+
+// isAchievementReviewer mengecek apakah dosen berhak meninjau (verify/reject)
+// bagian mahasiswa studentID pada pengajuan. Bagian pemilik ditinjau dosen yang
+// mempertahankannya (reviewer_id) bila ada; selain itu oleh dosen wali
+// mahasiswa tersebut saat ini.
+func isAchievementReviewer(lecturerID string, ref *model.AchievementReference, studentID string) (bool, error) {
+	if studentID == ref.StudentID && ref.ReviewerID != nil {
+		return *ref.ReviewerID == lecturerID, nil
+	}
+	return repository.IsStudentAdvisedBy(lecturerID, studentID)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/config"
+)
+
+// AttachmentLimits adalah kebijakan upload file bukti prestasi
+type AttachmentLimits struct {
+	MaxFileBytes  int64
+	MaxTotalBytes int64 // total semua attachment dalam satu prestasi
+	MaxCount      int   // jumlah attachment per prestasi
+	// AllowedTypes memetakan MIME hasil sniffing ke ekstensi yang boleh dipakai
+	AllowedTypes map[string][]string
+}
+
+// DefaultAttachmentLimits: sertifikat berupa PDF/JPEG/PNG, 5 MB per file, 20 MB & 5 file per prestasi
+func DefaultAttachmentLimits() AttachmentLimits {
+	return AttachmentLimits{
+		MaxFileBytes:  5 << 20,
+		MaxTotalBytes: 20 << 20,
+		MaxCount:      5,
+		AllowedTypes: map[string][]string{
+			"application/pdf": {".pdf"},
+			"image/jpeg":      {".jpg", ".jpeg"},
+			"image/png":       {".png"},
+		},
+	}
+}
+
+// AttachmentLimitsFromEnv membaca ATTACHMENT_MAX_FILE_MB, ATTACHMENT_MAX_TOTAL_MB
+// dan ATTACHMENT_MAX_COUNT; nilai kosong/tidak valid memakai default.
+func AttachmentLimitsFromEnv() AttachmentLimits {
+	l := DefaultAttachmentLimits()
+	if mb, err := strconv.ParseInt(config.Get("ATTACHMENT_MAX_FILE_MB"), 10, 64); err == nil && mb > 0 {
+		l.MaxFileBytes = mb << 20
+	}
+	if mb, err := strconv.ParseInt(config.Get("ATTACHMENT_MAX_TOTAL_MB"), 10, 64); err == nil && mb > 0 {
+		l.MaxTotalBytes = mb << 20
+	}
+	if n, err := strconv.Atoi(config.Get("ATTACHMENT_MAX_COUNT")); err == nil && n > 0 {
+		l.MaxCount = n
+	}
+	return l
+}
+
+// Limits adalah kebijakan upload yang dipakai handler, di-set di main.go
+var Limits = DefaultAttachmentLimits()
+
+// AttachmentRejection adalah penolakan upload beserta status HTTP-nya (413/415/422)
+type AttachmentRejection struct {
+	Status  int
+	Message string
+}
+
+func (e *AttachmentRejection) Error() string {
+	return e.Message
+}
+
+func formatMB(n int64) string {
+	return strconv.FormatFloat(float64(n)/(1<<20), 'f', -1, 64) + " MB"
+}
+
+// ValidateAttachmentUpload mengecek ukuran, jumlah, dan tipe file. MIME ditentukan
+// dari isi (head = byte awal file), lalu ekstensi nama file harus cocok dengannya.
+// Mengembalikan MIME hasil sniffing bila lolos.
+func ValidateAttachmentUpload(l AttachmentLimits, existing []model.Attachment, fileName string, size int64, head []byte) (string, error) {
+	if size > l.MaxFileBytes {
+		return "", &AttachmentRejection{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: "Ukuran file melebihi batas " + formatMB(l.MaxFileBytes),
+		}
+	}
+
+	if len(existing) >= l.MaxCount {
+		return "", &AttachmentRejection{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("Prestasi sudah memiliki %d attachment (maksimal %d)", len(existing), l.MaxCount),
+		}
+	}
+
+	total := size
+	for _, att := range existing {
+		total += att.Size
+	}
+	if total > l.MaxTotalBytes {
+		return "", &AttachmentRejection{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: "Total ukuran attachment prestasi melebihi batas " + formatMB(l.MaxTotalBytes),
+		}
+	}
+
+	sniffed := http.DetectContentType(head)
+	if i := strings.Index(sniffed, ";"); i >= 0 {
+		sniffed = sniffed[:i]
+	}
+
+	exts, ok := l.AllowedTypes[sniffed]
+	if !ok {
+		return "", &AttachmentRejection{
+			Status:  http.StatusUnsupportedMediaType,
+			Message: "Tipe file " + sniffed + " tidak diizinkan (hanya PDF, JPEG, PNG)",
+		}
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, allowed := range exts {
+		if ext == allowed {
+			return sniffed, nil
+		}
+	}
+	return "", &AttachmentRejection{
+		Status:  http.StatusUnsupportedMediaType,
+		Message: "Ekstensi " + ext + " tidak sesuai dengan isi file (" + sniffed + ")",
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/config"
+)
+
+// Ukuran sisi terpanjang thumbnail/preview
+const thumbnailMaxSide = 320
+
+// Gambar di atas batas piksel ini tidak dibuatkan thumbnail (menghindari decode raksasa)
+const thumbnailMaxPixels = 40_000_000
+
+// ErrPreviewUnsupported berarti tipe file tidak punya preview atau alatnya tidak tersedia
+var ErrPreviewUnsupported = errors.New("preview tidak tersedia untuk file ini")
+
+// PDFRenderer merender halaman pertama PDF menjadi PNG. Nil berarti
+// preview PDF dimatikan (pdftoppm tidak ditemukan).
+var PDFRenderer func(ctx context.Context, pdf io.Reader) ([]byte, error)
+
+// PDFRendererFromEnv memakai pdftoppm (poppler) dari PATH atau PDFTOPPM_PATH
+func PDFRendererFromEnv() func(ctx context.Context, pdf io.Reader) ([]byte, error) {
+	bin := config.Get("PDFTOPPM_PATH")
+	if bin == "" {
+		bin = "pdftoppm"
+	}
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil
+	}
+	return func(ctx context.Context, pdf io.Reader) ([]byte, error) {
+		return renderPDFFirstPage(ctx, path, pdf)
+	}
+}
+
+func renderPDFFirstPage(ctx context.Context, bin string, pdf io.Reader) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "pdf-preview-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in.pdf")
+	f, err := os.Create(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, pdf); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	out := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, bin,
+		"-png", "-f", "1", "-l", "1", "-singlefile",
+		"-scale-to", "1024", in, out)
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(out + ".png")
+}
+
+// GeneratePreview membuat thumbnail PNG untuk gambar (pure Go) atau preview
+// halaman pertama untuk PDF (via PDFRenderer). Hasilnya selalu diperkecil
+// sehingga sisi terpanjang maksimal 320px.
+func GeneratePreview(mimeType string, r io.Reader) ([]byte, *model.AttachmentPreview, error) {
+	switch mimeType {
+	case "image/jpeg", "image/png":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return thumbnailPNG(data)
+
+	case "application/pdf":
+		if PDFRenderer == nil {
+			return nil, nil, ErrPreviewUnsupported
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		page, err := PDFRenderer(ctx, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return thumbnailPNG(page)
+	}
+	return nil, nil, ErrPreviewUnsupported
+}
+
+func thumbnailPNG(data []byte) ([]byte, *model.AttachmentPreview, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.Width*cfg.Height > thumbnailMaxPixels {
+		return nil, nil, ErrPreviewUnsupported
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dst := downscale(src, thumbnailMaxSide)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, nil, err
+	}
+
+	b := dst.Bounds()
+	return buf.Bytes(), &model.AttachmentPreview{
+		Width:    b.Dx(),
+		Height:   b.Dy(),
+		MimeType: "image/png",
+		Size:     int64(buf.Len()),
+	}, nil
+}
+
+// downscale memperkecil gambar dengan rata-rata maksimal 4×4 sampel per piksel
+// tujuan; cukup halus untuk thumbnail tanpa dependensi tambahan.
+func downscale(src image.Image, maxSide int) image.Image {
+	sb := src.Bounds()
+	sw, sh := sb.Dx(), sb.Dy()
+
+	dw, dh := sw, sh
+	if sw > maxSide || sh > maxSide {
+		if sw >= sh {
+			dw, dh = maxSide, max(1, sh*maxSide/sw)
+		} else {
+			dw, dh = max(1, sw*maxSide/sh), maxSide
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			stepX, stepY := max(1, (x1-x0)/4), max(1, (y1-y0)/4)
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					cr, cg, cb, ca := src.At(sb.Min.X+sx, sb.Min.Y+sy).RGBA()
+					r, g, b, a, n = r+cr, g+cg, b+cb, a+ca, n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
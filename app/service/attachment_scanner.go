@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+
+	"prestasi_backend/config"
+)
+
+// ScanVerdict adalah hasil pemindaian satu file
+type ScanVerdict struct {
+	Clean  bool
+	Reason string
+}
+
+// AttachmentScanner memindai isi file sebelum disimpan. File yang tidak
+// Clean dipindahkan ke karantina, bukan ke attachment prestasi.
+type AttachmentScanner interface {
+	Scan(ctx context.Context, fileName string, r io.Reader) (ScanVerdict, error)
+}
+
+// Scanner adalah pemindai yang dipakai handler upload, di-set di main.go
+var Scanner AttachmentScanner = NoopScanner{}
+
+// NoopScanner menganggap semua file bersih (default bila tidak ada pemindai)
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, fileName string, r io.Reader) (ScanVerdict, error) {
+	return ScanVerdict{Clean: true}, nil
+}
+
+// CommandScanner menjalankan program eksternal dengan isi file di stdin,
+// mis. "clamdscan --no-summary -". Exit code 0 = bersih, 1 = mencurigakan,
+// lainnya dianggap error pemindai.
+type CommandScanner struct {
+	Command string
+	Args    []string
+}
+
+func (s CommandScanner) Scan(ctx context.Context, fileName string, r io.Reader) (ScanVerdict, error) {
+	cmd := exec.CommandContext(ctx, s.Command, s.Args...)
+	cmd.Stdin = r
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	if err == nil {
+		return ScanVerdict{Clean: true}, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return ScanVerdict{Clean: false, Reason: strings.TrimSpace(out.String())}, nil
+	}
+	return ScanVerdict{}, err
+}
+
+// ScannerFromEnv memakai ATTACHMENT_SCAN_COMMAND (dipisah spasi) bila di-set
+func ScannerFromEnv() AttachmentScanner {
+	fields := strings.Fields(config.Get("ATTACHMENT_SCAN_COMMAND"))
+	if len(fields) == 0 {
+		return NoopScanner{}
+	}
+	return CommandScanner{Command: fields[0], Args: fields[1:]}
+}
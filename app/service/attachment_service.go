@@ -0,0 +1,606 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/storage"
+	"prestasi_backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// attachmentPrefix adalah prefix key storage untuk attachment satu prestasi
+func attachmentPrefix(mongoID string) string {
+	return "achievements/" + mongoID
+}
+
+// quarantinePrefix adalah prefix key storage untuk file yang ditolak pemindai
+func quarantinePrefix(mongoID string) string {
+	return "quarantine/" + mongoID
+}
+
+// StoreAttachment menyimpan isi file ke storage dengan key prefix/<uuid> sambil
+// menghitung sha256 dan mendeteksi MIME type dari isi (bukan dari ekstensi).
+// Nama file klien tidak pernah dipakai sebagai path.
+func StoreAttachment(backend storage.Backend, prefix, fileName string, size int64, r io.Reader, uploadedBy string) (*model.Attachment, error) {
+	br := bufio.NewReaderSize(r, 512)
+	head, _ := br.Peek(512)
+
+	att := &model.Attachment{
+		ID:         uuid.NewString(),
+		FileName:   filepath.Base(fileName),
+		Size:       size,
+		MimeType:   http.DetectContentType(head),
+		UploadedBy: uploadedBy,
+		UploadedAt: time.Now(),
+	}
+	att.Key = prefix + "/" + att.ID
+
+	hash := sha256.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if err := backend.Put(ctx, att.Key, io.TeeReader(br, hash), size, att.MimeType); err != nil {
+		return nil, err
+	}
+	att.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	return att, nil
+}
+
+// ProcessAttachmentUpload menjalankan pipeline upload: validasi kebijakan
+// (Limits), pemindaian (Scanner), lalu simpan ke storage dan MongoDB.
+// File yang tidak lolos pemindai disimpan di karantina dan ditolak dengan 422.
+func ProcessAttachmentUpload(backend storage.Backend, ref *model.AchievementReference, doc *model.AchievementMongo, fileName string, size int64, src io.ReadSeeker, uploadedBy string) (*model.Attachment, error) {
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(src, head)
+
+	if _, err := ValidateAttachmentUpload(Limits, doc.Attachments, fileName, size, head[:n]); err != nil {
+		return nil, err
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	verdict, err := Scanner.Scan(ctx, fileName, src)
+	if err != nil {
+		return nil, fmt.Errorf("pemindai gagal: %w", err)
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	if !verdict.Clean {
+		quarantined, err := StoreAttachment(backend, quarantinePrefix(ref.MongoAchievementID), fileName, size, src, uploadedBy)
+		if err != nil {
+			log.Println("⚠️ gagal menyimpan file karantina:", err)
+		} else if err := repository.InsertQuarantinedAttachment(&model.QuarantinedAttachment{
+			Attachment:         *quarantined,
+			ReferenceID:        ref.ID,
+			MongoAchievementID: ref.MongoAchievementID,
+			Reason:             verdict.Reason,
+		}); err != nil {
+			log.Println("⚠️ gagal mencatat file karantina:", quarantined.Key, err)
+		}
+
+		return nil, &AttachmentRejection{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "File ditolak oleh pemindai keamanan dan dikarantina",
+		}
+	}
+
+	att, err := StoreAttachment(backend, attachmentPrefix(ref.MongoAchievementID), fileName, size, src, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Preview bersifat opsional: kegagalan tidak membatalkan upload
+	if _, err := src.Seek(0, io.SeekStart); err == nil {
+		att.Preview = storePreview(backend, att, src)
+	}
+
+	// Tambah metadata ke dokumen Mongo; file dihapus lagi bila gagal
+	if err := repository.AddAchievementAttachment(ref.MongoAchievementID, *att); err != nil {
+		for _, key := range attachmentKeys(att) {
+			if derr := backend.Delete(context.Background(), key); derr != nil {
+				log.Println("⚠️ gagal menghapus file yatim:", key, derr)
+			}
+		}
+		return nil, err
+	}
+
+	return att, nil
+}
+
+// ==================================================================
+// UPLOAD ATTACHMENT
+// ==================================================================
+
+// AchievementUploadAttachment godoc
+// @Summary      Upload Bukti (File)
+// @Description  Upload file sertifikat/bukti prestasi (PDF/JPEG/PNG). Tipe file dideteksi dari isi dan ekstensi harus cocok. Batas ukuran per file, total, dan jumlah file per prestasi dapat dikonfigurasi. File disimpan dengan key UUID beserta metadata; file yang ditolak pemindai dikarantina.
+// @Tags         Achievement
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      string  true  "Achievement ID"
+// @Param        file  formData  file    true  "File Attachment"
+// @Success      200   {object} map[string]interface{}
+// @Failure      400   {object} map[string]interface{}
+// @Failure      413   {object} map[string]interface{}
+// @Failure      415   {object} map[string]interface{}
+// @Failure      422   {object} map[string]interface{}
+// @Router       /achievements/{id}/attachments [post]
+func AchievementUploadAttachment(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+	// hak upload sudah dicek AchievementAccess("update")
+	ref := c.Locals("achievement").(*model.AchievementReference)
+
+	if storage.Default == nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Storage belum dikonfigurasi"})
+	}
+
+	// Ambil file upload
+	f, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "File tidak ditemukan"})
+	}
+
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+	}
+
+	src, err := f.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "File tidak dapat dibaca"})
+	}
+	defer src.Close()
+
+	att, err := ProcessAttachmentUpload(storage.Default, ref, doc, f.Filename, f.Size, src, claims.UserID)
+	if err != nil {
+		var rejected *AttachmentRejection
+		if errors.As(err, &rejected) {
+			return c.Status(rejected.Status).JSON(fiber.Map{"error": rejected.Message})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan attachment"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Attachment berhasil ditambahkan",
+		"data":    att,
+	})
+}
+
+// storePreview membuat dan menyimpan preview di samping file asli (<key>.preview.png)
+func storePreview(backend storage.Backend, att *model.Attachment, r io.Reader) *model.AttachmentPreview {
+	data, preview, err := GeneratePreview(att.MimeType, r)
+	if err != nil {
+		if !errors.Is(err, ErrPreviewUnsupported) {
+			log.Println("⚠️ gagal membuat preview attachment:", att.Key, err)
+		}
+		return nil
+	}
+
+	preview.Key = att.Key + ".preview.png"
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if err := backend.Put(ctx, preview.Key, bytes.NewReader(data), int64(len(data)), preview.MimeType); err != nil {
+		log.Println("⚠️ gagal menyimpan preview attachment:", preview.Key, err)
+		return nil
+	}
+	return preview
+}
+
+// attachmentKeys adalah semua key storage milik satu attachment (file asli + preview)
+func attachmentKeys(att *model.Attachment) []string {
+	keys := []string{att.Key}
+	if att.Preview != nil {
+		keys = append(keys, att.Preview.Key)
+	}
+	return keys
+}
+
+// withAttachmentURLs mengisi URL unduhan bertanda tangan untuk attachment dan preview-nya
+func withAttachmentURLs(refID string, atts []model.Attachment) []model.Attachment {
+	out := make([]model.Attachment, 0, len(atts))
+	for _, att := range atts {
+		att.URL, _ = signedAttachmentURL(refID, att.ID, false)
+		if att.Preview != nil {
+			preview := *att.Preview
+			preview.URL, _ = signedAttachmentURL(refID, att.ID, true)
+			att.Preview = &preview
+		}
+		out = append(out, att)
+	}
+	return out
+}
+
+// previewFile membungkus preview sebagai Attachment agar bisa dikirim serveAttachment
+func previewFile(att *model.Attachment) *model.Attachment {
+	return &model.Attachment{
+		Key:      att.Preview.Key,
+		FileName: strings.TrimSuffix(att.FileName, filepath.Ext(att.FileName)) + ".png",
+		Size:     att.Preview.Size,
+		MimeType: att.Preview.MimeType,
+	}
+}
+
+// attachmentPath adalah path yang ditandatangani untuk URL unduhan
+func attachmentPath(refID, attachmentID string) string {
+	return "/achievements/" + refID + "/attachments/" + attachmentID
+}
+
+// signedAttachmentURL membuat URL unduhan tanpa header Authorization
+// (untuk <img>/<iframe>) yang berlaku selama utils.SignedURLTTL
+func signedAttachmentURL(refID, attachmentID string, preview bool) (string, time.Time) {
+	path := attachmentPath(refID, attachmentID)
+	if preview {
+		path += "/preview"
+	}
+	expires := time.Now().Add(utils.SignedURLTTL)
+	return "/api/v1/files" + utils.SignURL(path, expires), expires
+}
+
+// loadAttachmentDoc mengambil reference + dokumen. Route ber-JWT memakai
+// reference yang sudah diotorisasi AchievementAccess; tanpa itu berarti
+// request lewat URL bertanda tangan yang sudah diverifikasi.
+func loadAttachmentDoc(c *fiber.Ctx) (*model.AchievementReference, *model.AchievementMongo, int, string) {
+	ref, ok := c.Locals("achievement").(*model.AchievementReference)
+	if !ok {
+		var err error
+		ref, err = repository.GetAchievementReferenceByID(c.Params("id"))
+		if err != nil {
+			if repository.IsNoRows(err) {
+				return nil, nil, 404, "Data achievement tidak ditemukan"
+			}
+			return nil, nil, 500, "Gagal mengambil data achievement"
+		}
+	}
+
+	doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil {
+		return nil, nil, 500, "Gagal mengambil data achievement (MongoDB)"
+	}
+	return ref, doc, 0, ""
+}
+
+func findAttachment(doc *model.AchievementMongo, attachmentID string) *model.Attachment {
+	for i := range doc.Attachments {
+		if doc.Attachments[i].ID == attachmentID {
+			return &doc.Attachments[i]
+		}
+	}
+	return nil
+}
+
+// serveAttachment mengirim isi file, mendukung header Range untuk file yang ukurannya diketahui
+func serveAttachment(c *fiber.Ctx, att *model.Attachment) error {
+	if storage.Default == nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Storage belum dikonfigurasi"})
+	}
+
+	contentType := att.MimeType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(att.FileName))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := "inline"
+	if c.QueryBool("download") {
+		disposition = "attachment"
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, disposition+"; filename*=UTF-8''"+url.PathEscape(att.FileName))
+	c.Set("X-Content-Type-Options", "nosniff")
+	if att.SHA256 != "" {
+		c.Set(fiber.HeaderETag, `"`+att.SHA256+`"`)
+	}
+
+	offset, length := int64(0), int64(-1)
+	status := 200
+
+	// data lama tidak menyimpan ukuran → kirim utuh tanpa dukungan Range
+	if att.Size > 0 {
+		c.Set(fiber.HeaderAcceptRanges, "bytes")
+		length = att.Size
+
+		start, n, partial, err := storage.ParseRange(c.Get(fiber.HeaderRange), att.Size)
+		if err != nil {
+			c.Set(fiber.HeaderContentRange, "bytes */"+strconv.FormatInt(att.Size, 10))
+			return c.Status(fiber.StatusRequestedRangeNotSatisfiable).JSON(fiber.Map{"error": "Range tidak valid"})
+		}
+		if partial {
+			offset, length, status = start, n, fiber.StatusPartialContent
+			c.Set(fiber.HeaderContentRange, "bytes "+strconv.FormatInt(start, 10)+"-"+
+				strconv.FormatInt(start+n-1, 10)+"/"+strconv.FormatInt(att.Size, 10))
+		}
+	}
+
+	rc, err := storage.Default.Get(context.Background(), att.Key, offset, length)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "File attachment tidak ditemukan di storage"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membaca file attachment"})
+	}
+
+	c.Status(status)
+	if length < 0 {
+		return c.SendStream(rc)
+	}
+	return c.SendStream(rc, int(length))
+}
+
+// ==================================================================
+// LIST ATTACHMENTS
+// ==================================================================
+
+// AchievementAttachmentList godoc
+// @Summary      Daftar Attachment Prestasi
+// @Description  Menampilkan metadata semua file bukti prestasi beserta URL unduhan bertanda tangan yang berlaku singkat. Hak akses sama dengan detail prestasi.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Achievement ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/attachments [get]
+func AchievementAttachmentList(c *fiber.Ctx) error {
+	ref, doc, status, msg := loadAttachmentDoc(c)
+	if ref == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	atts := withAttachmentURLs(ref.ID, doc.Attachments)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"count":   len(atts),
+		"data":    atts,
+	})
+}
+
+// ==================================================================
+// DOWNLOAD ATTACHMENT
+// ==================================================================
+
+// AchievementAttachmentDownload godoc
+// @Summary      Unduh Attachment
+// @Description  Mengunduh file bukti prestasi. Mendukung header Range (206 Partial Content). Tambahkan ?download=1 agar dikirim sebagai attachment.
+// @Tags         Achievement
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id            path   string  true   "Achievement ID"
+// @Param        attachmentId  path   string  true   "Attachment ID"
+// @Param        download      query  bool    false  "Paksa unduh (Content-Disposition: attachment)"
+// @Success      200  {file} file
+// @Success      206  {file} file
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      416  {object} map[string]interface{}
+// @Router       /achievements/{id}/attachments/{attachmentId} [get]
+func AchievementAttachmentDownload(c *fiber.Ctx) error {
+	ref, doc, status, msg := loadAttachmentDoc(c)
+	if ref == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	att := findAttachment(doc, c.Params("attachmentId"))
+	if att == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment tidak ditemukan"})
+	}
+
+	return serveAttachment(c, att)
+}
+
+// AchievementAttachmentPreview godoc
+// @Summary      Preview Attachment
+// @Description  Mengambil thumbnail (gambar) atau preview halaman pertama (PDF) berformat PNG, agar dosen tidak perlu mengunduh file asli saat verifikasi.
+// @Tags         Achievement
+// @Produce      png
+// @Security     BearerAuth
+// @Param        id            path  string  true  "Achievement ID"
+// @Param        attachmentId  path  string  true  "Attachment ID"
+// @Success      200  {file} file
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/attachments/{attachmentId}/preview [get]
+func AchievementAttachmentPreview(c *fiber.Ctx) error {
+	ref, doc, status, msg := loadAttachmentDoc(c)
+	if ref == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	att := findAttachment(doc, c.Params("attachmentId"))
+	if att == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment tidak ditemukan"})
+	}
+	if att.Preview == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Preview attachment tidak tersedia"})
+	}
+
+	return serveAttachment(c, previewFile(att))
+}
+
+// AchievementAttachmentURL godoc
+// @Summary      URL Unduhan Bertanda Tangan
+// @Description  Membuat URL unduhan singkat yang bisa dipakai tanpa header Authorization, mis. untuk menampilkan PDF/gambar di frontend.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string  true  "Achievement ID"
+// @Param        attachmentId  path  string  true  "Attachment ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/attachments/{attachmentId}/url [get]
+func AchievementAttachmentURL(c *fiber.Ctx) error {
+	ref, doc, status, msg := loadAttachmentDoc(c)
+	if ref == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	att := findAttachment(doc, c.Params("attachmentId"))
+	if att == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment tidak ditemukan"})
+	}
+
+	signed, expires := signedAttachmentURL(ref.ID, att.ID, false)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"url":        signed,
+			"expires_at": expires,
+		},
+	})
+}
+
+// AttachmentSignedDownload godoc
+// @Summary      Unduh Attachment via URL Bertanda Tangan
+// @Description  Mengunduh file memakai URL dari /achievements/{id}/attachments/{attachmentId}/url. Tidak memerlukan token; ditolak bila tanda tangan salah atau kedaluwarsa.
+// @Tags         Achievement
+// @Produce      octet-stream
+// @Param        id            path   string  true  "Achievement ID"
+// @Param        attachmentId  path   string  true  "Attachment ID"
+// @Param        expires       query  int     true  "Unix time kedaluwarsa"
+// @Param        signature     query  string  true  "Tanda tangan HMAC"
+// @Success      200  {file} file
+// @Success      206  {file} file
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /files/achievements/{id}/attachments/{attachmentId} [get]
+func AttachmentSignedDownload(c *fiber.Ctx) error {
+	return signedDownload(c, false)
+}
+
+// AttachmentSignedPreview godoc
+// @Summary      Preview Attachment via URL Bertanda Tangan
+// @Description  Mengambil thumbnail gambar / preview halaman pertama PDF memakai URL preview dari daftar attachment atau detail prestasi.
+// @Tags         Achievement
+// @Produce      png
+// @Param        id            path   string  true  "Achievement ID"
+// @Param        attachmentId  path   string  true  "Attachment ID"
+// @Param        expires       query  int     true  "Unix time kedaluwarsa"
+// @Param        signature     query  string  true  "Tanda tangan HMAC"
+// @Success      200  {file} file
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /files/achievements/{id}/attachments/{attachmentId}/preview [get]
+func AttachmentSignedPreview(c *fiber.Ctx) error {
+	return signedDownload(c, true)
+}
+
+func signedDownload(c *fiber.Ctx, preview bool) error {
+	path := attachmentPath(c.Params("id"), c.Params("attachmentId"))
+	if preview {
+		path += "/preview"
+	}
+	if !utils.VerifySignedURL(path, c.Query("expires"), c.Query("signature"), time.Now()) {
+		return c.Status(403).JSON(fiber.Map{"error": "URL tidak valid atau sudah kedaluwarsa"})
+	}
+
+	ref, doc, status, msg := loadAttachmentDoc(c)
+	if ref == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+	if ref.Status == model.StatusDeleted {
+		return c.Status(404).JSON(fiber.Map{"error": "Data achievement tidak ditemukan"})
+	}
+
+	att := findAttachment(doc, c.Params("attachmentId"))
+	if att == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment tidak ditemukan"})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(int(utils.SignedURLTTL.Seconds())))
+	if preview {
+		if att.Preview == nil {
+			return c.Status(404).JSON(fiber.Map{"error": "Preview attachment tidak tersedia"})
+		}
+		return serveAttachment(c, previewFile(att))
+	}
+	return serveAttachment(c, att)
+}
+
+// ==================================================================
+// DELETE ATTACHMENT
+// ==================================================================
+
+// AchievementAttachmentDelete godoc
+// @Summary      Hapus Attachment
+// @Description  Menghapus file bukti dari prestasi. Hanya bisa dilakukan selama prestasi masih berstatus 'draft'.
+// @Tags         Achievement
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string  true  "Achievement ID"
+// @Param        attachmentId  path  string  true  "Attachment ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      403  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Router       /achievements/{id}/attachments/{attachmentId} [delete]
+func AchievementAttachmentDelete(c *fiber.Ctx) error {
+	ref, doc, status, msg := loadAttachmentDoc(c)
+	if ref == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	if ref.Status != model.StatusDraft {
+		return c.Status(400).JSON(fiber.Map{"error": "Attachment hanya dapat dihapus saat prestasi masih draft"})
+	}
+
+	att := findAttachment(doc, c.Params("attachmentId"))
+	if att == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Attachment tidak ditemukan"})
+	}
+
+	if err := repository.RemoveAchievementAttachment(ref.MongoAchievementID, *att); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus attachment"})
+	}
+
+	// metadata sudah terhapus; file yang gagal dihapus hanya dicatat
+	if storage.Default != nil {
+		for _, key := range attachmentKeys(att) {
+			if err := storage.Default.Delete(context.Background(), key); err != nil {
+				log.Println("⚠️ gagal menghapus file attachment:", key, err)
+			}
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Attachment berhasil dihapus",
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -18,7 +19,7 @@ import (
 
 // AuthLogin godoc
 // @Summary      Login Pengguna
-// @Description  Otentikasi user menggunakan username dan password untuk mendapatkan JWT Token.
+// @Description  Otentikasi user menggunakan username dan password untuk mendapatkan access token (JWT, 15 menit) dan refresh token.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
@@ -56,25 +57,21 @@ func AuthLogin(c *fiber.Ctx) error {
 	// ambil permissions
 	perms, _ := repository.GetPermissionsByRoleID(user.RoleID)
 
-	// siapkan claim
-	claim := model.JWTClaims{
-		UserID:      user.ID,
-		RoleName:    role.Name,
-		Permissions: perms,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	token, err := utils.GenerateToken(claim)
+	// terbitkan access token + refresh token (family baru)
+	token, refresh, refreshPlain, err := issueTokens(user, role.Name, perms, uuid.NewString())
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat token"})
 	}
 
+	if err := repository.CreateRefreshToken(refresh); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan sesi login"})
+	}
+
 	return c.JSON(fiber.Map{
-		"success": true,
-		"token":   token,
+		"success":       true,
+		"token":         token,
+		"refresh_token": refreshPlain,
+		"expires_in":    int(utils.AccessTokenTTL.Seconds()),
 		"user": fiber.Map{
 			"id":        user.ID,
 			"username":  user.Username,
@@ -114,26 +111,51 @@ func AuthProfile(c *fiber.Ctx) error {
 
 // AuthRefresh godoc
 // @Summary      Refresh Token
-// @Description  Memperbarui token JWT untuk memperpanjang sesi login.
+// @Description  Menukar refresh token dengan access token baru. Refresh token dirotasi setiap dipakai; pemakaian ulang token lama mencabut seluruh sesi.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
-// @Security     BearerAuth
+// @Param        request body model.RefreshTokenRequest true "Refresh Token"
 // @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      401  {object} map[string]interface{}
 // @Failure      403  {object} map[string]interface{}
 // @Failure      500  {object} map[string]interface{}
 // @Router       /auth/refresh [post]
 func AuthRefresh(c *fiber.Ctx) error {
-	claims := c.Locals("user").(*model.JWTClaims)
+	var req model.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "refresh_token wajib diisi"})
+	}
+
+	current, err := repository.GetRefreshTokenByHash(utils.HashToken(req.RefreshToken))
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(401).JSON(fiber.Map{"error": "Refresh token tidak valid"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memeriksa refresh token"})
+	}
+
+	// token yang sudah dirotasi/dicabut dipakai lagi → anggap dicuri, cabut satu family
+	if current.RevokedAt != nil {
+		_ = repository.RevokeRefreshTokenFamily(current.FamilyID)
+		return c.Status(401).JSON(fiber.Map{"error": "Refresh token sudah tidak berlaku, silakan login ulang"})
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return c.Status(401).JSON(fiber.Map{"error": "Refresh token kedaluwarsa, silakan login ulang"})
+	}
 
 	// Ambil ulang data user
-	user, err := repository.GetUserByID(claims.UserID)
+	user, err := repository.GetUserByID(current.UserID)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "User tidak ditemukan"})
+		_ = repository.RevokeRefreshTokenFamily(current.FamilyID)
+		return c.Status(401).JSON(fiber.Map{"error": "User tidak ditemukan"})
 	}
 
 	// Cek apakah masih aktif
 	if !user.IsActive {
+		_ = repository.RevokeRefreshTokenFamily(current.FamilyID)
 		return c.Status(403).JSON(fiber.Map{
 			"error": "Akun tidak aktif, hubungi admin",
 		})
@@ -151,43 +173,68 @@ func AuthRefresh(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil permissions"})
 	}
 
-	// Buat claim baru
-	newClaim := model.JWTClaims{
-		UserID:      user.ID,
-		RoleName:    role.Name,
-		Permissions: perms,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
-
-	// Generate token baru
-	token, err := utils.GenerateToken(newClaim)
+	token, next, nextPlain, err := issueTokens(user, role.Name, perms, current.FamilyID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat token baru"})
 	}
 
+	// rotasi; kalau kalah balapan dengan request lain, token ini dianggap reuse
+	if err := repository.RotateRefreshToken(current.ID, next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			_ = repository.RevokeRefreshTokenFamily(current.FamilyID)
+			return c.Status(401).JSON(fiber.Map{"error": "Refresh token sudah tidak berlaku, silakan login ulang"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal merotasi refresh token"})
+	}
+
 	return c.JSON(fiber.Map{
-		"success":     true,
-		"token":       token,
-		"role":        role.Name,
-		"permissions": perms,
+		"success":       true,
+		"token":         token,
+		"refresh_token": nextPlain,
+		"expires_in":    int(utils.AccessTokenTTL.Seconds()),
+		"role":          role.Name,
+		"permissions":   perms,
 	})
 }
 
 // AuthLogout godoc
 // @Summary      Logout
-// @Description  Keluar dari aplikasi (Client-side clear token).
+// @Description  Mencabut access token yang sedang dipakai beserta seluruh refresh token pada sesi login tersebut.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        request body model.RefreshTokenRequest false "Refresh Token (opsional)"
 // @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
 // @Router       /auth/logout [post]
 func AuthLogout(c *fiber.Ctx) error {
-	// Tidak perlu hapus token (karena JWT stateless)
-	// Cukup respon berhasil
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	// cabut access token saat ini (jti)
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := repository.RevokeAccessToken(claims.ID, claims.UserID, claims.ExpiresAt.Time); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mencabut token"})
+		}
+	}
+
+	// cabut family refresh token dari sesi token ini
+	if claims.SessionID != "" {
+		if err := repository.RevokeRefreshTokenFamily(claims.SessionID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal mencabut refresh token"})
+		}
+	}
+
+	// refresh token yang dikirim eksplisit (misal token lama tanpa sid)
+	var req model.RefreshTokenRequest
+	if err := c.BodyParser(&req); err == nil && req.RefreshToken != "" {
+		rt, err := repository.GetRefreshTokenByHash(utils.HashToken(req.RefreshToken))
+		if err == nil && rt.UserID == claims.UserID {
+			if err := repository.RevokeRefreshTokenFamily(rt.FamilyID); err != nil {
+				return c.Status(500).JSON(fiber.Map{"error": "Gagal mencabut refresh token"})
+			}
+		}
+	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -195,6 +242,45 @@ func AuthLogout(c *fiber.Ctx) error {
 	})
 }
 
+// issueTokens menyiapkan access token dan refresh token baru dalam family yang sama.
+// Refresh token belum disimpan; pemanggil memilih Create (login) atau Rotate (refresh).
+func issueTokens(user *model.User, roleName string, perms []string, familyID string) (string, *model.RefreshToken, string, error) {
+	now := time.Now()
+
+	claim := model.JWTClaims{
+		UserID:       user.ID,
+		RoleName:     roleName,
+		Permissions:  perms,
+		SessionID:    familyID,
+		TokenVersion: user.TokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(utils.AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	access, err := utils.GenerateToken(claim)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	plain, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	refresh := &model.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: hash,
+		FamilyID:  familyID,
+		ExpiresAt: now.Add(utils.RefreshTokenTTL),
+	}
+
+	return access, refresh, plain, nil
+}
+
 // Login service: Cek user & password (tanpa logika JWT yang ribet dulu biar test jalan)
 func Login(username, password string) (string, error) {
 	// 1. Panggil Repo (yang sekarang sudah jadi var)
@@ -211,4 +297,4 @@ func Login(username, password string) (string, error) {
 
 	// 3. Return token dummy (biar simple dulu)
 	return "valid-jwt-token", nil
-}
\ No newline at end of file
+}
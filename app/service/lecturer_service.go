@@ -1,8 +1,15 @@
 package service
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
 	"prestasi_backend/app/repository"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // ==================================================================
@@ -11,42 +18,69 @@ import (
 
 // LecturerList godoc
 // @Summary      Lihat Daftar Dosen
-// @Description  Menampilkan data dosen. Admin bisa melihat semua dosen, Mahasiswa hanya melihat dosen walinya sendiri.
+// @Description  Menampilkan data dosen sesuai scope permission lecturer:read, dengan paginasi, filter, dan sorting. Scope own hanya melihat dosen wali sendiri (atau diri sendiri bagi dosen).
 // @Tags         Lecturer
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        page        query  int     false  "Nomor halaman (default 1)"
+// @Param        limit       query  int     false  "Jumlah per halaman (default 20, maks 100)"
+// @Param        cursor      query  string  false  "next_cursor dari response sebelumnya"
+// @Param        sort        query  string  false  "lecturer_id | department | created_at"
+// @Param        order       query  string  false  "asc | desc"
+// @Param        department  query  string  false  "Departemen"
+// @Param        is_active   query  bool    false  "Filter profil aktif / nonaktif"
+// @Param        search      query  string  false  "Cari NIDN"
 // @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
 // @Failure      403  {object} map[string]interface{}
 // @Failure      404  {object} map[string]interface{}
 // @Failure      500  {object} map[string]interface{}
 // @Router       /lecturers [get]
 func LecturerList(c *fiber.Ctx) error {
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
+	q := parseListQuery(c)
+
+	filter := model.LecturerFilter{
+		Department: c.Query("department"),
+		Search:     c.Query("search"),
+	}
+	if raw := c.Query("is_active"); raw != "" {
+		active := c.QueryBool("is_active")
+		filter.IsActive = &active
+	}
+
+	switch policy.Can(claims, "read", "lecturer") {
 
-	switch role {
+	case policy.ScopeAll:
+		// ✅ scope all → lihat semua dosen
 
-	case "Admin":
-		// ✅ Admin → lihat semua dosen
-		lects, err := repository.GetAllLecturers()
+	case policy.ScopeDepartment:
+		// ✅ scope department → dosen satu departemen
+		self, err := repository.GetLecturerByUserID(claims.UserID)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Gagal mengambil dosen",
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Data dosen tidak ditemukan",
+			})
+		}
+		filter.Department = self.Department
+
+	case policy.ScopeOwn, policy.ScopeAdvisees:
+		// ✅ mahasiswa → dosen walinya, dosen → dirinya sendiri
+		if self, err := repository.GetLecturerByUserID(claims.UserID); err == nil {
+			return c.JSON(fiber.Map{
+				"success":    true,
+				"data":       []any{self},
+				"pagination": singlePage(1, q),
 			})
 		}
-		return c.JSON(fiber.Map{
-			"success": true,
-			"data":    lects,
-		})
 
-	case "Mahasiswa":
-		// ✅ Mahasiswa → hanya dosen walinya
-		stud, err := repository.GetStudentByUserID(userID)
+		stud, err := repository.GetStudentByUserID(claims.UserID)
 		if err != nil || stud.AdvisorID == "" {
 			return c.JSON(fiber.Map{
-				"success": true,
-				"data":    []any{},
+				"success":    true,
+				"data":       []any{},
+				"pagination": singlePage(0, q),
 			})
 		}
 
@@ -58,21 +92,31 @@ func LecturerList(c *fiber.Ctx) error {
 		}
 
 		return c.JSON(fiber.Map{
-			"success": true,
-			"data":    []any{lect},
+			"success":    true,
+			"data":       []any{lect},
+			"pagination": singlePage(1, q),
 		})
 
-	case "Dosen Wali":
-		// ❌ Dosen tidak boleh lihat daftar dosen
+	default:
+		// ❌ tanpa permission lecturer:read
 		return c.Status(403).JSON(fiber.Map{
 			"error": "Forbidden",
 		})
+	}
 
-	default:
-		return c.Status(403).JSON(fiber.Map{
-			"error": "Role tidak dikenali",
-		})
+	lects, info, err := repository.ListLecturers(filter, q)
+	if err != nil {
+		return listError(c, err, "Gagal mengambil dosen")
+	}
+	if lects == nil {
+		lects = []model.Lecturer{}
 	}
+
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"data":       lects,
+		"pagination": info,
+	})
 }
 
 // ==================================================================
@@ -81,7 +125,7 @@ func LecturerList(c *fiber.Ctx) error {
 
 // LecturerAdvisees godoc
 // @Summary      Lihat Mahasiswa Bimbingan
-// @Description  Melihat daftar mahasiswa yang dibimbing oleh dosen tertentu. Dosen hanya bisa melihat bimbingannya sendiri.
+// @Description  Melihat daftar mahasiswa yang dibimbing oleh dosen tertentu sesuai scope permission student:read. Scope advisees hanya bimbingan sendiri.
 // @Tags         Lecturer
 // @Accept       json
 // @Produce      json
@@ -93,18 +137,36 @@ func LecturerList(c *fiber.Ctx) error {
 // @Router       /lecturers/{id}/advisees [get]
 func LecturerAdvisees(c *fiber.Ctx) error {
 	lectID := c.Params("id")
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
 
-	switch role {
+	switch policy.Can(claims, "read", "student") {
 
-	case "Admin":
-		// ✅ Admin boleh lihat advisees dosen mana pun
-		// lanjut
+	case policy.ScopeAll:
+		// ✅ boleh lihat advisees dosen mana pun
+
+	case policy.ScopeDepartment:
+		// ✅ hanya dosen pada departemen yang sama
+		self, err := repository.GetLecturerByUserID(claims.UserID)
+		if err != nil {
+			return c.Status(403).JSON(fiber.Map{
+				"error": "Data dosen tidak ditemukan",
+			})
+		}
+		target, err := repository.GetLecturerByID(lectID)
+		if err != nil {
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Dosen tidak ditemukan",
+			})
+		}
+		if !strings.EqualFold(self.Department, target.Department) {
+			return c.Status(403).JSON(fiber.Map{
+				"error": "Tidak boleh melihat mahasiswa bimbingan dosen departemen lain",
+			})
+		}
 
-	case "Dosen Wali":
-		// ✅ Dosen hanya boleh lihat advisees dirinya sendiri
-		self, err := repository.GetLecturerByUserID(userID)
+	case policy.ScopeAdvisees:
+		// ✅ dosen hanya boleh lihat advisees dirinya sendiri
+		self, err := repository.GetLecturerByUserID(claims.UserID)
 		if err != nil {
 			return c.Status(403).JSON(fiber.Map{
 				"error": "Data dosen tidak ditemukan",
@@ -117,7 +179,7 @@ func LecturerAdvisees(c *fiber.Ctx) error {
 		}
 
 	default:
-		// ❌ Mahasiswa tidak boleh akses endpoint ini
+		// ❌ scope own (mahasiswa) tidak boleh akses endpoint ini
 		return c.Status(403).JSON(fiber.Map{
 			"error": "Forbidden",
 		})
@@ -134,4 +196,312 @@ func LecturerAdvisees(c *fiber.Ctx) error {
 		"success": true,
 		"data":    students,
 	})
-}
\ No newline at end of file
+}
+
+// ==================================================================
+// CREATE / UPDATE / DEACTIVATE LECTURER (ADMIN ONLY)
+// ==================================================================
+
+// ValidateLecturerProfile merapikan lalu memvalidasi data profil dosen
+func ValidateLecturerProfile(p *model.LecturerProfileRequest) []model.FieldError {
+	p.LecturerID = strings.TrimSpace(p.LecturerID)
+	p.Department = strings.TrimSpace(p.Department)
+
+	errs := []model.FieldError{}
+	if p.LecturerID == "" {
+		errs = append(errs, model.FieldError{Field: "lecturer_id", Message: "wajib diisi"})
+	}
+	if p.Department == "" {
+		errs = append(errs, model.FieldError{Field: "department", Message: "wajib diisi"})
+	}
+	return errs
+}
+
+// newLecturerProfile menyusun profil dosen baru dari data yang sudah divalidasi
+func newLecturerProfile(userID string, p model.LecturerProfileRequest) *model.Lecturer {
+	return &model.Lecturer{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		LecturerID: p.LecturerID,
+		Department: p.Department,
+	}
+}
+
+// LecturerCreate godoc
+// @Summary      Buat Profil Dosen (Admin)
+// @Description  Membuat profil dosen untuk user yang role-nya memiliki permission achievement:verify dan belum memiliki profil. NIDN harus unik.
+// @Tags         Lecturer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      model.LecturerCreateRequest  true  "Data profil dosen"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /lecturers [post]
+func LecturerCreate(c *fiber.Ctx) error {
+	var req model.LecturerCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	if status, msg := checkProfileUser(req.UserID, policy.ProfileLecturer); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	if fieldErrs := ValidateLecturerProfile(&req.LecturerProfileRequest); len(fieldErrs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
+	}
+
+	lecturer := newLecturerProfile(req.UserID, req.LecturerProfileRequest)
+	if err := repository.CreateLecturer(lecturer); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "User sudah memiliki profil dosen atau NIDN sudah dipakai"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat profil dosen"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Profil dosen berhasil dibuat",
+		"data":    lecturer,
+	})
+}
+
+// LecturerUpdate godoc
+// @Summary      Update Profil Dosen (Admin)
+// @Description  Mengubah NIDN dan departemen dosen.
+// @Tags         Lecturer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                        true  "Lecturer ID"
+// @Param        request  body      model.LecturerProfileRequest  true  "Data profil dosen"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /lecturers/{id} [put]
+func LecturerUpdate(c *fiber.Ctx) error {
+	var req model.LecturerProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	lecturer, err := repository.GetLecturerByID(c.Params("id"))
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Dosen tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data dosen"})
+	}
+
+	if fieldErrs := ValidateLecturerProfile(&req); len(fieldErrs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
+	}
+
+	lecturer.LecturerID = req.LecturerID
+	lecturer.Department = req.Department
+
+	if err := repository.UpdateLecturer(lecturer); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "NIDN sudah dipakai dosen lain"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update profil dosen"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Profil dosen berhasil diupdate",
+		"data":    lecturer,
+	})
+}
+
+// LecturerDeactivate godoc
+// @Summary      Nonaktifkan Profil Dosen (Admin)
+// @Description  Menonaktifkan profil dosen (mis. pensiun atau pindah). Ditolak bila dosen masih membimbing mahasiswa aktif atau masih mempertahankan pengajuan yang belum ditinjau; pindahkan lebih dulu lewat POST /lecturers/{id}/reassign-advisees.
+// @Tags         Lecturer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Lecturer ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /lecturers/{id}/deactivate [post]
+func LecturerDeactivate(c *fiber.Ctx) error {
+	advisees, err := repository.CountActiveAdvisees(c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung mahasiswa bimbingan"})
+	}
+	if advisees > 0 {
+		return c.Status(409).JSON(fiber.Map{
+			"error":    "Dosen masih membimbing mahasiswa aktif, pindahkan bimbingannya lebih dulu",
+			"advisees": advisees,
+		})
+	}
+
+	kept, err := repository.CountReviewsKeptBy(c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung pengajuan yang belum ditinjau"})
+	}
+	if kept > 0 {
+		return c.Status(409).JSON(fiber.Map{
+			"error":           "Dosen masih mempertahankan pengajuan yang belum ditinjau, pindahkan dengan pending_reviews=transfer lebih dulu",
+			"pending_reviews": kept,
+		})
+	}
+	return setLecturerActive(c, false)
+}
+
+// LecturerReassignAdvisees godoc
+// @Summary      Pindahkan Mahasiswa Bimbingan (Admin)
+// @Description  Memindahkan mahasiswa bimbingan dosen ini ke dosen wali lain dalam satu transaksi, mis. saat dosen pensiun atau pindah. Tanpa student_ids, semua mahasiswa aktif bimbingannya dipindahkan dan pengajuan yang sebelumnya dipertahankan dosen ini ikut diproses. Bila masih ada pengajuan yang belum ditinjau, pending_reviews wajib dipilih: transfer (ikut pindah) atau keep (tetap ditinjau dosen ini).
+// @Tags         Lecturer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                        true  "Lecturer ID (dosen wali lama)"
+// @Param        request  body      model.AdvisorReassignRequest  true  "Dosen wali tujuan dan opsi pemindahan"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /lecturers/{id}/reassign-advisees [post]
+func LecturerReassignAdvisees(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	var req model.AdvisorReassignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	from, err := repository.GetLecturerByID(c.Params("id"))
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Dosen tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data dosen"})
+	}
+	if req.ToAdvisorID == from.ID {
+		return c.Status(400).JSON(fiber.Map{"error": "Dosen wali tujuan harus berbeda"})
+	}
+	if _, status, msg := checkNewAdvisor(req.ToAdvisorID); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	opts, msg := parseAdvisorChangeOptions(req.PendingReviews, req.EffectiveDate, req.Reason, claims.UserID)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	advisees, err := repository.GetStudentsByAdvisor(from.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil mahasiswa bimbingan"})
+	}
+
+	var studentIDs []string
+	if len(req.StudentIDs) == 0 {
+		for _, s := range advisees {
+			if s.DeactivatedAt == nil {
+				studentIDs = append(studentIDs, s.ID)
+			}
+		}
+	} else {
+		advised := map[string]bool{}
+		for _, s := range advisees {
+			advised[s.ID] = true
+		}
+		var notAdvised []string
+		for _, id := range req.StudentIDs {
+			if !advised[id] {
+				notAdvised = append(notAdvised, id)
+			} else if !slices.Contains(studentIDs, id) {
+				studentIDs = append(studentIDs, id)
+			}
+		}
+		if len(notAdvised) > 0 {
+			return c.Status(400).JSON(fiber.Map{
+				"error":       "Mahasiswa berikut bukan bimbingan dosen ini",
+				"student_ids": notAdvised,
+			})
+		}
+	}
+	if len(studentIDs) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Tidak ada mahasiswa yang dipindahkan"})
+	}
+
+	pending, err := repository.CountPendingReviews(studentIDs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung pengajuan yang belum ditinjau"})
+	}
+	// pengajuan yang dipertahankan dosen ini hanya ikut diproses saat seluruh bimbingannya dipindahkan
+	releaseReviewer := ""
+	if len(req.StudentIDs) == 0 {
+		kept, err := repository.CountReviewsKeptBy(from.ID)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung pengajuan yang belum ditinjau"})
+		}
+		pending += kept
+		if opts.pendingReviews == model.PendingReviewsTransfer {
+			releaseReviewer = from.ID
+		}
+	}
+	if pending > 0 && opts.pendingReviews == "" {
+		return pendingReviewsConflict(c, pending)
+	}
+
+	assignments := make([]model.AdvisorAssignment, len(studentIDs))
+	for i, id := range studentIDs {
+		assignments[i] = opts.assignment(id, req.ToAdvisorID, true)
+	}
+	if status, msg := reassignAdvisors(assignments, releaseReviewer); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": fmt.Sprintf("%d mahasiswa dipindahkan ke dosen wali baru", len(studentIDs)),
+		"data": fiber.Map{
+			"from_advisor_id": from.ID,
+			"to_advisor_id":   req.ToAdvisorID,
+			"student_ids":     studentIDs,
+			"pending_reviews": pending,
+		},
+	})
+}
+
+// LecturerActivate godoc
+// @Summary      Aktifkan Kembali Profil Dosen (Admin)
+// @Description  Mengaktifkan kembali profil dosen yang sebelumnya dinonaktifkan.
+// @Tags         Lecturer
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Lecturer ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /lecturers/{id}/activate [post]
+func LecturerActivate(c *fiber.Ctx) error {
+	return setLecturerActive(c, true)
+}
+
+func setLecturerActive(c *fiber.Ctx, active bool) error {
+	if err := repository.SetLecturerActive(c.Params("id"), active); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Dosen tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengubah status profil dosen"})
+	}
+
+	message := "Profil dosen dinonaktifkan"
+	if active {
+		message = "Profil dosen diaktifkan kembali"
+	}
+	return c.JSON(fiber.Map{"success": true, "message": message})
+}
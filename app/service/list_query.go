@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// parseListQuery membaca ?page, ?limit, ?cursor, ?sort dan ?order
+func parseListQuery(c *fiber.Ctx) model.ListQuery {
+	limit := c.QueryInt("limit", defaultListLimit)
+	if limit < 1 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return model.ListQuery{
+		Page:   c.QueryInt("page", 1),
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order", "desc"),
+	}
+}
+
+// queryList memecah query string berisi daftar dipisah koma, mis. ?status=submitted,verified
+func queryList(c *fiber.Ctx, key string) []string {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// queryDate membaca tanggal YYYY-MM-DD. endOfDay menggeser ke awal hari berikutnya
+// sehingga bisa dipakai sebagai batas eksklusif (< to).
+func queryDate(c *fiber.Ctx, key string, endOfDay bool) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, errors.New("format " + key + " harus YYYY-MM-DD")
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// listError memetakan error list ke response: sort/cursor tidak valid → 400
+func listError(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, repository.ErrInvalidSort) || errors.Is(err, repository.ErrInvalidCursor) {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.Status(500).JSON(fiber.Map{"error": message})
+}
+
+// singlePage dipakai untuk list yang isinya sudah pasti 0 atau 1 item (scope own)
+func singlePage(count int, q model.ListQuery) model.PageInfo {
+	return model.PageInfo{Total: count, Limit: q.Limit, Page: 1, Sort: q.Sort, Order: q.Order}
+}
@@ -1,3 +1,218 @@
 package service
 
-// (kosongkan dulu file ini sementara)
+import (
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ==================================================================
+// LIST PERMISSIONS
+// ==================================================================
+
+// PermissionList godoc
+// @Summary      Lihat Daftar Permission
+// @Description  Menampilkan seluruh permission (resource:action) yang tersedia.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /permissions [get]
+func PermissionList(c *fiber.Ctx) error {
+	perms, err := repository.GetAllPermissions()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data permission"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    perms,
+	})
+}
+
+// ==================================================================
+// DETAIL PERMISSION
+// ==================================================================
+
+// PermissionDetail godoc
+// @Summary      Detail Permission
+// @Description  Melihat detail satu permission berdasarkan ID.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Permission ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /permissions/{id} [get]
+func PermissionDetail(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	perm, err := repository.GetPermissionByID(id)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Permission tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil permission"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    perm,
+	})
+}
+
+// ==================================================================
+// CREATE PERMISSION
+// ==================================================================
+
+// PermissionCreate godoc
+// @Summary      Buat Permission Baru
+// @Description  Menambahkan permission baru. Resource dan action wajib diisi, nama default "resource:action".
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.PermissionRequest true "Data Permission"
+// @Success      201  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      409  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /permissions [post]
+func PermissionCreate(c *fiber.Ctx) error {
+	var req model.PermissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	req.Resource = strings.TrimSpace(req.Resource)
+	req.Action = strings.TrimSpace(req.Action)
+	if req.Resource == "" || req.Action == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "resource dan action wajib diisi"})
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = req.Resource + ":" + req.Action
+	}
+
+	perm := model.Permission{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Resource:    req.Resource,
+		Action:      req.Action,
+		Description: req.Description,
+	}
+
+	if err := repository.CreatePermission(&perm); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Nama permission sudah digunakan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat permission"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Permission berhasil dibuat",
+		"data":    perm,
+	})
+}
+
+// ==================================================================
+// UPDATE PERMISSION
+// ==================================================================
+
+// PermissionUpdate godoc
+// @Summary      Update Permission
+// @Description  Mengubah data permission. Field yang kosong tidak diubah (kecuali description).
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  string                  true  "Permission ID"
+// @Param        request body  model.PermissionRequest true  "Data Update"
+// @Success      200     {object} map[string]interface{}
+// @Failure      400     {object} map[string]interface{}
+// @Failure      404     {object} map[string]interface{}
+// @Failure      409     {object} map[string]interface{}
+// @Failure      500     {object} map[string]interface{}
+// @Router       /permissions/{id} [put]
+func PermissionUpdate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req model.PermissionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	perm, err := repository.GetPermissionByID(id)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Permission tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil permission"})
+	}
+
+	if v := strings.TrimSpace(req.Name); v != "" {
+		perm.Name = v
+	}
+	if v := strings.TrimSpace(req.Resource); v != "" {
+		perm.Resource = v
+	}
+	if v := strings.TrimSpace(req.Action); v != "" {
+		perm.Action = v
+	}
+	perm.Description = req.Description
+
+	if err := repository.UpdatePermission(perm); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Nama permission sudah digunakan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update permission"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Permission berhasil diupdate",
+		"data":    perm,
+	})
+}
+
+// ==================================================================
+// DELETE PERMISSION
+// ==================================================================
+
+// PermissionDelete godoc
+// @Summary      Hapus Permission
+// @Description  Menghapus permission dan melepasnya dari semua role.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Permission ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /permissions/{id} [delete]
+func PermissionDelete(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := repository.DeletePermission(id); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Permission tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus permission"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Permission berhasil dihapus",
+	})
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DefaultReconcileGrace melindungi dokumen yang baru dibuat: saga pembuatan
+// prestasi mungkin belum selesai menulis reference Postgre-nya.
+const DefaultReconcileGrace = 15 * time.Minute
+
+// RunReconciliation membandingkan dokumen MongoDB dengan achievement_references.
+//   - Dokumen tanpa reference (lebih tua dari grace) → dihapus bila repair.
+//   - Reference aktif tanpa dokumen → ditandai deleted bila repair, kecuali
+//     yang sudah verified (memiliki poin) sehingga harus ditangani manual.
+//   - Reference yang pemiliknya berbeda dengan studentId dokumen → studentId
+//     dokumen disamakan dengan Postgre bila repair.
+func RunReconciliation(repair bool, grace time.Duration, actorID string) (*model.ReconcileReport, error) {
+	report := &model.ReconcileReport{
+		Repair:             repair,
+		GracePeriod:        grace.String(),
+		StartedAt:          time.Now(),
+		OrphanDocuments:    []model.ReconcileOrphanDocument{},
+		DanglingReferences: []model.ReconcileDanglingReference{},
+		StudentMismatches:  []model.ReconcileStudentMismatch{},
+	}
+
+	// Reference dibaca lebih dulu: saga selalu menulis MongoDB sebelum Postgre,
+	// jadi setiap reference yang terbaca di sini dokumennya sudah ada saat scan MongoDB.
+	refs, err := repository.GetAllAchievementReferencesIncludingDeleted()
+	if err != nil {
+		return nil, err
+	}
+	docs, err := repository.GetAchievementSummaries()
+	if err != nil {
+		return nil, err
+	}
+	report.ScannedReferences = len(refs)
+	report.ScannedDocuments = len(docs)
+
+	referenced := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		referenced[ref.MongoAchievementID] = true
+	}
+
+	owners := make(map[string]string, len(docs))
+	cutoff := report.StartedAt.Add(-grace)
+
+	for _, doc := range docs {
+		id := doc.ID.Hex()
+		owners[id] = doc.StudentID
+
+		if referenced[id] || doc.CreatedAt.After(cutoff) {
+			continue
+		}
+
+		orphan := model.ReconcileOrphanDocument{
+			MongoAchievementID: id,
+			StudentID:          doc.StudentID,
+			Title:              doc.Title,
+			CreatedAt:          doc.CreatedAt,
+		}
+		if repair {
+			if err := repository.DeleteAchievement(id); err != nil {
+				orphan.Error = err.Error()
+			} else {
+				orphan.Repaired = true
+			}
+		}
+		report.OrphanDocuments = append(report.OrphanDocuments, orphan)
+	}
+
+	for _, ref := range refs {
+		if ref.Status == model.StatusDeleted {
+			continue
+		}
+
+		if owner, ok := owners[ref.MongoAchievementID]; ok {
+			if owner != ref.StudentID {
+				report.StudentMismatches = append(report.StudentMismatches, repairStudentMismatch(ref, owner, repair))
+			}
+			continue
+		}
+
+		dangling := model.ReconcileDanglingReference{
+			ReferenceID:        ref.ID,
+			MongoAchievementID: ref.MongoAchievementID,
+			StudentID:          ref.StudentID,
+			Status:             ref.Status,
+		}
+		if repair {
+			if ref.Status == model.StatusVerified {
+				dangling.Error = "prestasi terverifikasi harus ditangani manual"
+			} else if err := repository.DeleteDanglingAchievementReference(ref.ID, ref.Status, actorID); err != nil {
+				dangling.Error = err.Error()
+			} else {
+				dangling.Repaired = true
+			}
+		}
+		report.DanglingReferences = append(report.DanglingReferences, dangling)
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func repairStudentMismatch(ref model.AchievementReference, owner string, repair bool) model.ReconcileStudentMismatch {
+	mismatch := model.ReconcileStudentMismatch{
+		ReferenceID:        ref.ID,
+		MongoAchievementID: ref.MongoAchievementID,
+		ReferenceStudentID: ref.StudentID,
+		DocumentStudentID:  owner,
+	}
+	if repair {
+		if err := repository.UpdateAchievement(ref.MongoAchievementID, bson.M{"studentId": ref.StudentID}); err != nil {
+			mismatch.Error = err.Error()
+		} else {
+			mismatch.Repaired = true
+		}
+	}
+	return mismatch
+}
+
+// ==================================================================
+// RECONCILE ACHIEVEMENTS
+// ==================================================================
+
+// ReconcileReport godoc
+// @Summary      Laporan Konsistensi Prestasi
+// @Description  Mencari dokumen MongoDB tanpa reference Postgre, reference yang dokumennya hilang, dan pemilik (student_id) yang berbeda di kedua store, tanpa mengubah data.
+// @Tags         Maintenance
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        grace_minutes  query  int  false  "Umur minimal dokumen yatim dalam menit (default 15)"
+// @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /maintenance/reconcile [get]
+func ReconcileReport(c *fiber.Ctx) error {
+	return reconcile(c, false)
+}
+
+// ReconcileRepair godoc
+// @Summary      Perbaiki Konsistensi Prestasi
+// @Description  Sama seperti laporan, lalu menghapus dokumen MongoDB yatim, menandai reference tanpa dokumen sebagai deleted, dan menyamakan studentId dokumen dengan Postgre. Reference verified tanpa dokumen hanya dilaporkan.
+// @Tags         Maintenance
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        grace_minutes  query  int  false  "Umur minimal dokumen yatim dalam menit (default 15)"
+// @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /maintenance/reconcile [post]
+func ReconcileRepair(c *fiber.Ctx) error {
+	return reconcile(c, true)
+}
+
+func reconcile(c *fiber.Ctx, repair bool) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	grace := DefaultReconcileGrace
+	if minutes := c.QueryInt("grace_minutes", -1); minutes >= 0 {
+		grace = time.Duration(minutes) * time.Minute
+	}
+
+	report, err := RunReconciliation(repair, grace, claims.UserID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menjalankan rekonsiliasi"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    report,
+	})
+}
@@ -1,18 +1,20 @@
 package service
 
 import (
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
 	"prestasi_backend/app/repository"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // ==================================================================
-// SYSTEM STATISTICS (ADMIN ONLY)
+// SYSTEM STATISTICS
 // ==================================================================
 
 // ReportStatistics godoc
-// @Summary      Statistik Keseluruhan (Admin)
-// @Description  Melihat rekapitulasi data prestasi (Total Draft, Submitted, Verified, Rejected). Hanya bisa diakses oleh Admin.
+// @Summary      Statistik Prestasi
+// @Description  Melihat rekapitulasi data prestasi (Total Draft, Submitted, Verified, Rejected) sesuai scope permission report:read.
 // @Tags         Report
 // @Accept       json
 // @Produce      json
@@ -22,16 +24,43 @@ import (
 // @Failure      500  {object} map[string]interface{}
 // @Router       /reports/statistics [get]
 func ReportStatistics(c *fiber.Ctx) error {
-	role := c.Locals("role").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
 
-	// 🔒 hanya admin
-	if role != "Admin" {
+	var stats []repository.AchievementStats
+	var err error
+
+	// 🔒 statistik mengikuti scope report:read
+	switch policy.Can(claims, "read", "report") {
+	case policy.ScopeAll:
+		stats, err = repository.GetAchievementStats()
+
+	case policy.ScopeDepartment:
+		lect, lerr := repository.GetLecturerByUserID(claims.UserID)
+		if lerr != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "Data dosen tidak ditemukan"})
+		}
+		stats, err = repository.GetDepartmentAchievementStats(lect.Department)
+
+	case policy.ScopeAdvisees:
+		lect, lerr := repository.GetLecturerByUserID(claims.UserID)
+		if lerr != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "Data dosen tidak ditemukan"})
+		}
+		stats, err = repository.GetAdvisorAchievementStats(lect.ID)
+
+	case policy.ScopeOwn:
+		self, serr := repository.GetStudentByUserID(claims.UserID)
+		if serr != nil {
+			return c.Status(404).JSON(fiber.Map{"error": "Data mahasiswa tidak ditemukan"})
+		}
+		stats, err = repository.GetStudentAchievementStats(self.ID)
+
+	default:
 		return c.Status(403).JSON(fiber.Map{
-			"error": "Hanya admin yang dapat mengakses statistik",
+			"error": "Tidak punya akses ke statistik prestasi",
 		})
 	}
 
-	stats, err := repository.GetAchievementStats()
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{
 			"error": "Gagal mengambil statistik prestasi",
@@ -50,7 +79,7 @@ func ReportStatistics(c *fiber.Ctx) error {
 
 // ReportStudent godoc
 // @Summary      Laporan Statistik Mahasiswa
-// @Description  Melihat performa prestasi satu mahasiswa spesifik. Mahasiswa hanya bisa lihat diri sendiri, Dosen hanya bimbingannya, Admin bebas.
+// @Description  Melihat performa prestasi satu mahasiswa spesifik sesuai scope permission report:read.
 // @Tags         Report
 // @Accept       json
 // @Produce      json
@@ -63,8 +92,7 @@ func ReportStatistics(c *fiber.Ctx) error {
 // @Router       /reports/student/{id} [get]
 func ReportStudent(c *fiber.Ctx) error {
 	targetStudentID := c.Params("id")
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
 
 	// ambil mahasiswa target
 	targetStudent, err := repository.GetStudentByID(targetStudentID)
@@ -75,34 +103,17 @@ func ReportStudent(c *fiber.Ctx) error {
 	}
 
 	// ===================================================
-	// ROLE & OWNERSHIP VALIDATION
+	// POLICY VALIDATION
 	// ===================================================
-	switch role {
-
-	case "Mahasiswa":
-		// mahasiswa hanya boleh lihat laporan dirinya sendiri
-		self, err := repository.GetStudentByUserID(userID)
-		if err != nil || self.ID != targetStudent.ID {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Tidak boleh melihat laporan mahasiswa lain",
-			})
-		}
-
-	case "Dosen Wali":
-		// dosen hanya boleh lihat mahasiswa bimbingannya
-		lect, err := repository.GetLecturerByUserID(userID)
-		if err != nil || targetStudent.AdvisorID != lect.ID {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Mahasiswa bukan bimbingan Anda",
-			})
-		}
-
-	case "Admin":
-		// admin bebas
-
-	default:
+	ok, err := policy.CanAccessStudent(claims, "read", "report", targetStudent)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{
+			"error": "Gagal mengecek hak akses",
+		})
+	}
+	if !ok {
 		return c.Status(403).JSON(fiber.Map{
-			"error": "Role tidak dikenali",
+			"error": "Tidak boleh melihat laporan mahasiswa ini",
 		})
 	}
 
@@ -1,3 +1,129 @@
 package service
 
-// (kosongkan dulu file ini sementara)
+import (
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ==================================================================
+// LIST PERMISSIONS OF ROLE
+// ==================================================================
+
+// RolePermissionList godoc
+// @Summary      Lihat Permission Milik Role
+// @Description  Menampilkan daftar permission yang sudah ditempelkan ke role tertentu.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Role ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /roles/{id}/permissions [get]
+func RolePermissionList(c *fiber.Ctx) error {
+	roleID := c.Params("id")
+
+	if _, err := repository.GetRoleByID(roleID); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Role tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil role"})
+	}
+
+	perms, err := repository.GetPermissionDetailsByRoleID(roleID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil permission role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    perms,
+	})
+}
+
+// ==================================================================
+// ATTACH PERMISSION
+// ==================================================================
+
+// RolePermissionAdd godoc
+// @Summary      Tambah Permission ke Role
+// @Description  Menempelkan permission ke role. Berlaku untuk token yang diterbitkan setelah perubahan ini.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  string                      true  "Role ID"
+// @Param        request body  model.RolePermissionRequest true  "Permission ID"
+// @Success      200     {object} map[string]interface{}
+// @Failure      400     {object} map[string]interface{}
+// @Failure      404     {object} map[string]interface{}
+// @Failure      500     {object} map[string]interface{}
+// @Router       /roles/{id}/permissions [post]
+func RolePermissionAdd(c *fiber.Ctx) error {
+	roleID := c.Params("id")
+
+	var req model.RolePermissionRequest
+	if err := c.BodyParser(&req); err != nil || req.PermissionID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "permission_id wajib diisi"})
+	}
+
+	if _, err := repository.GetRoleByID(roleID); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Role tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil role"})
+	}
+
+	if _, err := repository.GetPermissionByID(req.PermissionID); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Permission tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil permission"})
+	}
+
+	if err := repository.AddPermissionToRole(roleID, req.PermissionID); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menambahkan permission ke role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Permission berhasil ditambahkan ke role",
+	})
+}
+
+// ==================================================================
+// DETACH PERMISSION
+// ==================================================================
+
+// RolePermissionRemove godoc
+// @Summary      Lepas Permission dari Role
+// @Description  Melepas permission dari role tertentu. Mengembalikan 404 bila permission tidak terpasang pada role.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path  string  true  "Role ID"
+// @Param        permissionId  path  string  true  "Permission ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /roles/{id}/permissions/{permissionId} [delete]
+func RolePermissionRemove(c *fiber.Ctx) error {
+	roleID := c.Params("id")
+	permID := c.Params("permissionId")
+
+	if err := repository.RemovePermissionFromRole(roleID, permID); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Permission tidak terpasang pada role"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal melepas permission dari role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Permission berhasil dilepas dari role",
+	})
+}
@@ -1,3 +1,223 @@
 package service
 
-// (kosongkan dulu file ini sementara)
+import (
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ==================================================================
+// LIST ROLES
+// ==================================================================
+
+// RoleList godoc
+// @Summary      Lihat Daftar Role
+// @Description  Menampilkan seluruh role yang terdaftar di sistem RBAC.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /roles [get]
+func RoleList(c *fiber.Ctx) error {
+	roles, err := repository.GetAllRoles()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    roles,
+	})
+}
+
+// ==================================================================
+// DETAIL ROLE
+// ==================================================================
+
+// RoleDetail godoc
+// @Summary      Detail Role
+// @Description  Melihat detail satu role beserta daftar permission yang dimilikinya.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Role ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /roles/{id} [get]
+func RoleDetail(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	role, err := repository.GetRoleByID(id)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Role tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil role"})
+	}
+
+	perms, err := repository.GetPermissionDetailsByRoleID(id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil permission role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"role":        role,
+			"permissions": perms,
+		},
+	})
+}
+
+// ==================================================================
+// CREATE ROLE
+// ==================================================================
+
+// RoleCreate godoc
+// @Summary      Buat Role Baru
+// @Description  Menambahkan role baru (misal: Kaprodi, Wakil Dekan). Nama role harus unik.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.RoleRequest true "Data Role"
+// @Success      201  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Failure      409  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /roles [post]
+func RoleCreate(c *fiber.Ctx) error {
+	var req model.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "name wajib diisi"})
+	}
+
+	role := model.Role{
+		ID:          uuid.NewString(),
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := repository.CreateRole(&role); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Nama role sudah digunakan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat role"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Role berhasil dibuat",
+		"data":    role,
+	})
+}
+
+// ==================================================================
+// UPDATE ROLE
+// ==================================================================
+
+// RoleUpdate godoc
+// @Summary      Update Role
+// @Description  Mengubah nama dan deskripsi role.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  string            true  "Role ID"
+// @Param        request body  model.RoleRequest true  "Data Update"
+// @Success      200     {object} map[string]interface{}
+// @Failure      400     {object} map[string]interface{}
+// @Failure      404     {object} map[string]interface{}
+// @Failure      409     {object} map[string]interface{}
+// @Failure      500     {object} map[string]interface{}
+// @Router       /roles/{id} [put]
+func RoleUpdate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req model.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	role, err := repository.GetRoleByID(id)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Role tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil role"})
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		role.Name = name
+	}
+	role.Description = req.Description
+
+	if err := repository.UpdateRole(role); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Nama role sudah digunakan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Role berhasil diupdate",
+		"data":    role,
+	})
+}
+
+// ==================================================================
+// DELETE ROLE
+// ==================================================================
+
+// RoleDelete godoc
+// @Summary      Hapus Role
+// @Description  Menghapus role beserta relasi permission-nya. Role yang masih dipakai user tidak bisa dihapus.
+// @Tags         Role Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Role ID"
+// @Success      200  {object} map[string]interface{}
+// @Failure      404  {object} map[string]interface{}
+// @Failure      409  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /roles/{id} [delete]
+func RoleDelete(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	used, err := repository.CountUsersByRole(id)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengecek pemakaian role"})
+	}
+	if used > 0 {
+		return c.Status(409).JSON(fiber.Map{
+			"error": "Role masih dipakai oleh user, pindahkan user ke role lain terlebih dahulu",
+		})
+	}
+
+	if err := repository.DeleteRole(id); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Role tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menghapus role"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Role berhasil dihapus",
+	})
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"prestasi_backend/app/model"
+)
+
+// Cara membagi poin untuk prestasi tim
+const (
+	TeamSplitNone   = "none"   // setiap anggota mendapat poin penuh
+	TeamSplitDivide = "divide" // poin dibagi rata jumlah anggota
+	TeamSplitSqrt   = "sqrt"   // poin dibagi akar jumlah anggota
+)
+
+// ComputeAchievementPoints menghitung poin prestasi dari details menurut satu versi aturan.
+// Input yang dipakai: jenis prestasi, details.level, details.rank dan details.teamSize.
+func ComputeAchievementPoints(rule *model.ScoringRule, doc *model.AchievementMongo) model.ScoreBreakdown {
+	set := rule.Rules
+	b := model.ScoreBreakdown{
+		RuleVersion:     rule.Version,
+		AchievementType: doc.AchievementType,
+		LevelMultiplier: set.DefaultLevelMultiplier,
+		RankMultiplier:  1,
+		TeamSize:        1,
+		TeamFactor:      1,
+	}
+
+	if base, ok := set.BasePoints[doc.AchievementType]; ok {
+		b.Base = base
+	} else {
+		b.Base = set.BasePoints["default"]
+	}
+
+	if level, ok := doc.Details["level"].(string); ok {
+		b.Level = level
+		if m, ok := set.LevelMultipliers[level]; ok {
+			b.LevelMultiplier = m
+		}
+	}
+
+	if rank, ok := toFloat(doc.Details["rank"]); ok && rank >= 1 {
+		b.Rank = int(rank)
+		b.RankMultiplier = rankMultiplier(set, b.Rank)
+	}
+
+	if size, ok := toFloat(doc.Details["teamSize"]); ok && size > 1 {
+		b.TeamSize = int(size)
+		switch set.TeamSplit {
+		case TeamSplitDivide:
+			b.TeamFactor = 1 / float64(b.TeamSize)
+		case TeamSplitSqrt:
+			b.TeamFactor = 1 / math.Sqrt(float64(b.TeamSize))
+		}
+	}
+
+	points := int(math.Round(b.Base * b.LevelMultiplier * b.RankMultiplier * b.TeamFactor))
+	if points < set.MinPoints {
+		points = set.MinPoints
+	}
+	if set.MaxPoints > 0 && points > set.MaxPoints {
+		points = set.MaxPoints
+	}
+	b.Points = points
+
+	return b
+}
+
+// rankMultiplier memilih entri dengan MaxRank terkecil yang masih mencakup rank
+func rankMultiplier(set model.ScoringRuleSet, rank int) float64 {
+	tiers := append([]model.RankMultiplier(nil), set.RankMultipliers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MaxRank < tiers[j].MaxRank })
+
+	for _, t := range tiers {
+		if rank <= t.MaxRank {
+			return t.Multiplier
+		}
+	}
+	return set.DefaultRankMultiplier
+}
+
+// ValidateScoringRuleSet memeriksa aturan skor yang dikirim admin sebelum disimpan.
+func ValidateScoringRuleSet(set *model.ScoringRuleSet) []model.FieldError {
+	errs := []model.FieldError{}
+
+	if len(set.BasePoints) == 0 {
+		errs = append(errs, model.FieldError{Field: "rules.base_points", Message: "wajib diisi minimal satu jenis (atau 'default')"})
+	}
+	for _, k := range sortedKeys(set.BasePoints) {
+		if set.BasePoints[k] < 0 {
+			errs = append(errs, model.FieldError{Field: "rules.base_points." + k, Message: "tidak boleh negatif"})
+		}
+	}
+	for _, k := range sortedKeys(set.LevelMultipliers) {
+		if set.LevelMultipliers[k] < 0 {
+			errs = append(errs, model.FieldError{Field: "rules.level_multipliers." + k, Message: "tidak boleh negatif"})
+		}
+	}
+	if set.DefaultLevelMultiplier < 0 {
+		errs = append(errs, model.FieldError{Field: "rules.default_level_multiplier", Message: "tidak boleh negatif"})
+	}
+	if set.DefaultRankMultiplier < 0 {
+		errs = append(errs, model.FieldError{Field: "rules.default_rank_multiplier", Message: "tidak boleh negatif"})
+	}
+
+	seen := map[int]bool{}
+	for i, t := range set.RankMultipliers {
+		path := fmt.Sprintf("rules.rank_multipliers[%d]", i)
+		if t.MaxRank < 1 {
+			errs = append(errs, model.FieldError{Field: path + ".max_rank", Message: "minimal 1"})
+		} else if seen[t.MaxRank] {
+			errs = append(errs, model.FieldError{Field: path + ".max_rank", Message: "duplikat"})
+		}
+		seen[t.MaxRank] = true
+		if t.Multiplier < 0 {
+			errs = append(errs, model.FieldError{Field: path + ".multiplier", Message: "tidak boleh negatif"})
+		}
+	}
+
+	switch set.TeamSplit {
+	case TeamSplitNone, TeamSplitDivide, TeamSplitSqrt:
+	case "":
+		set.TeamSplit = TeamSplitNone
+	default:
+		errs = append(errs, model.FieldError{Field: "rules.team_split", Message: "harus none, divide, atau sqrt"})
+	}
+
+	if set.MinPoints < 0 {
+		errs = append(errs, model.FieldError{Field: "rules.min_points", Message: "tidak boleh negatif"})
+	}
+	if set.MaxPoints > 0 && set.MaxPoints < set.MinPoints {
+		errs = append(errs, model.FieldError{Field: "rules.max_points", Message: "harus lebih besar dari min_points"})
+	}
+
+	return errs
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"strconv"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ==================================================================
+// LIST SCORING RULES
+// ==================================================================
+
+// ScoringRuleList godoc
+// @Summary      Daftar Versi Aturan Skor
+// @Description  Menampilkan seluruh versi aturan perhitungan poin, terbaru dulu. Hanya satu versi yang aktif.
+// @Tags         Scoring Rule
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object} map[string]interface{}
+// @Failure      500  {object} map[string]interface{}
+// @Router       /scoring-rules [get]
+func ScoringRuleList(c *fiber.Ctx) error {
+	rules, err := repository.GetScoringRules()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil aturan skor"})
+	}
+	if rules == nil {
+		rules = []model.ScoringRule{}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    rules,
+	})
+}
+
+// ==================================================================
+// DETAIL SCORING RULE
+// ==================================================================
+
+// ScoringRuleDetail godoc
+// @Summary      Detail Versi Aturan Skor
+// @Description  Menampilkan satu versi aturan skor. Gunakan 'active' sebagai version untuk versi yang sedang aktif.
+// @Tags         Scoring Rule
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        version  path      string  true  "Nomor versi atau 'active'"
+// @Success      200      {object} map[string]interface{}
+// @Failure      404      {object} map[string]interface{}
+// @Router       /scoring-rules/{version} [get]
+func ScoringRuleDetail(c *fiber.Ctx) error {
+	rule, status, msg := findScoringRule(c.Params("version"))
+	if rule == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    rule,
+	})
+}
+
+// ==================================================================
+// CREATE SCORING RULE VERSION
+// ==================================================================
+
+// ScoringRuleCreate godoc
+// @Summary      Buat Versi Aturan Skor
+// @Description  Menyimpan versi aturan skor baru dalam keadaan belum aktif. Gunakan preview untuk melihat dampaknya lalu activate.
+// @Tags         Scoring Rule
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body model.ScoringRuleRequest true "Aturan Skor"
+// @Success      201  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
+// @Router       /scoring-rules [post]
+func ScoringRuleCreate(c *fiber.Ctx) error {
+	var req model.ScoringRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	if errs := ValidateScoringRuleSet(&req.Rules); len(errs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Aturan skor tidak valid", "fields": errs})
+	}
+
+	userID := c.Locals("userId").(string)
+	rule := model.ScoringRule{
+		Description: req.Description,
+		Rules:       req.Rules,
+		CreatedBy:   &userID,
+	}
+
+	if err := repository.CreateScoringRule(&rule); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Versi bentrok dengan request lain, coba lagi"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan aturan skor"})
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"success": true,
+		"message": "Aturan skor versi baru berhasil dibuat (belum aktif)",
+		"data":    rule,
+	})
+}
+
+// ==================================================================
+// PREVIEW SCORING RULE
+// ==================================================================
+
+// ScoringRulePreview godoc
+// @Summary      Simulasi Aturan Skor
+// @Description  Menghitung ulang poin seluruh prestasi terverifikasi memakai versi aturan tertentu tanpa menyimpan apa pun, lalu membandingkannya dengan poin tersimpan.
+// @Tags         Scoring Rule
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        version  path      string  true  "Nomor versi atau 'active'"
+// @Success      200      {object} map[string]interface{}
+// @Failure      404      {object} map[string]interface{}
+// @Router       /scoring-rules/{version}/preview [get]
+func ScoringRulePreview(c *fiber.Ctx) error {
+	rule, status, msg := findScoringRule(c.Params("version"))
+	if rule == nil {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	refs, err := repository.GetVerifiedAchievementReferences()
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil prestasi terverifikasi"})
+	}
+
+	joined, issues, err := JoinAchievements(refs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement (MongoDB)"})
+	}
+
+	refIDs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		refIDs = append(refIDs, ref.ID)
+	}
+	teamSizes, err := repository.GetConfirmedMemberCounts(refIDs)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil anggota tim"})
+	}
+
+	items := []model.ScoringPreviewItem{}
+	totalBefore, totalAfter, changed := 0, 0, 0
+
+	for _, row := range joined {
+		if row.Issue != nil {
+			continue
+		}
+		ref, doc := row.Reference, row.Achievement
+
+		score := ComputeTeamPoints(rule, doc, teamSizes[ref.ID])
+		item := model.ScoringPreviewItem{
+			ReferenceID:    ref.ID,
+			StudentID:      ref.StudentID,
+			Title:          doc.Title,
+			CurrentPoints:  ref.Points,
+			CurrentVersion: ref.ScoringRuleVersion,
+			NewPoints:      score.Points,
+			Delta:          score.Points - ref.Points,
+		}
+		items = append(items, item)
+
+		totalBefore += item.CurrentPoints
+		totalAfter += item.NewPoints
+		if item.Delta != 0 {
+			changed++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"version": rule.Version,
+			"summary": fiber.Map{
+				"achievements": len(items),
+				"changed":      changed,
+				"total_before": totalBefore,
+				"total_after":  totalAfter,
+				"issues":       issues,
+			},
+			"items": items,
+		},
+	})
+}
+
+// ==================================================================
+// ACTIVATE SCORING RULE
+// ==================================================================
+
+// ScoringRuleActivate godoc
+// @Summary      Aktifkan Versi Aturan Skor
+// @Description  Menjadikan versi ini aturan aktif untuk verifikasi berikutnya. Poin prestasi yang sudah diverifikasi tidak diubah dan tetap mencatat versi lamanya.
+// @Tags         Scoring Rule
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        version  path      int  true  "Nomor versi"
+// @Success      200      {object} map[string]interface{}
+// @Failure      404      {object} map[string]interface{}
+// @Router       /scoring-rules/{version}/activate [post]
+func ScoringRuleActivate(c *fiber.Ctx) error {
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Versi tidak valid"})
+	}
+
+	if err := repository.ActivateScoringRule(version); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Versi aturan skor tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengaktifkan aturan skor"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Aturan skor versi " + strconv.Itoa(version) + " sekarang aktif",
+	})
+}
+
+// findScoringRule mengambil aturan berdasarkan nomor versi atau "active"
+func findScoringRule(param string) (*model.ScoringRule, int, string) {
+	var rule *model.ScoringRule
+	var err error
+
+	if param == "active" {
+		rule, err = repository.GetActiveScoringRule()
+	} else {
+		version, convErr := strconv.Atoi(param)
+		if convErr != nil {
+			return nil, 400, "Versi tidak valid"
+		}
+		rule, err = repository.GetScoringRuleByVersion(version)
+	}
+
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return nil, 404, "Versi aturan skor tidak ditemukan"
+		}
+		return nil, 500, "Gagal mengambil aturan skor"
+	}
+	return rule, 0, ""
+}
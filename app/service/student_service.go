@@ -1,7 +1,13 @@
 package service
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
 	"prestasi_backend/app/repository"
 )
 
@@ -11,50 +17,82 @@ import (
 
 // StudentList godoc
 // @Summary      Lihat Daftar Mahasiswa
-// @Description  Admin melihat semua mahasiswa. Dosen Wali hanya melihat mahasiswa bimbingannya.
+// @Description  Menampilkan mahasiswa sesuai scope permission student:read (own, advisees, department, all), dengan paginasi, filter, dan sorting.
 // @Tags         Student
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        page           query  int     false  "Nomor halaman (default 1)"
+// @Param        limit          query  int     false  "Jumlah per halaman (default 20, maks 100)"
+// @Param        cursor         query  string  false  "next_cursor dari response sebelumnya"
+// @Param        sort           query  string  false  "student_id | academic_year | created_at"
+// @Param        order          query  string  false  "asc | desc"
+// @Param        program_study  query  string  false  "Program studi"
+// @Param        academic_year  query  string  false  "Angkatan, mis. 2021/2022"
+// @Param        is_active      query  bool    false  "Filter profil aktif / nonaktif"
+// @Param        search         query  string  false  "Cari NIM"
 // @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
 // @Failure      403  {object} map[string]interface{}
 // @Failure      404  {object} map[string]interface{}
 // @Failure      500  {object} map[string]interface{}
 // @Router       /students [get]
 func StudentList(c *fiber.Ctx) error {
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
+	q := parseListQuery(c)
 
-	// ADMIN → semua mahasiswa
-	if role == "Admin" {
-		students, err := repository.GetAllStudents()
-		if err != nil {
-			return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil mahasiswa"})
-		}
-		return c.JSON(fiber.Map{"success": true, "data": students})
+	filter := model.StudentFilter{
+		ProgramStudy: c.Query("program_study"),
+		AcademicYear: c.Query("academic_year"),
+		Search:       c.Query("search"),
 	}
+	if raw := c.Query("is_active"); raw != "" {
+		active := c.QueryBool("is_active")
+		filter.IsActive = &active
+	}
+
+	switch policy.Can(claims, "read", "student") {
+	case policy.ScopeAll:
+		// tanpa batasan tambahan
 
-	// DOSEN WALI → mahasiswa bimbingannya
-	if role == "Dosen Wali" {
-		lect, err := repository.GetLecturerByUserID(userID)
+	case policy.ScopeDepartment:
+		lect, err := repository.GetLecturerByUserID(claims.UserID)
 		if err != nil {
 			return c.Status(404).JSON(fiber.Map{
 				"error": "Data dosen tidak ditemukan",
 			})
 		}
+		filter.ProgramStudy = lect.Department
 
-		students, err := repository.GetStudentsByAdvisor(lect.ID)
+	case policy.ScopeAdvisees:
+		lect, err := repository.GetLecturerByUserID(claims.UserID)
 		if err != nil {
-			return c.Status(500).JSON(fiber.Map{
-				"error": "Gagal mengambil mahasiswa bimbingan",
+			return c.Status(404).JSON(fiber.Map{
+				"error": "Data dosen tidak ditemukan",
 			})
 		}
+		filter.AdvisorID = lect.ID
+
+	case policy.ScopeOwn:
+		self, err := repository.GetStudentByUserID(claims.UserID)
+		if err != nil {
+			return c.JSON(fiber.Map{"success": true, "data": []any{}, "pagination": singlePage(0, q)})
+		}
+		return c.JSON(fiber.Map{"success": true, "data": []any{self}, "pagination": singlePage(1, q)})
 
-		return c.JSON(fiber.Map{"success": true, "data": students})
+	default:
+		return c.Status(403).JSON(fiber.Map{"error": "Forbidden"})
+	}
+
+	students, info, err := repository.ListStudents(filter, q)
+	if err != nil {
+		return listError(c, err, "Gagal mengambil mahasiswa")
+	}
+	if students == nil {
+		students = []model.Student{}
 	}
 
-	// MAHASISWA → tidak boleh lihat semua
-	return c.Status(403).JSON(fiber.Map{"error": "Forbidden"})
+	return c.JSON(fiber.Map{"success": true, "data": students, "pagination": info})
 }
 
 // ==================================================================
@@ -63,7 +101,7 @@ func StudentList(c *fiber.Ctx) error {
 
 // StudentDetail godoc
 // @Summary      Detail Mahasiswa
-// @Description  Melihat detail data satu mahasiswa. Mahasiswa hanya bisa lihat diri sendiri. Dosen hanya bimbingannya.
+// @Description  Melihat detail data satu mahasiswa sesuai scope permission student:read.
 // @Tags         Student
 // @Accept       json
 // @Produce      json
@@ -75,74 +113,343 @@ func StudentList(c *fiber.Ctx) error {
 // @Router       /students/{id} [get]
 func StudentDetail(c *fiber.Ctx) error {
 	id := c.Params("id")
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
 
 	stud, err := repository.GetStudentByID(id)
 	if err != nil {
 		return c.Status(404).JSON(fiber.Map{"error": "Mahasiswa tidak ditemukan"})
 	}
 
-	// mahasiswa hanya boleh lihat dirinya sendiri
-	if role == "Mahasiswa" {
-		self, _ := repository.GetStudentByUserID(userID)
-		if self.ID != stud.ID {
-			return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses data mahasiswa lain"})
+	ok, err := policy.CanAccessStudent(claims, "read", "student", stud)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengecek hak akses"})
+	}
+	if !ok {
+		return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses data mahasiswa ini"})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": stud})
+}
+
+// ==================================================================
+// CREATE / UPDATE / DEACTIVATE STUDENT (ADMIN ONLY)
+// ==================================================================
+
+var academicYearPattern = regexp.MustCompile(`^\d{4}/\d{4}$`)
+
+// ValidateStudentProfile merapikan lalu memvalidasi data profil mahasiswa.
+// Dosen wali (opsional) harus ada dan masih aktif. Error non-nil hanya untuk
+// kegagalan database.
+func ValidateStudentProfile(p *model.StudentProfileRequest) ([]model.FieldError, error) {
+	p.StudentID = strings.TrimSpace(p.StudentID)
+	p.ProgramStudy = strings.TrimSpace(p.ProgramStudy)
+	p.AcademicYear = strings.TrimSpace(p.AcademicYear)
+	p.AdvisorID = strings.TrimSpace(p.AdvisorID)
+
+	errs := []model.FieldError{}
+	if p.StudentID == "" {
+		errs = append(errs, model.FieldError{Field: "student_id", Message: "wajib diisi"})
+	}
+	if p.ProgramStudy == "" {
+		errs = append(errs, model.FieldError{Field: "program_study", Message: "wajib diisi"})
+	}
+	if p.AcademicYear == "" {
+		errs = append(errs, model.FieldError{Field: "academic_year", Message: "wajib diisi"})
+	} else if !academicYearPattern.MatchString(p.AcademicYear) {
+		errs = append(errs, model.FieldError{Field: "academic_year", Message: "format harus YYYY/YYYY"})
+	}
+
+	if p.AdvisorID != "" {
+		lect, err := repository.GetLecturerByID(p.AdvisorID)
+		switch {
+		case repository.IsNoRows(err):
+			errs = append(errs, model.FieldError{Field: "advisor_id", Message: "dosen tidak ditemukan"})
+		case err != nil:
+			return nil, err
+		case lect.DeactivatedAt != nil:
+			errs = append(errs, model.FieldError{Field: "advisor_id", Message: "dosen sudah nonaktif"})
 		}
 	}
+	return errs, nil
+}
 
-	// dosen hanya boleh lihat bimbingannya
-	if role == "Dosen Wali" {
-		lect, err := repository.GetLecturerByUserID(userID)
-		if err != nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Data dosen tidak ditemukan",
-			})
+// StudentCreate godoc
+// @Summary      Buat Profil Mahasiswa (Admin)
+// @Description  Membuat profil mahasiswa untuk user yang role-nya memiliki permission achievement:create (scope own) dan belum memiliki profil. NIM harus unik.
+// @Tags         Student
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      model.StudentCreateRequest  true  "Data profil mahasiswa"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /students [post]
+func StudentCreate(c *fiber.Ctx) error {
+	var req model.StudentCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	if status, msg := checkProfileUser(req.UserID, policy.ProfileStudent); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+
+	fieldErrs, err := ValidateStudentProfile(&req.StudentProfileRequest)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memvalidasi profil mahasiswa"})
+	}
+	if len(fieldErrs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
+	}
+
+	student := newStudentProfile(req.UserID, req.StudentProfileRequest)
+	if err := repository.CreateStudent(student); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "User sudah memiliki profil mahasiswa atau NIM sudah dipakai"})
 		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat profil mahasiswa"})
+	}
 
-		if stud.AdvisorID != lect.ID {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "Mahasiswa bukan bimbingan Anda",
-			})
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Profil mahasiswa berhasil dibuat",
+		"data":    student,
+	})
+}
+
+// newStudentProfile menyusun profil mahasiswa baru dari data yang sudah divalidasi
+func newStudentProfile(userID string, p model.StudentProfileRequest) *model.Student {
+	return &model.Student{
+		ID:           uuid.NewString(),
+		UserID:       userID,
+		StudentID:    p.StudentID,
+		ProgramStudy: p.ProgramStudy,
+		AcademicYear: p.AcademicYear,
+		AdvisorID:    p.AdvisorID,
+	}
+}
+
+// StudentUpdate godoc
+// @Summary      Update Profil Mahasiswa (Admin)
+// @Description  Mengubah NIM, program studi dan angkatan mahasiswa. Dosen wali diubah lewat PUT /students/{id}/advisor.
+// @Tags         Student
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                      true  "Student ID"
+// @Param        request  body      model.StudentUpdateRequest  true  "Data profil mahasiswa"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /students/{id} [put]
+func StudentUpdate(c *fiber.Ctx) error {
+	var req model.StudentUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	student, err := repository.GetStudentByID(c.Params("id"))
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Mahasiswa tidak ditemukan"})
 		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data mahasiswa"})
 	}
 
-	return c.JSON(fiber.Map{"success": true, "data": stud})
+	profile := model.StudentProfileRequest{
+		StudentID:    req.StudentID,
+		ProgramStudy: req.ProgramStudy,
+		AcademicYear: req.AcademicYear,
+	}
+	fieldErrs, err := ValidateStudentProfile(&profile)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal memvalidasi profil mahasiswa"})
+	}
+	if len(fieldErrs) > 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "Validasi gagal", "fields": fieldErrs})
+	}
+
+	student.StudentID = profile.StudentID
+	student.ProgramStudy = profile.ProgramStudy
+	student.AcademicYear = profile.AcademicYear
+
+	if err := repository.UpdateStudent(student); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "NIM sudah dipakai mahasiswa lain"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal update profil mahasiswa"})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Profil mahasiswa berhasil diupdate",
+		"data":    student,
+	})
+}
+
+// StudentDeactivate godoc
+// @Summary      Nonaktifkan Profil Mahasiswa (Admin)
+// @Description  Menonaktifkan profil mahasiswa (mis. lulus atau keluar). Prestasi lama tetap tersimpan, tetapi mahasiswa tidak bisa membuat prestasi baru. Akun login diatur terpisah lewat /users.
+// @Tags         Student
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Student ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /students/{id}/deactivate [post]
+func StudentDeactivate(c *fiber.Ctx) error {
+	return setStudentActive(c, false)
+}
+
+// StudentActivate godoc
+// @Summary      Aktifkan Kembali Profil Mahasiswa (Admin)
+// @Description  Mengaktifkan kembali profil mahasiswa yang sebelumnya dinonaktifkan.
+// @Tags         Student
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Student ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /students/{id}/activate [post]
+func StudentActivate(c *fiber.Ctx) error {
+	return setStudentActive(c, true)
+}
+
+func setStudentActive(c *fiber.Ctx, active bool) error {
+	if err := repository.SetStudentActive(c.Params("id"), active); err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Mahasiswa tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengubah status profil mahasiswa"})
+	}
+
+	message := "Profil mahasiswa dinonaktifkan"
+	if active {
+		message = "Profil mahasiswa diaktifkan kembali"
+	}
+	return c.JSON(fiber.Map{"success": true, "message": message})
 }
 
 // ==================================================================
-// SET ADVISOR (ADMIN ONLY)
+// SET ADVISOR & ADVISOR HISTORY
 // ==================================================================
 
 // StudentSetAdvisor godoc
-// @Summary      Set Dosen Wali (Admin)
-// @Description  Admin menetapkan dosen wali untuk mahasiswa tertentu.
+// @Summary      Ganti Dosen Wali (Admin)
+// @Description  Menetapkan dosen wali baru untuk mahasiswa. Dosen harus ada dan masih aktif. Penugasan lama ditutup dan dicatat di riwayat. Bila masih ada pengajuan prestasi (submitted) yang belum ditinjau, pending_reviews wajib dipilih: transfer (ikut pindah ke dosen wali baru) atau keep (tetap ditinjau dosen wali lama).
 // @Tags         Student
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id       path      string             true  "Student ID"
-// @Param        request  body      map[string]string  true  "Body: { advisor_id: 'uuid-dosen' }"
+// @Param        id       path      string                      true  "Student ID"
+// @Param        request  body      model.AdvisorAssignRequest  true  "Dosen wali baru"
 // @Success      200      {object}  map[string]interface{}
 // @Failure      400      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      409      {object}  map[string]interface{}
 // @Failure      500      {object}  map[string]interface{}
 // @Router       /students/{id}/advisor [put]
 func StudentSetAdvisor(c *fiber.Ctx) error {
-	studentID := c.Params("id")
-	var body struct {
-		AdvisorID string `json:"advisor_id"`
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	var req model.AdvisorAssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	}
+
+	student, err := repository.GetStudentByID(c.Params("id"))
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Mahasiswa tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data mahasiswa"})
+	}
+	if student.DeactivatedAt != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Profil mahasiswa sudah nonaktif"})
+	}
+
+	if _, status, msg := checkNewAdvisor(req.AdvisorID); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
+	}
+	if req.AdvisorID == student.AdvisorID {
+		return c.Status(400).JSON(fiber.Map{"error": "Dosen wali baru sama dengan dosen wali saat ini"})
+	}
+
+	opts, msg := parseAdvisorChangeOptions(req.PendingReviews, req.EffectiveDate, req.Reason, claims.UserID)
+	if msg != "" {
+		return c.Status(400).JSON(fiber.Map{"error": msg})
+	}
+
+	pending := 0
+	if student.AdvisorID != "" {
+		pending, err = repository.CountPendingReviews([]string{student.ID})
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal menghitung pengajuan yang belum ditinjau"})
+		}
+		if pending > 0 && opts.pendingReviews == "" {
+			return pendingReviewsConflict(c, pending)
+		}
+	}
+
+	assignment := opts.assignment(student.ID, req.AdvisorID, student.AdvisorID != "")
+	if status, msg := reassignAdvisors([]model.AdvisorAssignment{assignment}, ""); msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
 	}
 
-	if err := c.BodyParser(&body); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Input invalid"})
+	return c.JSON(fiber.Map{
+		"success":         true,
+		"message":         "Dosen wali berhasil diganti",
+		"data":            assignment,
+		"pending_reviews": pending,
+	})
+}
+
+// StudentAdvisorHistory godoc
+// @Summary      Riwayat Dosen Wali Mahasiswa
+// @Description  Menampilkan riwayat penugasan dosen wali (terbaru lebih dulu) sesuai scope permission student:read. Penugasan yang masih berlaku tidak memiliki effective_to.
+// @Tags         Student
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Student ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /students/{id}/advisors [get]
+func StudentAdvisorHistory(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*model.JWTClaims)
+
+	stud, err := repository.GetStudentByID(c.Params("id"))
+	if err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Mahasiswa tidak ditemukan"})
 	}
 
-	err := repository.SetStudentAdvisor(studentID, body.AdvisorID)
+	ok, err := policy.CanAccessStudent(claims, "read", "student", stud)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengupdate dosen wali"})
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengecek hak akses"})
+	}
+	if !ok {
+		return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses data mahasiswa ini"})
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Advisor updated"})
+	history, err := repository.GetAdvisorAssignments(stud.ID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil riwayat dosen wali"})
+	}
+	if history == nil {
+		history = []model.AdvisorAssignment{}
+	}
+
+	return c.JSON(fiber.Map{"success": true, "data": history})
 }
 
 // ==================================================================
@@ -151,12 +458,22 @@ func StudentSetAdvisor(c *fiber.Ctx) error {
 
 // StudentAchievements godoc
 // @Summary      Lihat Prestasi Mahasiswa Tertentu
-// @Description  Melihat daftar prestasi milik mahasiswa tertentu berdasarkan ID-nya (Admin, Dosen Wali, & Pemilik Akun).
+// @Description  Melihat daftar prestasi milik mahasiswa tertentu berdasarkan ID-nya sesuai scope permission achievement:read. Mendukung paginasi, filter, dan sorting yang sama dengan /achievements.
 // @Tags         Student
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      string  true  "Student ID"
+// @Param        id      path   string  true   "Student ID"
+// @Param        page    query  int     false  "Nomor halaman (default 1)"
+// @Param        limit   query  int     false  "Jumlah per halaman (default 20, maks 100)"
+// @Param        cursor  query  string  false  "next_cursor dari response sebelumnya"
+// @Param        sort    query  string  false  "created_at | updated_at | points | status"
+// @Param        order   query  string  false  "asc | desc"
+// @Param        status  query  string  false  "Daftar status dipisah koma"
+// @Param        type    query  string  false  "Daftar jenis prestasi dipisah koma"
+// @Param        tags    query  string  false  "Daftar tag dipisah koma"
+// @Param        from    query  string  false  "Dibuat sejak (YYYY-MM-DD)"
+// @Param        to      query  string  false  "Dibuat sampai (YYYY-MM-DD)"
 // @Success      200  {object} map[string]interface{}
 // @Failure      403  {object} map[string]interface{}
 // @Failure      404  {object} map[string]interface{}
@@ -164,8 +481,7 @@ func StudentSetAdvisor(c *fiber.Ctx) error {
 // @Router       /students/{id}/achievements [get]
 func StudentAchievements(c *fiber.Ctx) error {
 	studentID := c.Params("id")
-	role := c.Locals("role").(string)
-	userID := c.Locals("userId").(string)
+	claims := c.Locals("user").(*model.JWTClaims)
 
 	// ambil record mahasiswa berdasarkan studentID yang dikirim
 	targetStudent, err := repository.GetStudentByID(studentID)
@@ -173,50 +489,28 @@ func StudentAchievements(c *fiber.Ctx) error {
 		return c.Status(404).JSON(fiber.Map{"error": "Mahasiswa tidak ditemukan"})
 	}
 
-	// 🔐 ROLE VALIDATION
-	switch role {
-	case "Mahasiswa":
-		// mahasiswa hanya boleh akses prestasinya sendiri
-		self, _ := repository.GetStudentByUserID(userID)
-		if self.ID != targetStudent.ID {
-			return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses prestasi mahasiswa lain"})
-		}
-
-	case "Dosen Wali":
-		// dosen hanya boleh akses prestasi mahasiswa bimbingannya
-		lect, _ := repository.GetLecturerByUserID(userID)
-		if targetStudent.AdvisorID != lect.ID {
-			return c.Status(403).JSON(fiber.Map{"error": "Mahasiswa bukan bimbingan Anda"})
-		}
-
-	case "Admin":
-		// admin boleh akses semua → tidak perlu validasi
-	default:
-		return c.Status(403).JSON(fiber.Map{"error": "Role tidak dikenali"})
+	// 🔐 POLICY VALIDATION
+	ok, err := policy.CanAccessStudent(claims, "read", "achievement", targetStudent)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengecek hak akses"})
+	}
+	if !ok {
+		return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses prestasi mahasiswa ini"})
 	}
 
 	// ambil achievement reference milik mahasiswa
-	refs, err := repository.GetAchievementReferencesByStudentID(targetStudent.ID)
-	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil reference achievement"})
+	q := parseListQuery(c)
+	filter, status, msg := achievementFilterFromQuery(c, policy.Can(claims, "read", "achievement") == policy.ScopeAll)
+	if msg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": msg})
 	}
+	filter.StudentID = targetStudent.ID
 
-	// join ke Mongo
-	var results []fiber.Map
-	for _, ref := range refs {
-		doc, err := repository.GetAchievementByID(ref.MongoAchievementID)
-		if err != nil {
-			continue
-		}
-		results = append(results, fiber.Map{
-			"reference":   ref,
-			"achievement": doc,
-		})
+	refs, info, err := repository.ListAchievementReferences(filter, q)
+	if err != nil {
+		return listError(c, err, "Gagal mengambil reference achievement")
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"count":   len(results),
-		"data":    results,
-	})
+	// join ke Mongo
+	return buildAchievementResponse(c, refs, info)
 }
\ No newline at end of file
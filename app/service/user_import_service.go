@@ -0,0 +1,828 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/mail"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/utils"
+)
+
+// MaxUserImportRows membatasi jumlah baris data per file impor
+const MaxUserImportRows = 5000
+
+// MaxUserImportBytes membatasi ukuran file impor yang diterima endpoint
+const MaxUserImportBytes = 10 << 20
+
+// UserImportRejection adalah penolakan impor beserta status HTTP-nya
+// (400 file tidak valid, 409 bentrok data, 422 ada baris tidak valid)
+type UserImportRejection struct {
+	Status  int
+	Message string
+}
+
+func (e *UserImportRejection) Error() string {
+	return e.Message
+}
+
+// ==================================================================
+// PARSE FILE
+// ==================================================================
+
+// importColumn adalah kolom file impor; key sama dengan nama field pada FieldError
+type importColumn struct {
+	key      string
+	aliases  []string
+	required bool
+}
+
+var importColumns = map[string][]importColumn{
+	model.ImportKindStudents: {
+		{"student_id", []string{"nim", "student_id"}, true},
+		{"full_name", []string{"nama", "name", "full_name", "nama_lengkap"}, true},
+		{"email", []string{"email", "e_mail"}, true},
+		{"program_study", []string{"program_study", "program_studi", "prodi"}, true},
+		{"academic_year", []string{"academic_year", "angkatan", "tahun_akademik"}, true},
+		{"advisor_nidn", []string{"advisor_nidn", "nidn_wali", "nidn_dosen_wali", "dosen_wali"}, false},
+	},
+	model.ImportKindLecturers: {
+		{"lecturer_id", []string{"nidn", "lecturer_id"}, true},
+		{"full_name", []string{"nama", "name", "full_name", "nama_lengkap"}, true},
+		{"email", []string{"email", "e_mail"}, true},
+		{"department", []string{"department", "departemen", "program_study", "program_studi", "prodi"}, true},
+	},
+}
+
+// numberField adalah nama field NIM / NIDN untuk jenis impor
+func numberField(kind string) string {
+	if kind == model.ImportKindLecturers {
+		return "lecturer_id"
+	}
+	return "student_id"
+}
+
+// normalizeHeader menyamakan judul kolom: "Program Studi" → "program_studi"
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.TrimSpace(h))
+	h = strings.NewReplacer(" ", "_", "-", "_", ".", "").Replace(h)
+	return h
+}
+
+func blankRecord(rec []string) bool {
+	for _, v := range rec {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseUserImport membaca file CSV/XLSX menjadi baris impor. Baris pertama
+// yang tidak kosong dianggap header; urutan kolom bebas dan kolom lain diabaikan.
+func ParseUserImport(kind, fileName string, data []byte) ([]model.UserImportRow, error) {
+	cols, ok := importColumns[kind]
+	if !ok {
+		return nil, &UserImportRejection{Status: 400, Message: "Jenis impor tidak dikenal: " + kind}
+	}
+
+	records, err := utils.ReadSpreadsheet(fileName, data)
+	if err != nil {
+		return nil, &UserImportRejection{Status: 400, Message: "File tidak dapat dibaca: " + err.Error()}
+	}
+
+	headerIdx := slices.IndexFunc(records, func(rec []string) bool { return !blankRecord(rec) })
+	if headerIdx < 0 {
+		return nil, &UserImportRejection{Status: 400, Message: "File kosong"}
+	}
+
+	index := map[string]int{}
+	for i, h := range records[headerIdx] {
+		name := normalizeHeader(h)
+		for _, col := range cols {
+			if _, taken := index[col.key]; !taken && slices.Contains(col.aliases, name) {
+				index[col.key] = i
+			}
+		}
+	}
+	var missing []string
+	for _, col := range cols {
+		if _, found := index[col.key]; col.required && !found {
+			missing = append(missing, col.aliases[0])
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &UserImportRejection{Status: 400, Message: "Kolom wajib tidak ditemukan: " + strings.Join(missing, ", ")}
+	}
+
+	rows := []model.UserImportRow{}
+	for i := headerIdx + 1; i < len(records); i++ {
+		rec := records[i]
+		if blankRecord(rec) {
+			continue
+		}
+		if len(rows) >= MaxUserImportRows {
+			return nil, &UserImportRejection{Status: 400, Message: fmt.Sprintf("Maksimal %d baris per file", MaxUserImportRows)}
+		}
+
+		get := func(key string) string {
+			j, ok := index[key]
+			if !ok || j >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[j])
+		}
+		rows = append(rows, model.UserImportRow{
+			Row:          i + 1,
+			Number:       get(numberField(kind)),
+			FullName:     get("full_name"),
+			Email:        get("email"),
+			ProgramStudy: get("program_study"),
+			AcademicYear: get("academic_year"),
+			AdvisorNIDN:  get("advisor_nidn"),
+			Department:   get("department"),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, &UserImportRejection{Status: 400, Message: "File tidak berisi data"}
+	}
+	return rows, nil
+}
+
+// ==================================================================
+// VALIDATE & PLAN
+// ==================================================================
+
+// plannedImport adalah perubahan untuk baris report.Rows[row]
+type plannedImport struct {
+	row int
+	op  model.UserImportOp
+}
+
+func addImportError(r *model.UserImportRow, field, message string) {
+	r.Errors = append(r.Errors, model.FieldError{Field: field, Message: message})
+}
+
+// validateImportRow memeriksa isi satu baris tanpa melihat data yang sudah ada
+func validateImportRow(kind string, r *model.UserImportRow) error {
+	if r.FullName == "" {
+		addImportError(r, "full_name", "wajib diisi")
+	}
+	if r.Email == "" {
+		addImportError(r, "email", "wajib diisi")
+	} else if addr, err := mail.ParseAddress(r.Email); err != nil || addr.Address != r.Email {
+		addImportError(r, "email", "format email tidak valid")
+	}
+
+	if kind == model.ImportKindLecturers {
+		r.Errors = append(r.Errors, ValidateLecturerProfile(&model.LecturerProfileRequest{
+			LecturerID: r.Number,
+			Department: r.Department,
+		})...)
+		return nil
+	}
+
+	// dosen wali dicek terpisah lewat NIDN (batch), bukan ID
+	fieldErrs, err := ValidateStudentProfile(&model.StudentProfileRequest{
+		StudentID:    r.Number,
+		ProgramStudy: r.ProgramStudy,
+		AcademicYear: r.AcademicYear,
+	})
+	if err != nil {
+		return err
+	}
+	r.Errors = append(r.Errors, fieldErrs...)
+	return nil
+}
+
+// existingProfile adalah profil mahasiswa/dosen yang sudah ada untuk satu NIM/NIDN
+type existingProfile struct {
+	student  *model.Student
+	lecturer *model.Lecturer
+}
+
+func (p existingProfile) userID() string {
+	if p.student != nil {
+		return p.student.UserID
+	}
+	return p.lecturer.UserID
+}
+
+// planUserImport memvalidasi seluruh baris dan mencocokkannya dengan data yang
+// sudah ada (upsert berdasarkan NIM/NIDN). Baris tidak valid diberi action
+// "error"; error non-nil hanya untuk kegagalan database.
+func planUserImport(kind string, role *model.Role, rows []model.UserImportRow) ([]plannedImport, error) {
+	numField := numberField(kind)
+
+	// validasi isi & duplikat di dalam file
+	seenNumber, seenEmail := map[string]int{}, map[string]int{}
+	var numbers, emails, advisorNIDNs []string
+	for i := range rows {
+		r := &rows[i]
+		if err := validateImportRow(kind, r); err != nil {
+			return nil, err
+		}
+		if r.Number != "" {
+			if first, dup := seenNumber[r.Number]; dup {
+				addImportError(r, numField, fmt.Sprintf("duplikat dengan baris %d", first))
+			} else {
+				seenNumber[r.Number] = r.Row
+				numbers = append(numbers, r.Number)
+			}
+		}
+		if email := strings.ToLower(r.Email); email != "" {
+			if first, dup := seenEmail[email]; dup {
+				addImportError(r, "email", fmt.Sprintf("duplikat dengan baris %d", first))
+			} else {
+				seenEmail[email] = r.Row
+				emails = append(emails, email)
+			}
+		}
+		if kind == model.ImportKindStudents && r.AdvisorNIDN != "" {
+			advisorNIDNs = append(advisorNIDNs, r.AdvisorNIDN)
+		}
+	}
+
+	// data yang sudah ada, diambil sekaligus
+	profiles := map[string]existingProfile{}
+	var userIDs []string
+	if kind == model.ImportKindStudents {
+		list, err := repository.GetStudentsByStudentIDs(numbers)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list {
+			profiles[list[i].StudentID] = existingProfile{student: &list[i]}
+			userIDs = append(userIDs, list[i].UserID)
+		}
+	} else {
+		list, err := repository.GetLecturersByLecturerIDs(numbers)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list {
+			profiles[list[i].LecturerID] = existingProfile{lecturer: &list[i]}
+			userIDs = append(userIDs, list[i].UserID)
+		}
+	}
+
+	advisors := map[string]*model.Lecturer{}
+	if len(advisorNIDNs) > 0 {
+		list, err := repository.GetLecturersByLecturerIDs(advisorNIDNs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list {
+			advisors[list[i].LecturerID] = &list[i]
+		}
+	}
+
+	users, err := repository.GetUsersByIdentity(userIDs, numbers, emails)
+	if err != nil {
+		return nil, err
+	}
+	byID, byUsername, byEmail := map[string]*model.User{}, map[string]*model.User{}, map[string]*model.User{}
+	for i := range users {
+		u := &users[i]
+		byID[u.ID] = u
+		byUsername[u.Username] = u
+		byEmail[strings.ToLower(u.Email)] = u
+	}
+
+	var plan []plannedImport
+	for i := range rows {
+		r := &rows[i]
+		if len(r.Errors) > 0 {
+			continue
+		}
+		p, err := planImportRow(kind, role, r, profiles, advisors, byID, byUsername, byEmail)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			p.row = i
+			plan = append(plan, *p)
+		}
+	}
+
+	for i := range rows {
+		if len(rows[i].Errors) > 0 {
+			rows[i].Action = model.ImportActionError
+		}
+	}
+	return plan, nil
+}
+
+// planImportRow menentukan create / update / unchanged untuk satu baris valid
+func planImportRow(
+	kind string,
+	role *model.Role,
+	r *model.UserImportRow,
+	profiles map[string]existingProfile,
+	advisors map[string]*model.Lecturer,
+	byID, byUsername, byEmail map[string]*model.User,
+) (*plannedImport, error) {
+	numField := numberField(kind)
+	profile, found := profiles[r.Number]
+
+	var user model.User
+	newUser := false
+	switch u, ok := byUsername[r.Number]; {
+	case found:
+		owner, ok := byID[profile.userID()]
+		if !ok {
+			addImportError(r, numField, "user pemilik profil tidak ditemukan")
+			return nil, nil
+		}
+		user = *owner
+
+	case ok:
+		// user sudah dibuat manual dengan username = NIM/NIDN tapi belum berprofil
+		if u.RoleID != role.ID {
+			addImportError(r, numField, "username sudah dipakai user dengan role lain")
+			return nil, nil
+		}
+		hasProfile, err := userHasProfile(kind, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		if hasProfile {
+			addImportError(r, numField, "user "+u.Username+" sudah memiliki profil dengan nomor lain")
+			return nil, nil
+		}
+		user = *u
+
+	default:
+		newUser = true
+		user = model.User{ID: uuid.NewString(), Username: r.Number, RoleID: role.ID, IsActive: true}
+	}
+
+	if other, ok := byEmail[strings.ToLower(r.Email)]; ok && other.ID != user.ID {
+		addImportError(r, "email", "sudah dipakai user lain")
+	}
+
+	advisorID := ""
+	if r.AdvisorNIDN != "" {
+		lect, ok := advisors[r.AdvisorNIDN]
+		switch {
+		case !ok:
+			addImportError(r, "advisor_nidn", "dosen wali tidak ditemukan")
+		case lect.DeactivatedAt != nil:
+			addImportError(r, "advisor_nidn", "dosen wali sudah nonaktif")
+		default:
+			advisorID = lect.ID
+		}
+	}
+	if len(r.Errors) > 0 {
+		return nil, nil
+	}
+
+	changed := newUser || !found || user.FullName != r.FullName || !strings.EqualFold(user.Email, r.Email)
+	user.FullName = r.FullName
+	if !strings.EqualFold(user.Email, r.Email) {
+		user.Email = r.Email
+	}
+	op := model.UserImportOp{User: user, NewUser: newUser, NewProfile: !found}
+
+	if kind == model.ImportKindStudents {
+		if found {
+			s := *profile.student
+			changed = changed || s.ProgramStudy != r.ProgramStudy || s.AcademicYear != r.AcademicYear ||
+				(advisorID != "" && s.AdvisorID != advisorID)
+			s.ProgramStudy, s.AcademicYear = r.ProgramStudy, r.AcademicYear
+			// kolom dosen wali kosong berarti dosen wali lama dipertahankan.
+			// Penggantian dicatat di riwayat; pengajuan yang belum ditinjau
+			// tetap pada dosen wali lama (keep) agar impor tidak memindahkan antrean review.
+			if advisorID != "" && s.AdvisorID != advisorID {
+				reason, pending := "impor massal", model.PendingReviewsKeep
+				op.Advisor = &model.AdvisorAssignment{
+					ID:             uuid.NewString(),
+					StudentID:      s.ID,
+					LecturerID:     advisorID,
+					EffectiveFrom:  time.Now(),
+					Reason:         &reason,
+					PendingReviews: &pending,
+				}
+				s.AdvisorID = advisorID
+			}
+			op.Student = &s
+		} else {
+			op.Student = newStudentProfile(user.ID, model.StudentProfileRequest{
+				StudentID:    r.Number,
+				ProgramStudy: r.ProgramStudy,
+				AcademicYear: r.AcademicYear,
+				AdvisorID:    advisorID,
+			})
+		}
+	} else {
+		if found {
+			l := *profile.lecturer
+			changed = changed || l.Department != r.Department
+			l.Department = r.Department
+			op.Lecturer = &l
+		} else {
+			op.Lecturer = newLecturerProfile(user.ID, model.LecturerProfileRequest{
+				LecturerID: r.Number,
+				Department: r.Department,
+			})
+		}
+	}
+
+	r.Username = user.Username
+	switch {
+	case newUser:
+		r.Action = model.ImportActionCreate
+	case changed:
+		r.Action = model.ImportActionUpdate
+	default:
+		r.Action = model.ImportActionUnchanged
+		return nil, nil
+	}
+	return &plannedImport{op: op}, nil
+}
+
+func userHasProfile(kind, userID string) (bool, error) {
+	var err error
+	if kind == model.ImportKindStudents {
+		_, err = repository.GetStudentByUserID(userID)
+	} else {
+		_, err = repository.GetLecturerByUserID(userID)
+	}
+	if repository.IsNoRows(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ==================================================================
+// RUN (DRY-RUN / COMMIT)
+// ==================================================================
+
+// importProfile memetakan jenis impor ke jenis profil di policy
+func importProfile(kind string) string {
+	if kind == model.ImportKindLecturers {
+		return policy.ProfileLecturer
+	}
+	return policy.ProfileStudent
+}
+
+// resolveImportRole menentukan role user baru hasil impor. role_id yang diisi
+// harus ada dan boleh memiliki profil sesuai jenis impor (policy.CanHoldProfile).
+// Tanpa role_id, dipakai satu-satunya role yang memenuhi syarat tersebut.
+func resolveImportRole(kind, roleID string) (*model.Role, error) {
+	profile := importProfile(kind)
+
+	if roleID != "" {
+		role, err := repository.GetRoleByID(roleID)
+		if err != nil {
+			if repository.IsNoRows(err) {
+				return nil, &UserImportRejection{Status: http.StatusBadRequest, Message: "Role tidak ditemukan"}
+			}
+			return nil, err
+		}
+		allowed, err := roleCanHoldProfile(role.ID, profile)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, &UserImportRejection{
+				Status:  http.StatusBadRequest,
+				Message: "Role " + role.Name + " tidak dapat memiliki " + profileLabel(profile),
+			}
+		}
+		return role, nil
+	}
+
+	roles, err := repository.GetAllRoles()
+	if err != nil {
+		return nil, err
+	}
+	var eligible []model.Role
+	for _, r := range roles {
+		allowed, err := roleCanHoldProfile(r.ID, profile)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			eligible = append(eligible, r)
+		}
+	}
+	switch len(eligible) {
+	case 1:
+		return &eligible[0], nil
+	case 0:
+		return nil, &UserImportRejection{
+			Status:  http.StatusBadRequest,
+			Message: "Tidak ada role yang dapat memiliki " + profileLabel(profile),
+		}
+	}
+	return nil, &UserImportRejection{
+		Status:  http.StatusBadRequest,
+		Message: fmt.Sprintf("Ada %d role yang dapat memiliki %s, pilih salah satu lewat role_id", len(eligible), profileLabel(profile)),
+	}
+}
+
+// RunUserImport mem-parse file, memvalidasi setiap baris, lalu (bila bukan
+// dry-run) menulis semua perubahan dalam satu transaksi. Commit ditolak (422)
+// bila ada satu saja baris tidak valid; report tetap dikembalikan bersama error.
+// Password awal user baru hanya muncul di report hasil commit. roleID boleh
+// kosong, lihat resolveImportRole.
+func RunUserImport(kind, roleID, fileName string, data []byte, dryRun bool) (*model.UserImportReport, error) {
+	report := &model.UserImportReport{
+		Kind:      kind,
+		FileName:  fileName,
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+
+	role, err := resolveImportRole(kind, roleID)
+	if err != nil {
+		return nil, err
+	}
+	report.RoleID, report.RoleName = role.ID, role.Name
+
+	rows, err := ParseUserImport(kind, fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	report.Rows = rows
+
+	plan, err := planUserImport(kind, role, report.Rows)
+	if err != nil {
+		return nil, err
+	}
+	report.Summary = summarizeUserImport(report.Rows)
+
+	if !dryRun {
+		if report.Summary.Failed > 0 {
+			err = &UserImportRejection{
+				Status:  http.StatusUnprocessableEntity,
+				Message: fmt.Sprintf("Impor dibatalkan: %d baris tidak valid", report.Summary.Failed),
+			}
+		} else if err = commitUserImport(report, plan); err == nil {
+			report.Committed = true
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	if err != nil {
+		var rejected *UserImportRejection
+		if errors.As(err, &rejected) {
+			return report, err
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func summarizeUserImport(rows []model.UserImportRow) model.UserImportSummary {
+	s := model.UserImportSummary{Total: len(rows)}
+	for _, r := range rows {
+		switch r.Action {
+		case model.ImportActionCreate:
+			s.Created++
+		case model.ImportActionUpdate:
+			s.Updated++
+		case model.ImportActionUnchanged:
+			s.Unchanged++
+		default:
+			s.Failed++
+		}
+	}
+	return s
+}
+
+// commitUserImport membuat password awal untuk user baru lalu menulis semua
+// perubahan lewat repository.ApplyUserImport (satu transaksi)
+func commitUserImport(report *model.UserImportReport, plan []plannedImport) error {
+	if len(plan) == 0 {
+		return nil
+	}
+
+	var created []int
+	var passwords []string
+	for i, p := range plan {
+		if !p.op.NewUser {
+			continue
+		}
+		pw, err := generateInitialPassword()
+		if err != nil {
+			return err
+		}
+		created = append(created, i)
+		passwords = append(passwords, pw)
+	}
+
+	hashes, err := hashPasswords(passwords)
+	if err != nil {
+		return err
+	}
+	for j, i := range created {
+		plan[i].op.User.PasswordHash = hashes[j]
+	}
+
+	ops := make([]model.UserImportOp, len(plan))
+	for i, p := range plan {
+		ops[i] = p.op
+	}
+	if err := repository.ApplyUserImport(ops); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return &UserImportRejection{Status: 409, Message: "Impor dibatalkan: NIM/NIDN, username atau email bentrok dengan data yang baru berubah, ulangi dry-run"}
+		}
+		return err
+	}
+
+	for j, i := range created {
+		report.Rows[plan[i].row].InitialPassword = passwords[j]
+	}
+	return nil
+}
+
+// hashPasswords menjalankan bcrypt paralel sebanyak jumlah CPU; dengan cost 14
+// (±1 detik per password) impor ratusan user tidak lagi memakan waktu menit-an
+func hashPasswords(plain []string) ([]string, error) {
+	hashed := make([]string, len(plain))
+	errs := make([]error, len(plain))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				hashed[i], errs[i] = utils.HashPassword(plain[i])
+			}
+		}()
+	}
+	for i := range plain {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return hashed, errors.Join(errs...)
+}
+
+// karakter yang mudah tertukar (0/O, 1/l/I) tidak dipakai
+const initialPasswordChars = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789"
+
+func generateInitialPassword() (string, error) {
+	const length = 12
+	max := big.NewInt(int64(len(initialPasswordChars)))
+
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = initialPasswordChars[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// ==================================================================
+// REPORT CSV
+// ==================================================================
+
+// WriteUserImportCSV menulis report sebagai CSV untuk diunduh (termasuk password awal)
+func WriteUserImportCSV(w io.Writer, report *model.UserImportReport) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"row", "action", "nim", "full_name", "email", "program_study", "academic_year", "advisor_nidn"}
+	if report.Kind == model.ImportKindLecturers {
+		header = []string{"row", "action", "nidn", "full_name", "email", "department"}
+	}
+	header = append(header, "username", "initial_password", "errors")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range report.Rows {
+		record := []string{strconv.Itoa(r.Row), r.Action, r.Number, r.FullName, r.Email}
+		if report.Kind == model.ImportKindLecturers {
+			record = append(record, r.Department)
+		} else {
+			record = append(record, r.ProgramStudy, r.AcademicYear, r.AdvisorNIDN)
+		}
+
+		msgs := make([]string, len(r.Errors))
+		for i, e := range r.Errors {
+			msgs[i] = e.Field + ": " + e.Message
+		}
+		record = append(record, r.Username, r.InitialPassword, strings.Join(msgs, "; "))
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// UserImportReportFileName adalah nama file unduhan report, mis. import-students-20250801-093000.csv
+func UserImportReportFileName(report *model.UserImportReport) string {
+	return "import-" + report.Kind + "-" + report.StartedAt.Format("20060102-150405") + ".csv"
+}
+
+// ==================================================================
+// IMPORT ENDPOINT (ADMIN ONLY)
+// ==================================================================
+
+// UserImport godoc
+// @Summary      Impor Massal Mahasiswa / Dosen (Admin)
+// @Description  Mengimpor user beserta profil mahasiswa (kolom nim, nama, email, program_studi, angkatan, nidn_wali) atau dosen (kolom nidn, nama, email, departemen) dari file CSV atau XLSX. Baris dicocokkan berdasarkan NIM/NIDN: yang belum ada dibuat (username = NIM/NIDN, password awal acak, role dari role_id atau satu-satunya role yang permission-nya boleh memiliki profil tersebut), yang sudah ada diperbarui (penggantian dosen wali dicatat di riwayat, pengajuan yang belum ditinjau tetap pada dosen wali lama). Default dry-run: hanya validasi per baris tanpa menyimpan. Dengan dry_run=false semua baris disimpan dalam satu transaksi, dan ditolak (422) bila ada baris tidak valid. Password awal hanya ditampilkan sekali pada hasil commit. format=csv mengembalikan report sebagai file unduhan.
+// @Tags         User Management
+// @Accept       multipart/form-data
+// @Produce      json,text/csv
+// @Security     BearerAuth
+// @Param        kind     path      string  true   "students | lecturers"
+// @Param        file     formData  file    true   "File .csv atau .xlsx (sheet pertama)"
+// @Param        role_id  formData  string  false  "Role untuk user baru; default satu-satunya role yang boleh memiliki profil tersebut"
+// @Param        dry_run  query     bool    false  "Hanya validasi, tidak menyimpan (default true)"
+// @Param        format   query     string  false  "json | csv"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      413  {object}  map[string]interface{}
+// @Failure      422  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /imports/{kind} [post]
+func UserImport(c *fiber.Ctx) error {
+	kind := c.Params("kind")
+	if _, ok := importColumns[kind]; !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "Jenis impor tidak dikenal, gunakan students atau lecturers"})
+	}
+	dryRun := c.QueryBool("dry_run", true)
+
+	f, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "File tidak ditemukan"})
+	}
+	if f.Size > MaxUserImportBytes {
+		return c.Status(413).JSON(fiber.Map{"error": "Ukuran file melebihi batas " + formatMB(MaxUserImportBytes)})
+	}
+	src, err := f.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "File tidak dapat dibaca"})
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "File tidak dapat dibaca"})
+	}
+
+	report, err := RunUserImport(kind, c.FormValue("role_id"), f.Filename, data, dryRun)
+	status, message := 200, ""
+	if err != nil {
+		var rejected *UserImportRejection
+		if !errors.As(err, &rejected) {
+			return c.Status(500).JSON(fiber.Map{"error": "Gagal menjalankan impor"})
+		}
+		if report == nil {
+			return c.Status(rejected.Status).JSON(fiber.Map{"error": rejected.Message})
+		}
+		status, message = rejected.Status, rejected.Message
+	}
+
+	// report hasil commit memuat password awal
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	if c.Query("format") == "csv" {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+UserImportReportFileName(report)+`"`)
+		c.Status(status)
+		return WriteUserImportCSV(c, report)
+	}
+
+	if message != "" {
+		return c.Status(status).JSON(fiber.Map{"error": message, "data": report})
+	}
+	message = "Dry-run selesai, tidak ada data yang disimpan"
+	if report.Committed {
+		message = "Impor berhasil disimpan"
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": message,
+		"data":    report,
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
 	"prestasi_backend/app/repository"
 	"prestasi_backend/utils"
 
@@ -15,24 +16,47 @@ import (
 
 // UserList godoc
 // @Summary      Lihat Semua User
-// @Description  Menampilkan daftar seluruh user di sistem (Biasanya Admin Only).
+// @Description  Menampilkan daftar user di sistem (Biasanya Admin Only) dengan paginasi, filter, dan sorting.
 // @Tags         User Management
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
+// @Param        page       query  int     false  "Nomor halaman (default 1)"
+// @Param        limit      query  int     false  "Jumlah per halaman (default 20, maks 100)"
+// @Param        cursor     query  string  false  "next_cursor dari response sebelumnya"
+// @Param        sort       query  string  false  "created_at | username | full_name"
+// @Param        order      query  string  false  "asc | desc"
+// @Param        role_id    query  string  false  "Filter role"
+// @Param        is_active  query  bool    false  "Filter status aktif"
+// @Param        search     query  string  false  "Cari username, email, atau nama"
 // @Success      200  {object} map[string]interface{}
+// @Failure      400  {object} map[string]interface{}
 // @Failure      500  {object} map[string]interface{}
 // @Router       /users [get]
 func UserList(c *fiber.Ctx) error {
+	q := parseListQuery(c)
+
+	filter := model.UserFilter{
+		RoleID: c.Query("role_id"),
+		Search: c.Query("search"),
+	}
+	if raw := c.Query("is_active"); raw != "" {
+		active := c.QueryBool("is_active")
+		filter.IsActive = &active
+	}
 
-	users, err := repository.GetAllUsers()
+	users, info, err := repository.ListUsers(filter, q)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data user"})
+		return listError(c, err, "Gagal mengambil data user")
+	}
+	if users == nil {
+		users = []model.User{}
 	}
 
 	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    users,
+		"success":    true,
+		"data":       users,
+		"pagination": info,
 	})
 }
 
@@ -75,7 +99,7 @@ func UserDetail(c *fiber.Ctx) error {
 
 // UserCreate godoc
 // @Summary      Buat User Baru
-// @Description  Menambahkan user baru secara manual (Admin). Password akan otomatis di-hash.
+// @Description  Menambahkan user baru secara manual (Admin). Password akan otomatis di-hash. Profil mahasiswa (student, untuk role dengan permission achievement:create scope own) atau dosen (lecturer, untuk role dengan permission achievement:verify) dapat dibuat sekaligus dalam transaksi yang sama.
 // @Tags         User Management
 // @Accept       json
 // @Produce      json
@@ -83,6 +107,7 @@ func UserDetail(c *fiber.Ctx) error {
 // @Param        request body model.UserCreateRequest true "Data User Baru"
 // @Success      200  {object} map[string]interface{}
 // @Failure      400  {object} map[string]interface{}
+// @Failure      409  {object} map[string]interface{}
 // @Failure      500  {object} map[string]interface{}
 // @Router       /users [post]
 func UserCreate(c *fiber.Ctx) error {
@@ -92,6 +117,12 @@ func UserCreate(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
 	}
 
+	// profil opsional harus sesuai role user
+	student, lecturer, status, body := userProfileFromRequest(&req)
+	if body != nil {
+		return c.Status(status).JSON(body)
+	}
+
 	// hash password
 	hashed, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -108,15 +139,127 @@ func UserCreate(c *fiber.Ctx) error {
 		IsActive:     req.IsActive,
 	}
 
-	if err := repository.CreateUser(&user); err != nil {
+	if student != nil {
+		student.UserID = user.ID
+	}
+	if lecturer != nil {
+		lecturer.UserID = user.ID
+	}
+
+	if err := repository.CreateUserWithProfile(&user, student, lecturer); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return c.Status(409).JSON(fiber.Map{"error": "Username, email, NIM atau NIDN sudah dipakai"})
+		}
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat user"})
 	}
 
-	return c.JSON(fiber.Map{
+	resp := fiber.Map{
 		"success": true,
 		"message": "User berhasil dibuat",
 		"data":    user,
-	})
+	}
+	if student != nil {
+		resp["student"] = student
+	}
+	if lecturer != nil {
+		resp["lecturer"] = lecturer
+	}
+	return c.JSON(resp)
+}
+
+// userProfileFromRequest memvalidasi profil opsional pada UserCreateRequest
+// terhadap role yang dipilih. Bila gagal, body berisi response error; field
+// error diberi awalan "student." / "lecturer.".
+func userProfileFromRequest(req *model.UserCreateRequest) (*model.Student, *model.Lecturer, int, fiber.Map) {
+	if req.Student == nil && req.Lecturer == nil {
+		return nil, nil, 0, nil
+	}
+	if req.Student != nil && req.Lecturer != nil {
+		return nil, nil, 400, fiber.Map{"error": "Pilih salah satu profil: student atau lecturer"}
+	}
+
+	role, err := repository.GetRoleByID(req.RoleID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return nil, nil, 400, fiber.Map{"error": "Role tidak ditemukan"}
+		}
+		return nil, nil, 500, fiber.Map{"error": "Gagal mengambil role"}
+	}
+
+	profile := policy.ProfileLecturer
+	if req.Student != nil {
+		profile = policy.ProfileStudent
+	}
+	allowed, err := roleCanHoldProfile(role.ID, profile)
+	if err != nil {
+		return nil, nil, 500, fiber.Map{"error": "Gagal mengambil permission role"}
+	}
+	if !allowed {
+		return nil, nil, 400, fiber.Map{"error": "Role " + role.Name + " tidak dapat memiliki " + profileLabel(profile)}
+	}
+
+	if req.Student != nil {
+		fieldErrs, err := ValidateStudentProfile(req.Student)
+		if err != nil {
+			return nil, nil, 500, fiber.Map{"error": "Gagal memvalidasi profil mahasiswa"}
+		}
+		if len(fieldErrs) > 0 {
+			return nil, nil, 400, fiber.Map{"error": "Validasi gagal", "fields": prefixFieldErrors("student.", fieldErrs)}
+		}
+		return newStudentProfile("", *req.Student), nil, 0, nil
+	}
+
+	if fieldErrs := ValidateLecturerProfile(req.Lecturer); len(fieldErrs) > 0 {
+		return nil, nil, 400, fiber.Map{"error": "Validasi gagal", "fields": prefixFieldErrors("lecturer.", fieldErrs)}
+	}
+	return nil, newLecturerProfile("", *req.Lecturer), 0, nil
+}
+
+// roleCanHoldProfile mengecek kelayakan profil dari permission role (lihat policy.CanHoldProfile)
+func roleCanHoldProfile(roleID, profile string) (bool, error) {
+	perms, err := repository.GetPermissionsByRoleID(roleID)
+	if err != nil {
+		return false, err
+	}
+	return policy.CanHoldProfile(perms, profile), nil
+}
+
+func profileLabel(profile string) string {
+	if profile == policy.ProfileStudent {
+		return "profil mahasiswa"
+	}
+	return "profil dosen"
+}
+
+func prefixFieldErrors(prefix string, errs []model.FieldError) []model.FieldError {
+	for i := range errs {
+		errs[i].Field = prefix + errs[i].Field
+	}
+	return errs
+}
+
+// checkProfileUser memastikan user ada dan role-nya boleh memiliki profil yang akan dibuat
+func checkProfileUser(userID, profile string) (int, string) {
+	if userID == "" {
+		return 400, "user_id wajib diisi"
+	}
+
+	user, err := repository.GetUserByID(userID)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return 400, "User tidak ditemukan"
+		}
+		return 500, "Gagal mengambil user"
+	}
+
+	allowed, err := roleCanHoldProfile(user.RoleID, profile)
+	if err != nil {
+		return 500, "Gagal mengambil permission role user"
+	}
+	if !allowed {
+		return 400, "Role user tidak dapat memiliki " + profileLabel(profile)
+	}
+	return 0, ""
 }
 
 // ==================================================================
@@ -125,7 +268,7 @@ func UserCreate(c *fiber.Ctx) error {
 
 // UserUpdate godoc
 // @Summary      Update Data User
-// @Description  Mengubah data user (Username, Email, Nama, Password, Status Aktif).
+// @Description  Mengubah data user (Username, Email, Nama, Password, Status Aktif). Menonaktifkan akun atau mengganti password mencabut semua sesi user.
 // @Tags         User Management
 // @Accept       json
 // @Produce      json
@@ -153,6 +296,9 @@ func UserUpdate(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil user"})
 	}
 
+	// sesi lama dicabut jika akun dinonaktifkan atau password diganti
+	revokeSessions := (user.IsActive && !req.IsActive) || req.Password != ""
+
 	// hash password jika dikirim
 	if req.Password != "" {
 		hash, _ := utils.HashPassword(req.Password)
@@ -168,6 +314,15 @@ func UserUpdate(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "Gagal update user"})
 	}
 
+	if revokeSessions {
+		if err := repository.BumpUserTokenVersion(user.ID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "User diupdate, tetapi gagal mencabut sesi lama"})
+		}
+		if err := repository.RevokeRefreshTokensByUser(user.ID); err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": "User diupdate, tetapi gagal mencabut refresh token"})
+		}
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
 		"message": "User berhasil diupdate",
@@ -181,7 +336,7 @@ func UserUpdate(c *fiber.Ctx) error {
 
 // UserDelete godoc
 // @Summary      Hapus User
-// @Description  Menghapus user dari database secara permanen.
+// @Description  Menghapus user dari database secara permanen. Token milik user langsung ditolak.
 // @Tags         User Management
 // @Accept       json
 // @Produce      json
@@ -214,7 +369,7 @@ func UserDelete(c *fiber.Ctx) error {
 
 // UserUpdateRole godoc
 // @Summary      Ganti Role User
-// @Description  Mengubah role/hak akses user (Misal: dari Mahasiswa ke Admin).
+// @Description  Mengubah role/hak akses user (Misal: dari Mahasiswa ke Admin). Access token lama ditolak, user perlu refresh untuk mendapat permission baru.
 // @Tags         User Management
 // @Accept       json
 // @Produce      json
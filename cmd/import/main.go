@@ -0,0 +1,95 @@
+// Command import mengimpor mahasiswa atau dosen dari file CSV/XLSX, sama
+// seperti POST /api/v1/imports/{kind}. Default dry-run: hanya validasi.
+//
+//	go run ./cmd/import -kind students -file mahasiswa.xlsx                          # dry-run
+//	go run ./cmd/import -kind students -file mahasiswa.xlsx -commit -report hasil.csv
+//
+// Tanpa -role, user baru memakai satu-satunya role yang permission-nya boleh
+// memiliki profil tersebut (lihat policy.CanHoldProfile).
+//
+// Report CSV hasil commit memuat password awal user baru dan ditulis dengan
+// permission 0600. Tanpa -report, report JSON ditulis ke stdout.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/config"
+	"prestasi_backend/database"
+)
+
+func main() {
+	kind := flag.String("kind", model.ImportKindStudents, "jenis data: students | lecturers")
+	file := flag.String("file", "", "file .csv atau .xlsx")
+	roleID := flag.String("role", "", "role_id untuk user baru (default: satu-satunya role yang boleh memiliki profil tersebut)")
+	commit := flag.Bool("commit", false, "simpan perubahan (tanpa flag ini hanya dry-run)")
+	reportPath := flag.String("report", "", "tulis report CSV ke path ini")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config.LoadEnv()
+
+	postgresDB, err := database.ConnectPostgre()
+	if err != nil {
+		log.Fatal(err)
+	}
+	database.DB = postgresDB
+
+	report, err := service.RunUserImport(*kind, *roleID, *file, data, !*commit)
+	var rejected *service.UserImportRejection
+	if err != nil && (report == nil || !errors.As(err, &rejected)) {
+		log.Fatal(err)
+	}
+
+	if *reportPath != "" {
+		if werr := writeReport(*reportPath, report); werr != nil {
+			log.Fatal(werr)
+		}
+		fmt.Fprintf(os.Stderr, "report ditulis ke %s\n", *reportPath)
+	} else {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	summary, _ := json.Marshal(report.Summary)
+	fmt.Fprintf(os.Stderr, "%s committed=%v\n", summary, report.Committed)
+
+	// exit code 1 bila ada baris tidak valid atau commit ditolak
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if report.Summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func writeReport(path string, report *model.UserImportReport) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := service.WriteUserImportCSV(f, report); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,60 @@
+// Command reconcile mencari (dan opsional memperbaiki) ketidaksesuaian antara
+// dokumen achievement di MongoDB dan achievement_references di PostgreSQL.
+//
+//	go run ./cmd/reconcile            # laporan saja
+//	go run ./cmd/reconcile -repair    # hapus dokumen yatim & tandai reference tanpa dokumen
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"prestasi_backend/app/service"
+	"prestasi_backend/config"
+	"prestasi_backend/database"
+)
+
+func main() {
+	repair := flag.Bool("repair", false, "perbaiki ketidaksesuaian yang ditemukan")
+	grace := flag.Duration("grace", service.DefaultReconcileGrace, "umur minimal dokumen sebelum dianggap yatim")
+	flag.Parse()
+
+	config.LoadEnv()
+
+	postgresDB, err := database.ConnectPostgre()
+	if err != nil {
+		log.Fatal(err)
+	}
+	database.DB = postgresDB
+
+	mongoDB, err := database.ConnectMongo()
+	if err != nil {
+		log.Fatal(err)
+	}
+	database.MongoDB = mongoDB
+
+	report, err := service.RunReconciliation(*repair, *grace, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatal(err)
+	}
+
+	// exit code 1 bila masih ada masalah yang belum diperbaiki (untuk cron/CI)
+	for _, o := range report.OrphanDocuments {
+		if !o.Repaired {
+			os.Exit(1)
+		}
+	}
+	for _, d := range report.DanglingReferences {
+		if !d.Repaired {
+			os.Exit(1)
+		}
+	}
+}
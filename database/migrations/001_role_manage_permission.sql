@@ -0,0 +1,12 @@
+-- Permission untuk endpoint manajemen RBAC (/roles, /permissions)
+INSERT INTO permissions (id, name, resource, action, description)
+VALUES (gen_random_uuid(), 'role:manage', 'role', 'manage', 'Mengelola role, permission, dan relasinya')
+ON CONFLICT (name) DO NOTHING;
+
+-- Berikan ke Admin
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'role:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,41 @@
+-- Permission ber-scope untuk policy layer (app/policy).
+-- Format nama: resource:action:scope, scope = own | advisees | department | all.
+-- Permission lama tanpa scope tetap berlaku sebagai scope "own".
+INSERT INTO permissions (id, name, resource, action, description) VALUES
+    (gen_random_uuid(), 'achievement:read:own',        'achievement', 'read',   'Melihat prestasi milik sendiri'),
+    (gen_random_uuid(), 'achievement:read:advisees',   'achievement', 'read',   'Melihat prestasi mahasiswa bimbingan'),
+    (gen_random_uuid(), 'achievement:read:department', 'achievement', 'read',   'Melihat prestasi mahasiswa satu program studi'),
+    (gen_random_uuid(), 'achievement:read:all',        'achievement', 'read',   'Melihat seluruh prestasi'),
+    (gen_random_uuid(), 'achievement:update:own',      'achievement', 'update', 'Mengubah prestasi milik sendiri'),
+    (gen_random_uuid(), 'achievement:update:all',      'achievement', 'update', 'Mengubah seluruh prestasi'),
+    (gen_random_uuid(), 'achievement:delete:own',      'achievement', 'delete', 'Menghapus prestasi draft milik sendiri'),
+    (gen_random_uuid(), 'achievement:delete:all',      'achievement', 'delete', 'Menghapus prestasi apa pun'),
+    (gen_random_uuid(), 'student:read:own',            'student',     'read',   'Melihat profil mahasiswa sendiri'),
+    (gen_random_uuid(), 'student:read:advisees',       'student',     'read',   'Melihat mahasiswa bimbingan'),
+    (gen_random_uuid(), 'student:read:department',     'student',     'read',   'Melihat mahasiswa satu program studi'),
+    (gen_random_uuid(), 'student:read:all',            'student',     'read',   'Melihat seluruh mahasiswa'),
+    (gen_random_uuid(), 'lecturer:read:own',           'lecturer',    'read',   'Melihat dosen wali sendiri'),
+    (gen_random_uuid(), 'lecturer:read:department',    'lecturer',    'read',   'Melihat dosen satu departemen'),
+    (gen_random_uuid(), 'lecturer:read:all',           'lecturer',    'read',   'Melihat seluruh dosen'),
+    (gen_random_uuid(), 'report:read:own',             'report',      'read',   'Melihat laporan prestasi sendiri'),
+    (gen_random_uuid(), 'report:read:advisees',        'report',      'read',   'Melihat laporan mahasiswa bimbingan'),
+    (gen_random_uuid(), 'report:read:department',      'report',      'read',   'Melihat laporan satu program studi'),
+    (gen_random_uuid(), 'report:read:all',             'report',      'read',   'Melihat seluruh laporan dan statistik')
+ON CONFLICT (name) DO NOTHING;
+
+-- Pemetaan awal yang meniru perilaku lama berbasis nama role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = ANY (CASE r.name
+    WHEN 'Admin' THEN ARRAY[
+        'achievement:read:all', 'achievement:update:all', 'achievement:delete:all',
+        'student:read:all', 'lecturer:read:all', 'report:read:all']
+    WHEN 'Dosen Wali' THEN ARRAY[
+        'achievement:read:advisees', 'student:read:advisees', 'report:read:advisees']
+    WHEN 'Mahasiswa' THEN ARRAY[
+        'achievement:read:own', 'achievement:update:own', 'achievement:delete:own',
+        'student:read:own', 'lecturer:read:own', 'report:read:own']
+    ELSE ARRAY[]::text[]
+END)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,24 @@
+-- Refresh token opaque (disimpan dalam bentuk hash SHA-256)
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id          UUID PRIMARY KEY,
+    user_id     UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash  VARCHAR(64) NOT NULL UNIQUE,
+    family_id   UUID        NOT NULL,
+    replaced_by UUID,
+    expires_at  TIMESTAMP   NOT NULL,
+    revoked_at  TIMESTAMP,
+    created_at  TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens (user_id);
+
+-- Access token (jti) yang dicabut sebelum kedaluwarsa, misalnya saat logout
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    jti        UUID PRIMARY KEY,
+    user_id    UUID      NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_access_tokens_expires ON revoked_access_tokens (expires_at);
@@ -0,0 +1,4 @@
+-- Versi token per user; dinaikkan saat akun dinonaktifkan, password diganti,
+-- atau role berubah sehingga access token lama langsung ditolak middleware.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS token_version INT NOT NULL DEFAULT 0;
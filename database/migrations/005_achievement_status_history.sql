@@ -0,0 +1,48 @@
+-- Riwayat transisi status prestasi (append-only)
+CREATE TABLE IF NOT EXISTS achievement_status_history (
+    id                 UUID PRIMARY KEY,
+    achievement_ref_id UUID        NOT NULL REFERENCES achievement_references (id) ON DELETE CASCADE,
+    from_status        VARCHAR(20),
+    to_status          VARCHAR(20) NOT NULL,
+    actor_id           UUID,
+    note               TEXT,
+    created_at         TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_status_history_ref
+    ON achievement_status_history (achievement_ref_id, created_at);
+
+-- Tabel riwayat tidak boleh diubah atau dihapus
+CREATE OR REPLACE FUNCTION achievement_status_history_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'achievement_status_history bersifat append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_achievement_status_history_append_only ON achievement_status_history;
+CREATE TRIGGER trg_achievement_status_history_append_only
+    BEFORE UPDATE OR DELETE ON achievement_status_history
+    FOR EACH ROW EXECUTE FUNCTION achievement_status_history_append_only();
+
+-- Backfill dari kolom snapshot untuk data lama
+INSERT INTO achievement_status_history (id, achievement_ref_id, from_status, to_status, actor_id, note, created_at)
+SELECT gen_random_uuid(), ar.id, NULL, 'draft', s.user_id, NULL, ar.created_at
+FROM achievement_references ar
+JOIN students s ON s.id = ar.student_id
+WHERE NOT EXISTS (SELECT 1 FROM achievement_status_history h WHERE h.achievement_ref_id = ar.id);
+
+INSERT INTO achievement_status_history (id, achievement_ref_id, from_status, to_status, actor_id, note, created_at)
+SELECT gen_random_uuid(), ar.id, 'draft', 'submitted', s.user_id, NULL, ar.submitted_at
+FROM achievement_references ar
+JOIN students s ON s.id = ar.student_id
+WHERE ar.submitted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM achievement_status_history h
+                  WHERE h.achievement_ref_id = ar.id AND h.to_status = 'submitted');
+
+INSERT INTO achievement_status_history (id, achievement_ref_id, from_status, to_status, actor_id, note, created_at)
+SELECT gen_random_uuid(), ar.id, 'submitted', ar.status, ar.verified_by, ar.rejection_note, ar.verified_at
+FROM achievement_references ar
+WHERE ar.status IN ('verified', 'rejected')
+  AND ar.verified_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM achievement_status_history h
+                  WHERE h.achievement_ref_id = ar.id AND h.to_status = ar.status);
@@ -0,0 +1,12 @@
+-- Permission untuk mengelola skema jenis prestasi (/achievement-types)
+INSERT INTO permissions (id, name, resource, action, description)
+VALUES (gen_random_uuid(), 'achievement_type:manage', 'achievement_type', 'manage', 'Mengelola skema details per jenis prestasi')
+ON CONFLICT (name) DO NOTHING;
+
+-- Berikan ke Admin
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'achievement_type:manage'
+ON CONFLICT DO NOTHING;
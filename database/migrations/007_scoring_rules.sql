@@ -0,0 +1,54 @@
+-- Aturan perhitungan poin prestasi yang berversi.
+-- Versi lama tidak diubah agar skor historis bisa direproduksi.
+CREATE TABLE IF NOT EXISTS scoring_rules (
+    id           UUID PRIMARY KEY,
+    version      INT NOT NULL UNIQUE,
+    description  TEXT NOT NULL DEFAULT '',
+    rules        JSONB NOT NULL,
+    is_active    BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    activated_at TIMESTAMP
+);
+
+-- Hanya boleh ada satu versi aktif
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scoring_rules_single_active
+    ON scoring_rules (is_active) WHERE is_active;
+
+-- Poin disimpan di reference beserta versi aturan yang menghitungnya
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS points INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS scoring_rule_version INT REFERENCES scoring_rules(version);
+
+-- Versi awal
+INSERT INTO scoring_rules (id, version, description, rules, is_active, activated_at)
+VALUES (gen_random_uuid(), 1, 'Aturan awal', '{
+    "base_points": {
+        "competition": 50, "publication": 60, "certification": 30,
+        "organization": 25, "internship": 30, "default": 20
+    },
+    "level_multipliers": {"international": 3, "national": 2, "regional": 1.5, "campus": 1},
+    "default_level_multiplier": 1,
+    "rank_multipliers": [
+        {"max_rank": 1, "multiplier": 2},
+        {"max_rank": 2, "multiplier": 1.5},
+        {"max_rank": 3, "multiplier": 1.25}
+    ],
+    "default_rank_multiplier": 1,
+    "team_split": "sqrt",
+    "min_points": 0,
+    "max_points": 500
+}', TRUE, NOW())
+ON CONFLICT (version) DO NOTHING;
+
+-- Permission untuk mengelola aturan skor
+INSERT INTO permissions (id, name, resource, action, description)
+VALUES (gen_random_uuid(), 'scoring:manage', 'scoring', 'manage', 'Mengelola, mensimulasikan, dan mengaktifkan aturan skor')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'scoring:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+-- Permission untuk endpoint pemeliharaan (rekonsiliasi MongoDB ↔ PostgreSQL)
+INSERT INTO permissions (id, name, resource, action, description)
+VALUES (gen_random_uuid(), 'maintenance:manage', 'maintenance', 'manage', 'Menjalankan rekonsiliasi dan perbaikan data prestasi')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'maintenance:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,23 @@
+-- Thread komentar per prestasi: komentar biasa, permintaan klarifikasi dari
+-- dosen (tanpa menolak prestasi), balasan mahasiswa, dan catatan penolakan
+CREATE TABLE IF NOT EXISTS achievement_comments (
+    id                 UUID PRIMARY KEY,
+    achievement_ref_id UUID        NOT NULL REFERENCES achievement_references (id) ON DELETE CASCADE,
+    parent_id          UUID        REFERENCES achievement_comments (id) ON DELETE CASCADE,
+    author_id          UUID        NOT NULL REFERENCES users (id),
+    kind               VARCHAR(20) NOT NULL DEFAULT 'comment'
+                       CHECK (kind IN ('comment', 'clarification', 'rejection')),
+    body               TEXT        NOT NULL,
+    attachment_ids     TEXT[]      NOT NULL DEFAULT '{}',
+    resolved_at        TIMESTAMP,
+    resolved_by        UUID REFERENCES users (id),
+    created_at         TIMESTAMP   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_ref
+    ON achievement_comments (achievement_ref_id, created_at);
+
+-- Klarifikasi yang belum dijawab/ditutup, untuk antrian dosen
+CREATE INDEX IF NOT EXISTS idx_achievement_comments_open_clarification
+    ON achievement_comments (achievement_ref_id)
+    WHERE kind = 'clarification' AND resolved_at IS NULL;
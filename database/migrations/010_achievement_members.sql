@@ -0,0 +1,33 @@
+-- Anggota tim prestasi. Pembuat prestasi (achievement_references.student_id)
+-- selalu menjadi anggota; anggota lain harus mengonfirmasi keikutsertaannya.
+CREATE TABLE IF NOT EXISTS achievement_members (
+    achievement_ref_id UUID        NOT NULL REFERENCES achievement_references (id) ON DELETE CASCADE,
+    student_id         UUID        NOT NULL REFERENCES students (id),
+    role               VARCHAR(10) NOT NULL DEFAULT 'anggota' CHECK (role IN ('ketua', 'anggota')),
+    status             VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'declined')),
+    responded_at       TIMESTAMP,
+    points             INT         NOT NULL DEFAULT 0,
+    verified_at        TIMESTAMP,
+    verified_by        UUID,
+    created_at         TIMESTAMP   NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (achievement_ref_id, student_id)
+);
+
+-- Satu ketua per tim
+CREATE UNIQUE INDEX IF NOT EXISTS idx_achievement_members_one_leader
+    ON achievement_members (achievement_ref_id)
+    WHERE role = 'ketua';
+
+CREATE INDEX IF NOT EXISTS idx_achievement_members_student
+    ON achievement_members (student_id);
+
+-- Backfill: prestasi lama menjadi tim satu orang
+INSERT INTO achievement_members (
+    achievement_ref_id, student_id, role, status, responded_at, points, verified_at, verified_by, created_at
+)
+SELECT ar.id, ar.student_id, 'ketua', 'confirmed', ar.created_at, ar.points,
+       CASE WHEN ar.status = 'verified' THEN ar.verified_at END,
+       CASE WHEN ar.status = 'verified' THEN ar.verified_by END,
+       ar.created_at
+FROM achievement_references ar
+ON CONFLICT DO NOTHING;
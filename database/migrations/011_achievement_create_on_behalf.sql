@@ -0,0 +1,12 @@
+-- Admin boleh membuat prestasi atas nama mahasiswa (field on_behalf_of).
+-- Mahasiswa tetap memakai achievement:create dan selalu menjadi pemilik prestasinya sendiri.
+INSERT INTO permissions (id, name, resource, action, description)
+VALUES (gen_random_uuid(), 'achievement:create:all', 'achievement', 'create', 'Membuat prestasi atas nama mahasiswa mana pun')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'Admin'
+  AND p.name = 'achievement:create:all'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,15 @@
+-- Siklus hidup profil mahasiswa & dosen: profil dinonaktifkan (bukan dihapus)
+-- agar prestasi, riwayat review dan bimbingan lama tetap utuh.
+ALTER TABLE students
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_at     TIMESTAMP NOT NULL DEFAULT NOW();
+
+ALTER TABLE lecturers
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_at     TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Satu profil per user, NIM dan NIDN unik
+CREATE UNIQUE INDEX IF NOT EXISTS idx_students_user_id    ON students (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_students_student_id ON students (student_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lecturers_user_id     ON lecturers (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lecturers_lecturer_id ON lecturers (lecturer_id);
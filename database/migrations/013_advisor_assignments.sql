@@ -0,0 +1,41 @@
+-- Riwayat dosen wali. students.advisor_id tetap menyimpan dosen wali saat ini;
+-- setiap penggantian menutup baris lama (effective_to) dan membuka baris baru.
+CREATE TABLE IF NOT EXISTS advisor_assignments (
+    id              UUID        PRIMARY KEY,
+    student_id      UUID        NOT NULL REFERENCES students (id) ON DELETE CASCADE,
+    lecturer_id     UUID        NOT NULL REFERENCES lecturers (id),
+    effective_from  TIMESTAMP   NOT NULL,
+    effective_to    TIMESTAMP,
+    assigned_by     UUID        REFERENCES users (id) ON DELETE SET NULL,
+    reason          TEXT,
+    -- pilihan untuk pengajuan yang belum ditinjau saat dosen wali sebelumnya diganti
+    pending_reviews VARCHAR(10) CHECK (pending_reviews IN ('transfer', 'keep')),
+    created_at      TIMESTAMP   NOT NULL DEFAULT NOW(),
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+-- Hanya satu penugasan aktif per mahasiswa
+CREATE UNIQUE INDEX IF NOT EXISTS idx_advisor_assignments_current
+    ON advisor_assignments (student_id)
+    WHERE effective_to IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_advisor_assignments_lecturer
+    ON advisor_assignments (lecturer_id);
+
+-- Backfill: dosen wali yang ada sekarang dianggap berlaku sejak profil dibuat
+INSERT INTO advisor_assignments (id, student_id, lecturer_id, effective_from, created_at)
+SELECT gen_random_uuid(), s.id, s.advisor_id, s.created_at, NOW()
+FROM students s
+JOIN lecturers l ON l.id = s.advisor_id
+WHERE NOT EXISTS (
+    SELECT 1 FROM advisor_assignments a
+    WHERE a.student_id = s.id AND a.effective_to IS NULL
+);
+
+-- Pengajuan yang tetap ditinjau dosen wali lama (pending_reviews = keep)
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS reviewer_id UUID REFERENCES lecturers (id);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_reviewer
+    ON achievement_references (reviewer_id)
+    WHERE reviewer_id IS NOT NULL;
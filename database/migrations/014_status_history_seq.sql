@@ -0,0 +1,8 @@
+-- Urutan sisip riwayat status. Transisi dalam satu transaksi berbagi NOW(),
+-- sehingga created_at saja tidak cukup untuk mengurutkannya.
+ALTER TABLE achievement_status_history
+    ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+
+DROP INDEX IF EXISTS idx_achievement_status_history_ref;
+CREATE INDEX IF NOT EXISTS idx_achievement_status_history_ref
+    ON achievement_status_history (achievement_ref_id, created_at, seq);
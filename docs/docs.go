@@ -19,14 +19,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/achievements": {
+        "/achievement-types": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar prestasi berdasarkan Role (Mahasiswa lihat punya sendiri, Dosen lihat bimbingan, Admin lihat semua).",
+                "description": "Menampilkan skema details per jenis prestasi untuk merender form di frontend. Gunakan ?all=true untuk menyertakan jenis nonaktif.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,9 +34,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievement"
+                    "Achievement Type"
+                ],
+                "summary": "Daftar Jenis Prestasi",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan jenis nonaktif",
+                        "name": "all",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Lihat Daftar Prestasi",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -45,13 +53,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -67,7 +68,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mahasiswa menginput data prestasi baru (Status awal: Draft). Disimpan ke MongoDB \u0026 PostgreSQL.",
+                "description": "Admin menambahkan jenis prestasi baru beserta definisi field details.",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,17 +76,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievement"
+                    "Achievement Type"
                 ],
-                "summary": "Input Prestasi Baru",
+                "summary": "Buat Jenis Prestasi",
                 "parameters": [
                     {
-                        "description": "Data Prestasi",
+                        "description": "Skema Jenis Prestasi",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementCreateRequest"
+                            "$ref": "#/definitions/model.AchievementTypeRequest"
                         }
                     }
                 ],
@@ -103,18 +104,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/achievements/{id}": {
+        "/achievement-types/{code}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat detail lengkap satu prestasi berdasarkan ID Reference.",
+                "description": "Menampilkan skema details untuk satu jenis prestasi.",
                 "consumes": [
                     "application/json"
                 ],
@@ -122,14 +130,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievement"
+                    "Achievement Type"
                 ],
-                "summary": "Detail Prestasi",
+                "summary": "Detail Jenis Prestasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement Reference ID",
-                        "name": "id",
+                        "description": "Kode jenis prestasi",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
@@ -157,7 +165,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah data prestasi di MongoDB. Hanya bisa dilakukan jika status masih 'draft'.",
+                "description": "Admin mengubah skema jenis prestasi. Kode tidak bisa diubah; skema baru berlaku untuk create/update berikutnya.",
                 "consumes": [
                     "application/json"
                 ],
@@ -165,24 +173,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievement"
+                    "Achievement Type"
                 ],
-                "summary": "Update Data Prestasi",
+                "summary": "Update Jenis Prestasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
+                        "description": "Kode jenis prestasi",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data Update",
+                        "description": "Skema Jenis Prestasi",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementUpdateRequest"
+                            "$ref": "#/definitions/model.AchievementTypeRequest"
                         }
                     }
                 ],
@@ -200,6 +208,13 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             },
@@ -209,7 +224,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus prestasi. Mahasiswa hanya bisa hapus 'draft'. Admin bisa hapus kapan saja.",
+                "description": "Admin menonaktifkan jenis prestasi. Prestasi lama tetap divalidasi dengan skema ini, tetapi jenis ini tidak bisa dipakai untuk prestasi baru.",
                 "consumes": [
                     "application/json"
                 ],
@@ -217,14 +232,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievement"
+                    "Achievement Type"
                 ],
-                "summary": "Hapus Prestasi (Soft Delete)",
+                "summary": "Nonaktifkan Jenis Prestasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
+                        "description": "Kode jenis prestasi",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
@@ -237,8 +252,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -247,16 +262,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/attachments": {
-            "post": {
+        "/achievements": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload file sertifikat/bukti prestasi. Format file bisa gambar/PDF.",
+                "description": "Menampilkan daftar prestasi sesuai scope permission achievement:read (own, advisees, department, all), dengan paginasi (page/limit atau cursor), filter, dan sorting. Prestasi terhapus hanya tampil untuk scope all dengan ?status=deleted.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -264,21 +279,79 @@ const docTemplate = `{
                 "tags": [
                     "Achievement"
                 ],
-                "summary": "Upload Bukti (File)",
+                "summary": "Lihat Daftar Prestasi",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor dari response sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "File Attachment",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "created_at | updated_at | points | status",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar status dipisah koma",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar jenis prestasi dipisah koma",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar tag dipisah koma (harus dimiliki semua)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sejak (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Program studi mahasiswa",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Angkatan mahasiswa, mis. 2021/2022",
+                        "name": "academic_year",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -295,18 +368,30 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            }
-        },
-        "/achievements/{id}/history": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat riwayat kapan prestasi disubmit, diverifikasi, atau ditolak.",
+                "description": "Mahasiswa menginput data prestasi baru (Status awal: Draft). Pemilik prestasi diambil dari token; Admin (achievement:create:all) dapat membuat atas nama mahasiswa lewat on_behalf_of dan tercatat di riwayat status. Details divalidasi terhadap skema jenis prestasi (lihat /achievement-types); kesalahan dikembalikan per field. Prestasi tim dapat menyertakan members; anggota selain pembuat harus mengonfirmasi keikutsertaan sebelum submit. Response menyertakan kemungkinan duplikat. Disimpan ke MongoDB \u0026 PostgreSQL.",
                 "consumes": [
                     "application/json"
                 ],
@@ -316,19 +401,35 @@ const docTemplate = `{
                 "tags": [
                     "Achievement"
                 ],
-                "summary": "History Status Prestasi",
+                "summary": "Input Prestasi Baru",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data Prestasi",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -337,14 +438,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/achievements/{id}/reject": {
+        "/achievements/batch-review": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Dosen menolak prestasi mahasiswa. Wajib menyertakan alasan penolakan.",
+                "description": "Dosen wali memverifikasi atau menolak banyak prestasi sekaligus (maks 200). Setiap item diperiksa seperti endpoint tunggal (mahasiswa bimbingan \u0026 status submitted) dan hasilnya dilaporkan per item; item yang gagal tidak menggagalkan batch.",
                 "consumes": [
                     "application/json"
                 ],
@@ -354,22 +455,15 @@ const docTemplate = `{
                 "tags": [
                     "Achievement"
                 ],
-                "summary": "Tolak Prestasi (Dosen)",
+                "summary": "Verifikasi / Tolak Prestasi Massal (Dosen)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Alasan Penolakan",
+                        "description": "Daftar keputusan",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementRejectRequest"
+                            "$ref": "#/definitions/model.AchievementBatchReviewRequest"
                         }
                     }
                 ],
@@ -387,18 +481,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/achievements/{id}/submit": {
-            "post": {
+        "/achievements/search": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah status prestasi dari 'draft' menjadi 'submitted' agar bisa diverifikasi dosen.",
+                "description": "Pencarian full-text atas judul, deskripsi, tag dan field details utama (nama kompetisi, penerbit, organisasi, dsb.). Hasil diurutkan menurut relevansi, dilengkapi highlight (\u003cmark\u003e) dan jumlah per jenis, tag dan status. Hasil dibatasi scope achievement:read (own, advisees, department, all).",
                 "consumes": [
                     "application/json"
                 ],
@@ -408,14 +509,68 @@ const docTemplate = `{
                 "tags": [
                     "Achievement"
                 ],
-                "summary": "Ajukan Prestasi (Submit)",
+                "summary": "Cari Prestasi (Full-Text)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Kata kunci (mendukung \\",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar status dipisah koma",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar jenis prestasi dipisah koma",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar tag dipisah koma (harus dimiliki semua)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sejak (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Program studi mahasiswa",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Angkatan mahasiswa, mis. 2021/2022",
+                        "name": "academic_year",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -432,18 +587,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/achievements/{id}/verify": {
-            "post": {
+        "/achievements/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Dosen menyetujui prestasi mahasiswa bimbingannya. Status berubah jadi 'verified'.",
+                "description": "Melihat detail lengkap satu prestasi berdasarkan ID Reference. Setiap attachment dilengkapi URL unduhan dan URL preview (thumbnail gambar / halaman pertama PDF) bertanda tangan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -453,11 +615,11 @@ const docTemplate = `{
                 "tags": [
                     "Achievement"
                 ],
-                "summary": "Verifikasi Prestasi (Dosen)",
+                "summary": "Detail Prestasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -477,13 +639,23 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            }
-        },
-        "/auth/login": {
-            "post": {
-                "description": "Otentikasi user menggunakan username dan password untuk mendapatkan JWT Token.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data prestasi di MongoDB. Hanya untuk status 'draft' dan minimal satu field diisi; prestasi 'rejected' harus dibuka dulu lewat POST /achievements/{id}/revise (409).",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,17 +663,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievement"
                 ],
-                "summary": "Login Pengguna",
+                "summary": "Update Data Prestasi",
                 "parameters": [
                     {
-                        "description": "Credential User",
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
+                            "$ref": "#/definitions/model.AchievementUpdateRequest"
                         }
                     }
                 ],
@@ -520,31 +699,29 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
-            "post": {
-                "security": [
+            },
+            "delete": {
+                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Keluar dari aplikasi (Client-side clear token).",
+                "description": "Menghapus prestasi. Scope achievement:delete:all bisa hapus kapan saja, scope lain hanya 'draft' yang terjangkau.",
                 "consumes": [
                     "application/json"
                 ],
@@ -552,9 +729,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievement"
+                ],
+                "summary": "Hapus Prestasi (Soft Delete)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -562,18 +748,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/auth/profile": {
+        "/achievements/{id}/attachments": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mendapatkan data profil user yang sedang login (berdasarkan Token).",
+                "description": "Menampilkan metadata semua file bukti prestasi beserta URL unduhan bertanda tangan yang berlaku singkat. Hak akses sama dengan detail prestasi.",
                 "consumes": [
                     "application/json"
                 ],
@@ -581,9 +774,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievement"
+                ],
+                "summary": "Daftar Attachment Prestasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Profil Saya",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -592,6 +794,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -600,26 +809,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/refresh": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Memperbarui token JWT untuk memperpanjang sesi login.",
+                "description": "Upload file sertifikat/bukti prestasi (PDF/JPEG/PNG). Tipe file dideteksi dari isi dan ekstensi harus cocok. Batas ukuran per file, total, dan jumlah file per prestasi dapat dikonfigurasi. File disimpan dengan key UUID beserta metadata; file yang ditolak pemindai dikarantina.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Achievement"
+                ],
+                "summary": "Upload Bukti (File)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File Attachment",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "Refresh Token",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -628,15 +851,29 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -645,30 +882,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/lecturers": {
+        "/achievements/{id}/attachments/{attachmentId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan data dosen. Admin bisa melihat semua dosen, Mahasiswa hanya melihat dosen walinya sendiri.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengunduh file bukti prestasi. Mendukung header Range (206 Partial Content). Tambahkan ?download=1 agar dikirim sebagai attachment.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Lecturer"
+                    "Achievement"
+                ],
+                "summary": "Unduh Attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Paksa unduh (Content-Disposition: attachment)",
+                        "name": "download",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Lihat Daftar Dosen",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
                         }
                     },
                     "403": {
@@ -685,24 +946,22 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "416": {
+                        "description": "Requested Range Not Satisfiable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/lecturers/{id}/advisees": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat daftar mahasiswa yang dibimbing oleh dosen tertentu. Dosen hanya bisa melihat bimbingannya sendiri.",
+                "description": "Menghapus file bukti dari prestasi. Hanya bisa dilakukan selama prestasi masih berstatus 'draft'.",
                 "consumes": [
                     "application/json"
                 ],
@@ -710,16 +969,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturer"
+                    "Achievement"
                 ],
-                "summary": "Lihat Mahasiswa Bimbingan",
+                "summary": "Hapus Attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -730,6 +996,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -737,8 +1010,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -747,30 +1020,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/statistics": {
+        "/achievements/{id}/attachments/{attachmentId}/preview": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat rekapitulasi data prestasi (Total Draft, Submitted, Verified, Rejected). Hanya bisa diakses oleh Admin.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mengambil thumbnail (gambar) atau preview halaman pertama (PDF) berformat PNG, agar dosen tidak perlu mengunduh file asli saat verifikasi.",
                 "produces": [
-                    "application/json"
+                    "image/png"
                 ],
                 "tags": [
-                    "Report"
+                    "Achievement"
+                ],
+                "summary": "Preview Attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Statistik Keseluruhan (Admin)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     },
                     "403": {
@@ -780,8 +1065,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -790,14 +1075,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/student/{id}": {
+        "/achievements/{id}/attachments/{attachmentId}/url": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat performa prestasi satu mahasiswa spesifik. Mahasiswa hanya bisa lihat diri sendiri, Dosen hanya bimbingannya, Admin bebas.",
+                "description": "Membuat URL unduhan singkat yang bisa dipakai tanpa header Authorization, mis. untuk menampilkan PDF/gambar di frontend.",
                 "consumes": [
                     "application/json"
                 ],
@@ -805,16 +1090,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Achievement"
                 ],
-                "summary": "Laporan Statistik Mahasiswa",
+                "summary": "URL Unduhan Bertanda Tangan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -838,25 +1130,18 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             }
         },
-        "/students": {
+        "/achievements/{id}/comments": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin melihat semua mahasiswa. Dosen Wali hanya melihat mahasiswa bimbingannya.",
+                "description": "Menampilkan komentar, permintaan klarifikasi, balasan, dan catatan penolakan dalam bentuk thread. Hak akses sama dengan detail prestasi.",
                 "consumes": [
                     "application/json"
                 ],
@@ -864,9 +1149,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Achievement Comment"
+                ],
+                "summary": "Thread Komentar Prestasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement Reference ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Lihat Daftar Mahasiswa",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -888,25 +1182,16 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
-            }
-        },
-        "/students/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat detail data satu mahasiswa. Mahasiswa hanya bisa lihat diri sendiri. Dosen hanya bimbingannya.",
+                "description": "Menulis komentar atau balasan pada prestasi. Dosen/verifikator dapat meminta klarifikasi (kind=clarification) pada prestasi yang sedang diajukan tanpa menolaknya. Komentar dapat merujuk attachment prestasi.",
                 "consumes": [
                     "application/json"
                 ],
@@ -914,21 +1199,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Achievement Comment"
                 ],
-                "summary": "Detail Mahasiswa",
+                "summary": "Tulis Komentar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Isi komentar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementCommentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -951,14 +1252,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}/achievements": {
-            "get": {
+        "/achievements/{id}/comments/{commentId}/resolve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat daftar prestasi milik mahasiswa tertentu berdasarkan ID-nya (Admin, Dosen Wali, \u0026 Pemilik Akun).",
+                "description": "Menandai permintaan klarifikasi sudah terjawab. Hanya dapat dilakukan oleh dosen/verifikator yang berhak atas prestasi ini.",
                 "consumes": [
                     "application/json"
                 ],
@@ -966,16 +1267,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Achievement Comment"
                 ],
-                "summary": "Lihat Prestasi Mahasiswa Tertentu",
+                "summary": "Tutup Permintaan Klarifikasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1000,8 +1308,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1010,14 +1318,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/students/{id}/advisor": {
-            "put": {
+        "/achievements/{id}/diff": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Admin menetapkan dosen wali untuk mahasiswa tertentu.",
+                "description": "Membandingkan dokumen prestasi saat ini dengan snapshot pada review terakhir, per field (termasuk per key di details).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1025,28 +1333,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Student"
+                    "Achievement"
                 ],
-                "summary": "Set Dosen Wali (Admin)",
+                "summary": "Perubahan Sejak Review Terakhir",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Body: { advisor_id: 'uuid-dosen' }",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 ],
                 "responses": {
@@ -1057,15 +1353,15 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1074,14 +1370,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/achievements/{id}/duplicates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menampilkan daftar seluruh user di sistem (Biasanya Admin Only).",
+                "description": "Membandingkan judul, nama kegiatan, tanggal dan hash lampiran dengan prestasi lain yang belum dihapus (milik mahasiswa yang sama maupun mahasiswa lain). Mengembalikan skor kemiripan 0..1 dan tautan ke prestasi yang cocok.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1089,9 +1385,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Achievement"
+                ],
+                "summary": "Kemungkinan Duplikat Prestasi",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement Reference ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Lihat Semua User",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1100,22 +1405,24 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/achievements/{id}/history": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menambahkan user baru secara manual (Admin). Password akan otomatis di-hash.",
+                "description": "Melihat timeline lengkap transisi status prestasi (siapa, kapan, dari-ke status, dan catatan).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1123,18 +1430,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Achievement"
                 ],
-                "summary": "Buat User Baru",
+                "summary": "History Status Prestasi",
                 "parameters": [
                     {
-                        "description": "Data User Baru",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UserCreateRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1145,8 +1450,15 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1162,14 +1474,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/achievements/{id}/members": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Melihat detail satu user berdasarkan ID.",
+                "description": "Menampilkan anggota tim prestasi beserta peran (ketua/anggota), status konfirmasi dan verifikasi per anggota.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1177,13 +1489,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Achievement"
                 ],
-                "summary": "Detail User",
+                "summary": "Anggota Tim Prestasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1203,13 +1515,6 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 }
             },
@@ -1219,7 +1524,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah data user (Username, Email, Nama, Password, Status Aktif).",
+                "description": "Pemilik prestasi mengganti susunan tim saat status 'draft' atau 'rejected'. Anggota baru harus mengonfirmasi keikutsertaannya sebelum prestasi bisa disubmit. Anggota yang pernah menolak lalu dicantumkan lagi diundang ulang (kembali pending).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1227,24 +1532,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Achievement"
                 ],
-                "summary": "Update Data User",
+                "summary": "Atur Anggota Tim Prestasi",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data Update",
+                        "description": "Anggota Tim",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserUpdateRequest"
+                            "$ref": "#/definitions/model.AchievementMembersRequest"
                         }
                     }
                 ],
@@ -1262,30 +1567,72 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/members/respond": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mahasiswa yang dicantumkan sebagai anggota tim mengonfirmasi (confirm) atau menolak (decline) keikutsertaannya. Hanya saat prestasi berstatus 'draft' atau 'rejected'.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Konfirmasi Keikutsertaan Tim",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement Reference ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "404": {
-                        "description": "Not Found",
+                    {
+                        "description": "Keputusan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementMemberResponseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/achievements/{id}/members/{studentId}/verify": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Menghapus user dari database secara permanen.",
+                "description": "Dosen wali seorang anggota memverifikasi bagian anggota tersebut pada prestasi tim berstatus 'submitted'. Bagian pemilik yang dipertahankan dosen wali lama (pending_reviews=keep) diverifikasi dosen tersebut, bukan dosen wali barunya. Setelah seluruh anggota yang konfirmasi terverifikasi, prestasi otomatis berstatus 'verified'. Verifikasi sekaligus untuk satu tim tetap bisa lewat /achievements/{id}/verify.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1293,16 +1640,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Achievement"
                 ],
-                "summary": "Hapus User",
+                "summary": "Verifikasi Anggota Tim (Dosen Wali Anggota)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement Reference ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Student ID anggota",
+                        "name": "studentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1313,15 +1667,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1330,14 +1677,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/role": {
-            "put": {
+        "/achievements/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mengubah role/hak akses user (Misal: dari Mahasiswa ke Admin).",
+                "description": "Dosen menolak prestasi mahasiswa berstatus 'submitted'. Wajib menyertakan alasan penolakan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1345,24 +1692,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Achievement"
                 ],
-                "summary": "Ganti Role User",
+                "summary": "Tolak Prestasi (Dosen)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role ID Baru",
+                        "description": "Alasan Penolakan",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UserUpdateRoleRequest"
+                            "$ref": "#/definitions/model.AchievementRejectRequest"
                         }
                     }
                 ],
@@ -1380,16 +1727,54 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan setiap ronde review (verifikasi/penolakan) beserta catatan dan snapshot dokumen saat direview.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Riwayat Ronde Review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1397,49 +1782,3568 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AchievementCreateRequest": {
-            "type": "object"
-        },
-        "model.AchievementRejectRequest": {
-            "type": "object",
-            "properties": {
-                "note": {
-                    "description": "Alasan mengapa prestasi ditolak",
-                    "type": "string",
-                    "example": "Sertifikat tidak valid atau kadaluarsa"
-                }
-            }
         },
-        "model.AchievementUpdateRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "Update deskripsi prestasi"
-                },
-                "details": {
-                    "type": "object"
-                },
-                "points": {
-                    "type": "integer",
-                    "example": 100
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "teknologi"
-                    ]
-                },
-                "title": {
-                    "type": "string",
-                    "example": "Juara 1 Hackathon Nasional 2025 (Updated)"
-                }
+        "/achievements/{id}/revise": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuka kembali prestasi berstatus 'rejected' menjadi 'draft' agar bisa diperbaiki dan disubmit ulang. Catatan penolakan ronde sebelumnya tetap tersimpan di history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Revisi Prestasi yang Ditolak",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Catatan revisi",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementReviseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah status prestasi dari 'draft' menjadi 'submitted' agar bisa diverifikasi dosen. Prestasi tim hanya bisa disubmit setelah semua anggota mengonfirmasi atau menolak. Kemungkinan duplikat dicatat pada dokumen (field duplicates) untuk dosen verifikator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Ajukan Prestasi (Submit)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dosen menyetujui prestasi mahasiswa bimbingannya. Hanya status 'submitted' yang bisa diverifikasi; status berubah jadi 'verified' dan poin dihitung dengan aturan skor aktif. Untuk prestasi tim, dosen wali pemilik memverifikasi sekaligus seluruh anggota yang sudah konfirmasi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Verifikasi Prestasi (Dosen)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Otentikasi user menggunakan username dan password untuk mendapatkan access token (JWT, 15 menit) dan refresh token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login Pengguna",
+                "parameters": [
+                    {
+                        "description": "Credential User",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencabut access token yang sedang dipakai beserta seluruh refresh token pada sesi login tersebut.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh Token (opsional)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mendapatkan data profil user yang sedang login (berdasarkan Token).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Profil Saya",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Menukar refresh token dengan access token baru. Refresh token dirotasi setiap dipakai; pemakaian ulang token lama mencabut seluruh sesi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/files/achievements/{id}/attachments/{attachmentId}": {
+            "get": {
+                "description": "Mengunduh file memakai URL dari /achievements/{id}/attachments/{attachmentId}/url. Tidak memerlukan token; ditolak bila tanda tangan salah atau kedaluwarsa.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Unduh Attachment via URL Bertanda Tangan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time kedaluwarsa",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanda tangan HMAC",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/files/achievements/{id}/attachments/{attachmentId}/preview": {
+            "get": {
+                "description": "Mengambil thumbnail gambar / preview halaman pertama PDF memakai URL preview dari daftar attachment atau detail prestasi.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Achievement"
+                ],
+                "summary": "Preview Attachment via URL Bertanda Tangan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time kedaluwarsa",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanda tangan HMAC",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{kind}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengimpor user beserta profil mahasiswa (kolom nim, nama, email, program_studi, angkatan, nidn_wali) atau dosen (kolom nidn, nama, email, departemen) dari file CSV atau XLSX. Baris dicocokkan berdasarkan NIM/NIDN: yang belum ada dibuat (username = NIM/NIDN, password awal acak, role dari role_id atau satu-satunya role yang permission-nya boleh memiliki profil tersebut), yang sudah ada diperbarui (penggantian dosen wali dicatat di riwayat, pengajuan yang belum ditinjau tetap pada dosen wali lama). Default dry-run: hanya validasi per baris tanpa menyimpan. Dengan dry_run=false semua baris disimpan dalam satu transaksi, dan ditolak (422) bila ada baris tidak valid. Password awal hanya ditampilkan sekali pada hasil commit. format=csv mengembalikan report sebagai file unduhan.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Impor Massal Mahasiswa / Dosen (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "students | lecturers",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File .csv atau .xlsx (sheet pertama)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role untuk user baru; default satu-satunya role yang boleh memiliki profil tersebut",
+                        "name": "role_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hanya validasi, tidak menyimpan (default true)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json | csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan data dosen sesuai scope permission lecturer:read, dengan paginasi, filter, dan sorting. Scope own hanya melihat dosen wali sendiri (atau diri sendiri bagi dosen).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Lihat Daftar Dosen",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari response sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lecturer_id | department | created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Departemen",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter profil aktif / nonaktif",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari NIDN",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat profil dosen untuk user yang role-nya memiliki permission achievement:verify dan belum memiliki profil. NIDN harus unik.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Buat Profil Dosen (Admin)",
+                "parameters": [
+                    {
+                        "description": "Data profil dosen",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LecturerCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah NIDN dan departemen dosen.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Update Profil Dosen (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data profil dosen",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LecturerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengaktifkan kembali profil dosen yang sebelumnya dinonaktifkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Aktifkan Kembali Profil Dosen (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}/advisees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat daftar mahasiswa yang dibimbing oleh dosen tertentu sesuai scope permission student:read. Scope advisees hanya bimbingan sendiri.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Lihat Mahasiswa Bimbingan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menonaktifkan profil dosen (mis. pensiun atau pindah). Ditolak bila dosen masih membimbing mahasiswa aktif atau masih mempertahankan pengajuan yang belum ditinjau; pindahkan lebih dulu lewat POST /lecturers/{id}/reassign-advisees.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Nonaktifkan Profil Dosen (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}/reassign-advisees": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Memindahkan mahasiswa bimbingan dosen ini ke dosen wali lain dalam satu transaksi, mis. saat dosen pensiun atau pindah. Tanpa student_ids, semua mahasiswa aktif bimbingannya dipindahkan dan pengajuan yang sebelumnya dipertahankan dosen ini ikut diproses. Bila masih ada pengajuan yang belum ditinjau, pending_reviews wajib dipilih: transfer (ikut pindah) atau keep (tetap ditinjau dosen ini).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturer"
+                ],
+                "summary": "Pindahkan Mahasiswa Bimbingan (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID (dosen wali lama)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dosen wali tujuan dan opsi pemindahan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AdvisorReassignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance/reconcile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mencari dokumen MongoDB tanpa reference Postgre, reference yang dokumennya hilang, dan pemilik (student_id) yang berbeda di kedua store, tanpa mengubah data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "Laporan Konsistensi Prestasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Umur minimal dokumen yatim dalam menit (default 15)",
+                        "name": "grace_minutes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sama seperti laporan, lalu menghapus dokumen MongoDB yatim, menandai reference tanpa dokumen sebagai deleted, dan menyamakan studentId dokumen dengan Postgre. Reference verified tanpa dokumen hanya dilaporkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Maintenance"
+                ],
+                "summary": "Perbaiki Konsistensi Prestasi",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Umur minimal dokumen yatim dalam menit (default 15)",
+                        "name": "grace_minutes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan seluruh permission (resource:action) yang tersedia.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Lihat Daftar Permission",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan permission baru. Resource dan action wajib diisi, nama default \"resource:action\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Buat Permission Baru",
+                "parameters": [
+                    {
+                        "description": "Data Permission",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat detail satu permission berdasarkan ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Detail Permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data permission. Field yang kosong tidak diubah (kecuali description).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Update Permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus permission dan melepasnya dari semua role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Hapus Permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat rekapitulasi data prestasi (Total Draft, Submitted, Verified, Rejected) sesuai scope permission report:read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Statistik Prestasi",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/student/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat performa prestasi satu mahasiswa spesifik sesuai scope permission report:read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Laporan Statistik Mahasiswa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan seluruh role yang terdaftar di sistem RBAC.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Lihat Daftar Role",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan role baru (misal: Kaprodi, Wakil Dekan). Nama role harus unik.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Buat Role Baru",
+                "parameters": [
+                    {
+                        "description": "Data Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat detail satu role beserta daftar permission yang dimilikinya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Detail Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah nama dan deskripsi role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Update Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus role beserta relasi permission-nya. Role yang masih dipakai user tidak bisa dihapus.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Hapus Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar permission yang sudah ditempelkan ke role tertentu.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Lihat Permission Milik Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menempelkan permission ke role. Berlaku untuk token yang diterbitkan setelah perubahan ini.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Tambah Permission ke Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permission ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RolePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/permissions/{permissionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melepas permission dari role tertentu. Mengembalikan 404 bila permission tidak terpasang pada role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role Management"
+                ],
+                "summary": "Lepas Permission dari Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Permission ID",
+                        "name": "permissionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scoring-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan seluruh versi aturan perhitungan poin, terbaru dulu. Hanya satu versi yang aktif.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rule"
+                ],
+                "summary": "Daftar Versi Aturan Skor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menyimpan versi aturan skor baru dalam keadaan belum aktif. Gunakan preview untuk melihat dampaknya lalu activate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rule"
+                ],
+                "summary": "Buat Versi Aturan Skor",
+                "parameters": [
+                    {
+                        "description": "Aturan Skor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScoringRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scoring-rules/{version}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan satu versi aturan skor. Gunakan 'active' sebagai version untuk versi yang sedang aktif.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rule"
+                ],
+                "summary": "Detail Versi Aturan Skor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nomor versi atau 'active'",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scoring-rules/{version}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menjadikan versi ini aturan aktif untuk verifikasi berikutnya. Poin prestasi yang sudah diverifikasi tidak diubah dan tetap mencatat versi lamanya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rule"
+                ],
+                "summary": "Aktifkan Versi Aturan Skor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor versi",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scoring-rules/{version}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghitung ulang poin seluruh prestasi terverifikasi memakai versi aturan tertentu tanpa menyimpan apa pun, lalu membandingkannya dengan poin tersimpan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scoring Rule"
+                ],
+                "summary": "Simulasi Aturan Skor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nomor versi atau 'active'",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan mahasiswa sesuai scope permission student:read (own, advisees, department, all), dengan paginasi, filter, dan sorting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Lihat Daftar Mahasiswa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari response sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "student_id | academic_year | created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Program studi",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Angkatan, mis. 2021/2022",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter profil aktif / nonaktif",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari NIM",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Membuat profil mahasiswa untuk user yang role-nya memiliki permission achievement:create (scope own) dan belum memiliki profil. NIM harus unik.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Buat Profil Mahasiswa (Admin)",
+                "parameters": [
+                    {
+                        "description": "Data profil mahasiswa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StudentCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat detail data satu mahasiswa sesuai scope permission student:read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Detail Mahasiswa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah NIM, program studi dan angkatan mahasiswa. Dosen wali diubah lewat PUT /students/{id}/advisor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Update Profil Mahasiswa (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data profil mahasiswa",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StudentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat daftar prestasi milik mahasiswa tertentu berdasarkan ID-nya sesuai scope permission achievement:read. Mendukung paginasi, filter, dan sorting yang sama dengan /achievements.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Lihat Prestasi Mahasiswa Tertentu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari response sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at | updated_at | points | status",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar status dipisah koma",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar jenis prestasi dipisah koma",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Daftar tag dipisah koma",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sejak (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dibuat sampai (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengaktifkan kembali profil mahasiswa yang sebelumnya dinonaktifkan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Aktifkan Kembali Profil Mahasiswa (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menetapkan dosen wali baru untuk mahasiswa. Dosen harus ada dan masih aktif. Penugasan lama ditutup dan dicatat di riwayat. Bila masih ada pengajuan prestasi (submitted) yang belum ditinjau, pending_reviews wajib dipilih: transfer (ikut pindah ke dosen wali baru) atau keep (tetap ditinjau dosen wali lama).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Ganti Dosen Wali (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dosen wali baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AdvisorAssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/advisors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan riwayat penugasan dosen wali (terbaru lebih dulu) sesuai scope permission student:read. Penugasan yang masih berlaku tidak memiliki effective_to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Riwayat Dosen Wali Mahasiswa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menonaktifkan profil mahasiswa (mis. lulus atau keluar). Prestasi lama tetap tersimpan, tetapi mahasiswa tidak bisa membuat prestasi baru. Akun login diatur terpisah lewat /users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Student"
+                ],
+                "summary": "Nonaktifkan Profil Mahasiswa (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menampilkan daftar user di sistem (Biasanya Admin Only) dengan paginasi, filter, dan sorting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Lihat Semua User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah per halaman (default 20, maks 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari response sebelumnya",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at | username | full_name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter role",
+                        "name": "role_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter status aktif",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari username, email, atau nama",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan user baru secara manual (Admin). Password akan otomatis di-hash. Profil mahasiswa (student, untuk role dengan permission achievement:create scope own) atau dosen (lecturer, untuk role dengan permission achievement:verify) dapat dibuat sekaligus dalam transaksi yang sama.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Buat User Baru",
+                "parameters": [
+                    {
+                        "description": "Data User Baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Melihat detail satu user berdasarkan ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Detail User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah data user (Username, Email, Nama, Password, Status Aktif). Menonaktifkan akun atau mengganti password mencabut semua sesi user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Update Data User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Menghapus user dari database secara permanen. Token milik user langsung ditolak.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Hapus User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mengubah role/hak akses user (Misal: dari Mahasiswa ke Admin). Access token lama ditolak, user perlu refresh untuk mendapat permission baru.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Ganti Role User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role ID Baru",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserUpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.AchievementBatchReviewItem": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "description": "verify | reject (kosong = ikut decision di level atas)",
+                    "type": "string",
+                    "example": "reject"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "note": {
+                    "description": "Wajib untuk reject (boleh dari note di level atas)",
+                    "type": "string",
+                    "example": "Sertifikat tidak terbaca"
+                }
+            }
+        },
+        "model.AchievementBatchReviewRequest": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "description": "verify | reject",
+                    "type": "string",
+                    "example": "verify"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementBatchReviewItem"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Bukti lengkap"
+                }
+            }
+        },
+        "model.AchievementCommentRequest": {
+            "type": "object",
+            "properties": {
+                "attachment_ids": {
+                    "description": "ID attachment prestasi yang dirujuk komentar",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0b9f6c1e-4c7a-4b7e-9a55-2d7f1c3e8a10"
+                    ]
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Surat keterangan sudah saya lampirkan."
+                },
+                "kind": {
+                    "description": "comment (default) | clarification (khusus dosen/verifikator)",
+                    "type": "string",
+                    "example": "comment"
+                },
+                "parent_id": {
+                    "description": "Kosongkan untuk komentar baru; isi untuk membalas komentar lain",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440019"
+                }
+            }
+        },
+        "model.AchievementCreateRequest": {
+            "type": "object"
+        },
+        "model.AchievementField": {
+            "type": "object",
+            "properties": {
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "international",
+                        "national",
+                        "regional",
+                        "campus"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Peringkat"
+                },
+                "max": {
+                    "type": "number",
+                    "example": 100
+                },
+                "maxLength": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "min": {
+                    "type": "number",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "rank"
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "^[0-9]{4}-[0-9]{4}$"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "example": "integer"
+                }
+            }
+        },
+        "model.AchievementMemberInput": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "ketua | anggota (default anggota)",
+                    "type": "string",
+                    "example": "anggota"
+                },
+                "student_id": {
+                    "type": "string",
+                    "example": "uuid-student-456"
+                }
+            }
+        },
+        "model.AchievementMemberResponseRequest": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "description": "confirm | decline",
+                    "type": "string",
+                    "example": "confirm"
+                }
+            }
+        },
+        "model.AchievementMembersRequest": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementMemberInput"
+                    }
+                }
+            }
+        },
+        "model.AchievementRejectRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "Alasan mengapa prestasi ditolak",
+                    "type": "string",
+                    "example": "Sertifikat tidak valid atau kadaluarsa"
+                }
+            }
+        },
+        "model.AchievementReviseRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "example": "Sertifikat sudah diganti dengan scan yang lebih jelas"
+                }
+            }
+        },
+        "model.AchievementTypeRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "allow_extra": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "code": {
+                    "type": "string",
+                    "example": "competition"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Lomba atau kejuaraan akademik maupun non-akademik"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementField"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kompetisi"
+                }
+            }
+        },
+        "model.AchievementUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Update deskripsi prestasi"
+                },
+                "details": {
+                    "type": "object"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "teknologi"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Juara 1 Hackathon Nasional 2025 (Updated)"
+                }
+            }
+        },
+        "model.AdvisorAssignRequest": {
+            "type": "object",
+            "properties": {
+                "advisor_id": {
+                    "description": "ID tabel lecturers; harus ada dan masih aktif",
+                    "type": "string",
+                    "example": "uuid-lecturer-456"
+                },
+                "effective_date": {
+                    "description": "Tanggal mulai berlaku (YYYY-MM-DD), default hari ini; tidak boleh di masa depan",
+                    "type": "string",
+                    "example": "2025-08-01"
+                },
+                "pending_reviews": {
+                    "description": "transfer | keep, wajib bila masih ada pengajuan yang belum ditinjau",
+                    "type": "string",
+                    "example": "transfer"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Permintaan program studi"
+                }
+            }
+        },
+        "model.AdvisorReassignRequest": {
+            "type": "object",
+            "properties": {
+                "effective_date": {
+                    "type": "string",
+                    "example": "2025-08-01"
+                },
+                "pending_reviews": {
+                    "type": "string",
+                    "example": "transfer"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Dosen wali pensiun"
+                },
+                "student_ids": {
+                    "description": "Kosong berarti semua mahasiswa aktif bimbingan dosen ini",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to_advisor_id": {
+                    "type": "string",
+                    "example": "uuid-lecturer-789"
+                }
+            }
+        },
+        "model.LecturerCreateRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string",
+                    "example": "Teknik Informatika"
+                },
+                "lecturer_id": {
+                    "description": "NIDN atau Nomor Induk Dosen",
+                    "type": "string",
+                    "example": "198801012015011001"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "uuid-user-456"
+                }
+            }
+        },
+        "model.LecturerProfileRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string",
+                    "example": "Teknik Informatika"
+                },
+                "lecturer_id": {
+                    "description": "NIDN atau Nomor Induk Dosen",
+                    "type": "string",
+                    "example": "198801012015011001"
+                }
             }
         },
         "model.LoginRequest": {
@@ -1455,6 +5359,201 @@ const docTemplate = `{
                 }
             }
         },
+        "model.PermissionRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "create"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Memberikan akses untuk membuat laporan prestasi baru"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "achievement:create"
+                },
+                "resource": {
+                    "type": "string",
+                    "example": "achievement"
+                }
+            }
+        },
+        "model.RankMultiplier": {
+            "type": "object",
+            "properties": {
+                "max_rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "multiplier": {
+                    "type": "number",
+                    "example": 2
+                }
+            }
+        },
+        "model.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wX9..."
+                }
+            }
+        },
+        "model.RolePermissionRequest": {
+            "type": "object",
+            "properties": {
+                "permission_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440005"
+                }
+            }
+        },
+        "model.RoleRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Ketua program studi"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Kaprodi"
+                }
+            }
+        },
+        "model.ScoringRuleRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Bobot internasional dinaikkan"
+                },
+                "rules": {
+                    "$ref": "#/definitions/model.ScoringRuleSet"
+                }
+            }
+        },
+        "model.ScoringRuleSet": {
+            "type": "object",
+            "properties": {
+                "base_points": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "example": {
+                        "competition": 50,
+                        "default": 20
+                    }
+                },
+                "default_level_multiplier": {
+                    "type": "number",
+                    "example": 1
+                },
+                "default_rank_multiplier": {
+                    "type": "number",
+                    "example": 1
+                },
+                "level_multipliers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "example": {
+                        "international": 3,
+                        "national": 2
+                    }
+                },
+                "max_points": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "min_points": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "rank_multipliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RankMultiplier"
+                    }
+                },
+                "team_split": {
+                    "type": "string",
+                    "example": "sqrt"
+                }
+            }
+        },
+        "model.StudentCreateRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2021/2022"
+                },
+                "advisor_id": {
+                    "description": "Dosen wali (opsional, ID tabel lecturers)",
+                    "type": "string",
+                    "example": "uuid-lecturer-456"
+                },
+                "program_study": {
+                    "type": "string",
+                    "example": "Teknik Informatika"
+                },
+                "student_id": {
+                    "description": "NIM",
+                    "type": "string",
+                    "example": "2021101234"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "uuid-user-123"
+                }
+            }
+        },
+        "model.StudentProfileRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2021/2022"
+                },
+                "advisor_id": {
+                    "description": "Dosen wali (opsional, ID tabel lecturers)",
+                    "type": "string",
+                    "example": "uuid-lecturer-456"
+                },
+                "program_study": {
+                    "type": "string",
+                    "example": "Teknik Informatika"
+                },
+                "student_id": {
+                    "description": "NIM",
+                    "type": "string",
+                    "example": "2021101234"
+                }
+            }
+        },
+        "model.StudentUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2021/2022"
+                },
+                "program_study": {
+                    "type": "string",
+                    "example": "Teknik Informatika"
+                },
+                "student_id": {
+                    "type": "string",
+                    "example": "2021101234"
+                }
+            }
+        },
         "model.UserCreateRequest": {
             "type": "object",
             "properties": {
@@ -1470,6 +5569,9 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
+                "lecturer": {
+                    "$ref": "#/definitions/model.LecturerProfileRequest"
+                },
                 "password": {
                     "type": "string",
                     "example": "StrictPass2025!"
@@ -1478,6 +5580,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "uuid-role-dosen"
                 },
+                "student": {
+                    "description": "Profil yang dibuat dalam transaksi yang sama (opsional, sesuai role:\nstudent untuk Mahasiswa, lecturer untuk Dosen Wali)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.StudentProfileRequest"
+                        }
+                    ]
+                },
                 "username": {
                     "type": "string",
                     "example": "dosen_wali1"
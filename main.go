@@ -2,10 +2,14 @@ package main
 
 import (
 	"log"
+	"time"
 
+	"prestasi_backend/app/repository"
+	"prestasi_backend/app/service"
 	"prestasi_backend/config"
 	"prestasi_backend/database"
 	"prestasi_backend/route"
+	"prestasi_backend/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
@@ -40,7 +44,47 @@ func main() {
 	}
 	database.MongoDB = mongoDB
 
-	app := fiber.New()
+	fileStorage, err := storage.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	storage.Default = fileStorage
+
+	// Skema bawaan jenis prestasi (tidak menimpa skema yang sudah diubah admin)
+	if err := repository.SeedAchievementTypes(service.DefaultAchievementTypes()); err != nil {
+		log.Println("⚠️ gagal seed jenis prestasi:", err)
+	}
+
+	// Indeks deteksi duplikat & full-text search; dokumen lama diberi kunci di background
+	if err := repository.EnsureAchievementIndexes(); err != nil {
+		log.Println("⚠️ gagal membuat index achievement:", err)
+	}
+	go func() {
+		if n, err := service.BackfillDedupKeys(); err != nil {
+			log.Println("⚠️ gagal mengisi dedupKeys achievement:", err)
+		} else if n > 0 {
+			log.Println("dedupKeys diisi untuk", n, "achievement lama")
+		}
+	}()
+
+	// Bersihkan catatan token kedaluwarsa secara berkala
+	go func() {
+		for range time.Tick(time.Hour) {
+			if err := repository.PurgeExpiredTokens(); err != nil {
+				log.Println("⚠️ gagal membersihkan token kedaluwarsa:", err)
+			}
+		}
+	}()
+
+	// Kebijakan upload attachment & pemindai file
+	service.Limits = service.AttachmentLimitsFromEnv()
+	service.Scanner = service.ScannerFromEnv()
+	service.PDFRenderer = service.PDFRendererFromEnv()
+
+	// Body multipart sedikit lebih besar dari batas file agar 413 dikirim oleh handler
+	app := fiber.New(fiber.Config{
+		BodyLimit: int(service.Limits.MaxFileBytes) + 1<<20,
+	})
 
 	// Route Cek Health
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
+	"prestasi_backend/app/repository"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AchievementAccess memastikan user boleh melakukan action ("read", "update",
+// "delete", "verify") pada prestasi :id — sebagai pemilik, anggota tim, dosen
+// wali, dosen satu departemen, atau lewat scope all. Reference yang sudah
+// dimuat disimpan di c.Locals("achievement"); handler di belakangnya membaca
+// reference dari sana tanpa mengambil ulang maupun mengecek ulang hak akses.
+func AchievementAccess(action string) fiber.Handler {
+	// fungsi bernama (bukan closure) agar guard tiap route bisa dikenali saat pengujian
+	switch action {
+	case "read":
+		return achievementReadGuard
+	case "update":
+		return achievementUpdateGuard
+	case "delete":
+		return achievementDeleteGuard
+	case "verify":
+		return achievementVerifyGuard
+	}
+	panic("middleware: action achievement tidak dikenal: " + action)
+}
+
+func achievementReadGuard(c *fiber.Ctx) error   { return guardAchievement(c, "read") }
+func achievementUpdateGuard(c *fiber.Ctx) error { return guardAchievement(c, "update") }
+func achievementDeleteGuard(c *fiber.Ctx) error { return guardAchievement(c, "delete") }
+func achievementVerifyGuard(c *fiber.Ctx) error { return guardAchievement(c, "verify") }
+
+func guardAchievement(c *fiber.Ctx, action string) error {
+	claims, ok := c.Locals("user").(*model.JWTClaims)
+	if !ok {
+		return c.Status(403).JSON(fiber.Map{
+			"error": "Unauthorized: invalid token claims",
+		})
+	}
+
+	ref, err := repository.GetAchievementReferenceByID(c.Params("id"))
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Achievement tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data achievement"})
+	}
+
+	allowed, err := policy.CanAccessAchievement(claims, action, ref)
+	if err != nil {
+		if repository.IsNoRows(err) {
+			return c.Status(404).JSON(fiber.Map{"error": "Data mahasiswa tidak ditemukan"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengecek hak akses"})
+	}
+	if !allowed {
+		return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses prestasi ini"})
+	}
+
+	c.Locals("achievement", ref)
+	return c.Next()
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+    "prestasi_backend/app/repository"
     "prestasi_backend/utils"
     "github.com/gofiber/fiber/v2"
 )
@@ -24,6 +25,50 @@ func JWTRequired() fiber.Handler {
             })
         }
 
+        // Tolak token yang sudah dicabut (logout)
+        if userClaims.ID != "" {
+            revoked, err := repository.IsAccessTokenRevoked(userClaims.ID)
+            if err != nil {
+                return c.Status(500).JSON(fiber.Map{
+                    "error": "Gagal memeriksa status token",
+                })
+            }
+            if revoked {
+                return c.Status(401).JSON(fiber.Map{
+                    "error": "Token sudah dicabut, silakan login ulang",
+                })
+            }
+        }
+
+        // Tolak token dari sesi yang family refresh token-nya sudah dicabut
+        // (logout atau deteksi reuse), walau jti-nya sendiri belum dicabut
+        if userClaims.SessionID != "" {
+            revoked, err := familyRevoked(userClaims.SessionID)
+            if err != nil {
+                return c.Status(500).JSON(fiber.Map{
+                    "error": "Gagal memeriksa status sesi",
+                })
+            }
+            if revoked {
+                return c.Status(401).JSON(fiber.Map{
+                    "error": "Sesi sudah dicabut, silakan login ulang",
+                })
+            }
+        }
+
+        // Tolak token milik user yang dihapus, dinonaktifkan, atau ganti role
+        valid, err := sessionValid(userClaims)
+        if err != nil {
+            return c.Status(500).JSON(fiber.Map{
+                "error": "Gagal memeriksa status sesi",
+            })
+        }
+        if !valid {
+            return c.Status(401).JSON(fiber.Map{
+                "error": "Sesi tidak berlaku lagi, silakan login ulang",
+            })
+        }
+
         // Simpan ke context (FULL claims)
         c.Locals("user", userClaims)
 
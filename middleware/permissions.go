@@ -2,10 +2,13 @@ package middleware
 
 import (
     "prestasi_backend/app/model"
+    "prestasi_backend/app/policy"
     "github.com/gofiber/fiber/v2"
 )
 
-// PermissionRequired memastikan user punya izin tertentu
+// PermissionRequired memastikan user punya izin tertentu.
+// Izin "resource:action" juga terpenuhi oleh varian ber-scope
+// seperti "resource:action:own" atau "resource:action:all".
 func PermissionRequired(required string) fiber.Handler {
     return func(c *fiber.Ctx) error {
 
@@ -17,18 +20,13 @@ func PermissionRequired(required string) fiber.Handler {
             })
         }
 
-        // ambil permission list dari token
-        userPerms := claims.Permissions
-
-        // cek apakah permission sesuai
-        for _, p := range userPerms {
-            if p == required {
-                return c.Next()
-            }
+        // cek permission lewat policy layer
+        if policy.HasPermission(claims, required) {
+            return c.Next()
         }
 
         return c.Status(403).JSON(fiber.Map{
             "error": "Forbidden: missing permission (" + required + ")",
         })
     }
-}
\ No newline at end of file
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+    "sync"
+    "time"
+
+    "prestasi_backend/app/model"
+    "prestasi_backend/app/repository"
+)
+
+// Status sesi user di-cache sebentar supaya tidak query DB di setiap request.
+// Perubahan (nonaktif, hapus, ganti role) tetap berlaku paling lambat
+// setelah SessionCacheTTL.
+const SessionCacheTTL = 5 * time.Second
+
+type sessionState struct {
+    exists    bool
+    active    bool
+    version   int
+    fetchedAt time.Time
+}
+
+var sessionCache sync.Map
+
+// sessionValid mengecek apakah user pemilik token masih ada, aktif,
+// dan token_version-nya sama dengan yang tercatat di token.
+func sessionValid(claims *model.JWTClaims) (bool, error) {
+    state, err := loadSessionState(claims.UserID)
+    if err != nil {
+        return false, err
+    }
+    return state.exists && state.active && state.version == claims.TokenVersion, nil
+}
+
+func loadSessionState(userID string) (sessionState, error) {
+    if cached, ok := sessionCache.Load(userID); ok {
+        state := cached.(sessionState)
+        if time.Since(state.fetchedAt) < SessionCacheTTL {
+            return state, nil
+        }
+    }
+
+    active, version, err := repository.GetUserTokenState(userID)
+    state := sessionState{exists: true, active: active, version: version, fetchedAt: time.Now()}
+    if err != nil {
+        if !repository.IsNoRows(err) {
+            return sessionState{}, err
+        }
+        state = sessionState{exists: false, fetchedAt: time.Now()}
+    }
+
+    sessionCache.Store(userID, state)
+    return state, nil
+}
+
+// Status pencabutan family refresh token (sid) di-cache dengan pola yang sama.
+// Family yang sudah dicabut tidak bisa aktif lagi, jadi hasil revoked disimpan
+// tanpa batas waktu; hanya hasil "belum dicabut" yang kedaluwarsa.
+type familyState struct {
+    revoked   bool
+    fetchedAt time.Time
+}
+
+var familyCache sync.Map
+
+// familyRevoked mengecek apakah sesi login (family refresh token) sudah dicabut,
+// misalnya karena logout atau deteksi pemakaian ulang refresh token.
+func familyRevoked(familyID string) (bool, error) {
+    if cached, ok := familyCache.Load(familyID); ok {
+        state := cached.(familyState)
+        if state.revoked || time.Since(state.fetchedAt) < SessionCacheTTL {
+            return state.revoked, nil
+        }
+    }
+
+    revoked, err := repository.IsRefreshTokenFamilyRevoked(familyID)
+    if err != nil {
+        return false, err
+    }
+
+    familyCache.Store(familyID, familyState{revoked: revoked, fetchedAt: time.Now()})
+    return revoked, nil
+}
@@ -16,7 +16,7 @@ func SetupRoutes(app *fiber.App) {
 	// 5.1 AUTHENTICATION
 	api.Post("/auth/login", service.AuthLogin)
 	api.Post("/auth/logout", middleware.JWTRequired(), service.AuthLogout)
-	api.Post("/auth/refresh", middleware.JWTRequired(), service.AuthRefresh)
+	api.Post("/auth/refresh", service.AuthRefresh)
 	api.Get("/auth/profile", middleware.JWTRequired(), service.AuthProfile)
 
 	// 5.2 USERS (Admin Only)
@@ -29,22 +29,90 @@ func SetupRoutes(app *fiber.App) {
 	users.Delete("/:id", middleware.PermissionRequired("user:manage"), service.UserDelete)
 	users.Put("/:id/role", middleware.PermissionRequired("user:manage"), service.UserUpdateRole)
 
+	// IMPOR MASSAL MAHASISWA / DOSEN (Admin Only)
+	imports := api.Group("/imports", middleware.JWTRequired(), middleware.PermissionRequired("user:manage"))
+	imports.Post("/:kind", service.UserImport)
+
+	// 5.3 ROLES & PERMISSIONS (Admin Only)
+	roles := api.Group("/roles", middleware.JWTRequired(), middleware.PermissionRequired("role:manage"))
+
+	roles.Get("/", service.RoleList)
+	roles.Get("/:id", service.RoleDetail)
+	roles.Post("/", service.RoleCreate)
+	roles.Put("/:id", service.RoleUpdate)
+	roles.Delete("/:id", service.RoleDelete)
+	roles.Get("/:id/permissions", service.RolePermissionList)
+	roles.Post("/:id/permissions", service.RolePermissionAdd)
+	roles.Delete("/:id/permissions/:permissionId", service.RolePermissionRemove)
+
+	perms := api.Group("/permissions", middleware.JWTRequired(), middleware.PermissionRequired("role:manage"))
+
+	perms.Get("/", service.PermissionList)
+	perms.Get("/:id", service.PermissionDetail)
+	perms.Post("/", service.PermissionCreate)
+	perms.Put("/:id", service.PermissionUpdate)
+	perms.Delete("/:id", service.PermissionDelete)
+
+	// ACHIEVEMENT TYPES (skema details per jenis prestasi)
+	types := api.Group("/achievement-types", middleware.JWTRequired())
+	types.Get("/", service.AchievementTypeList)
+	types.Get("/:code", service.AchievementTypeDetail)
+	types.Post("/", middleware.PermissionRequired("achievement_type:manage"), service.AchievementTypeCreate)
+	types.Put("/:code", middleware.PermissionRequired("achievement_type:manage"), service.AchievementTypeUpdate)
+	types.Delete("/:code", middleware.PermissionRequired("achievement_type:manage"), service.AchievementTypeDelete)
+
+	// SCORING RULES (Admin Only)
+	scoring := api.Group("/scoring-rules", middleware.JWTRequired(), middleware.PermissionRequired("scoring:manage"))
+	scoring.Get("/", service.ScoringRuleList)
+	scoring.Post("/", service.ScoringRuleCreate)
+	scoring.Get("/:version", service.ScoringRuleDetail)
+	scoring.Get("/:version/preview", service.ScoringRulePreview)
+	scoring.Post("/:version/activate", service.ScoringRuleActivate)
+
+	// MAINTENANCE (Admin Only)
+	maint := api.Group("/maintenance", middleware.JWTRequired(), middleware.PermissionRequired("maintenance:manage"))
+	maint.Get("/reconcile", service.ReconcileReport)
+	maint.Post("/reconcile", service.ReconcileRepair)
+
 	// 5.4 ACHIEVEMENTS
+	// Route /:id* dijaga AchievementAccess: pemilik, anggota tim, dosen wali, departemen, atau scope all
 	ach := api.Group("/achievements", middleware.JWTRequired())
 
 	ach.Get("/", service.AchievementList)
-	ach.Get("/:id", service.AchievementDetail)
+	ach.Get("/search", service.AchievementSearch)
+	ach.Get("/:id", middleware.AchievementAccess("read"), service.AchievementDetail)
 
 	ach.Post("/", middleware.PermissionRequired("achievement:create"), service.AchievementCreate)
-	ach.Put("/:id", middleware.PermissionRequired("achievement:update"), service.AchievementUpdate)
-	ach.Delete("/:id", middleware.PermissionRequired("achievement:delete"), service.AchievementDelete)
-
-	ach.Post("/:id/submit", middleware.PermissionRequired("achievement:update"), service.AchievementSubmit)
-	ach.Post("/:id/verify", middleware.PermissionRequired("achievement:verify"), service.AchievementVerify)
-	ach.Post("/:id/reject", middleware.PermissionRequired("achievement:verify"), service.AchievementReject)
-
-	ach.Get("/:id/history", service.AchievementHistory)
-	ach.Post("/:id/attachments", middleware.PermissionRequired("achievement:update"), service.AchievementUploadAttachment)
+	ach.Put("/:id", middleware.PermissionRequired("achievement:update"), middleware.AchievementAccess("update"), service.AchievementUpdate)
+	ach.Delete("/:id", middleware.PermissionRequired("achievement:delete"), middleware.AchievementAccess("delete"), service.AchievementDelete)
+
+	ach.Post("/:id/submit", middleware.PermissionRequired("achievement:update"), middleware.AchievementAccess("update"), service.AchievementSubmit)
+	ach.Post("/:id/verify", middleware.PermissionRequired("achievement:verify"), middleware.AchievementAccess("verify"), service.AchievementVerify)
+	ach.Post("/:id/reject", middleware.PermissionRequired("achievement:verify"), middleware.AchievementAccess("verify"), service.AchievementReject)
+	ach.Post("/batch-review", middleware.PermissionRequired("achievement:verify"), service.AchievementBatchReview)
+	ach.Post("/:id/revise", middleware.PermissionRequired("achievement:update"), middleware.AchievementAccess("update"), service.AchievementRevise)
+
+	ach.Get("/:id/history", middleware.AchievementAccess("read"), service.AchievementHistory)
+	ach.Get("/:id/reviews", middleware.AchievementAccess("read"), service.AchievementReviews)
+	ach.Get("/:id/diff", middleware.AchievementAccess("read"), service.AchievementDiff)
+	ach.Get("/:id/comments", middleware.AchievementAccess("read"), service.AchievementComments)
+	ach.Post("/:id/comments", middleware.AchievementAccess("read"), service.AchievementCommentCreate)
+	ach.Post("/:id/comments/:commentId/resolve", middleware.AchievementAccess("read"), service.AchievementCommentResolve)
+	ach.Get("/:id/duplicates", middleware.AchievementAccess("read"), service.AchievementDuplicates)
+	ach.Get("/:id/members", middleware.AchievementAccess("read"), service.AchievementMembers)
+	ach.Put("/:id/members", middleware.PermissionRequired("achievement:update"), middleware.AchievementAccess("update"), service.AchievementMembersUpdate)
+	ach.Post("/:id/members/respond", middleware.AchievementAccess("read"), service.AchievementMemberRespond)
+	ach.Post("/:id/members/:studentId/verify", middleware.PermissionRequired("achievement:verify"), middleware.AchievementAccess("verify"), service.AchievementMemberVerify)
+	ach.Get("/:id/attachments", middleware.AchievementAccess("read"), service.AchievementAttachmentList)
+	ach.Post("/:id/attachments", middleware.PermissionRequired("achievement:update"), middleware.AchievementAccess("update"), service.AchievementUploadAttachment)
+	ach.Get("/:id/attachments/:attachmentId", middleware.AchievementAccess("read"), service.AchievementAttachmentDownload)
+	ach.Get("/:id/attachments/:attachmentId/url", middleware.AchievementAccess("read"), service.AchievementAttachmentURL)
+	ach.Get("/:id/attachments/:attachmentId/preview", middleware.AchievementAccess("read"), service.AchievementAttachmentPreview)
+	ach.Delete("/:id/attachments/:attachmentId", middleware.PermissionRequired("achievement:update"), middleware.AchievementAccess("update"), service.AchievementAttachmentDelete)
+
+	// Unduhan via URL bertanda tangan (tanpa JWT, untuk <img>/<iframe>)
+	api.Get("/files/achievements/:id/attachments/:attachmentId", service.AttachmentSignedDownload)
+	api.Get("/files/achievements/:id/attachments/:attachmentId/preview", service.AttachmentSignedPreview)
 
 	// 5.5 STUDENTS
 	students := api.Group("/students", middleware.JWTRequired())
@@ -52,13 +120,23 @@ func SetupRoutes(app *fiber.App) {
 	students.Get("/", service.StudentList)
 	students.Get("/:id", service.StudentDetail)
 	students.Get("/:id/achievements", service.StudentAchievements)
+	students.Post("/", middleware.PermissionRequired("user:manage"), service.StudentCreate)
+	students.Put("/:id", middleware.PermissionRequired("user:manage"), service.StudentUpdate)
+	students.Post("/:id/deactivate", middleware.PermissionRequired("user:manage"), service.StudentDeactivate)
+	students.Post("/:id/activate", middleware.PermissionRequired("user:manage"), service.StudentActivate)
 	students.Put("/:id/advisor", middleware.PermissionRequired("user:manage"), service.StudentSetAdvisor)
+	students.Get("/:id/advisors", service.StudentAdvisorHistory)
 
 	// 5.5 LECTURERS
 	lect := api.Group("/lecturers", middleware.JWTRequired())
 
 	lect.Get("/", service.LecturerList)
 	lect.Get("/:id/advisees", service.LecturerAdvisees)
+	lect.Post("/", middleware.PermissionRequired("user:manage"), service.LecturerCreate)
+	lect.Put("/:id", middleware.PermissionRequired("user:manage"), service.LecturerUpdate)
+	lect.Post("/:id/deactivate", middleware.PermissionRequired("user:manage"), service.LecturerDeactivate)
+	lect.Post("/:id/activate", middleware.PermissionRequired("user:manage"), service.LecturerActivate)
+	lect.Post("/:id/reassign-advisees", middleware.PermissionRequired("user:manage"), service.LecturerReassignAdvisees)
 
 	// 5.8 REPORTS
 	reports := api.Group("/reports", middleware.JWTRequired())
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrRangeNotSatisfiable dikembalikan untuk header Range di luar ukuran file (HTTP 416)
+var ErrRangeNotSatisfiable = errors.New("range tidak dapat dipenuhi")
+
+// ParseRange membaca header Range satu rentang ("bytes=a-b", "bytes=a-", "bytes=-n")
+// untuk file berukuran size. ok=false berarti header kosong atau tidak didukung
+// (mis. multi-range) sehingga seluruh file dikirim.
+func ParseRange(header string, size int64) (offset, length int64, ok bool, err error) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, size, false, nil
+	}
+
+	startStr, endStr, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, size, false, nil
+	}
+
+	if startStr == "" {
+		// suffix range: n byte terakhir
+		n, perr := strconv.ParseInt(endStr, 10, 64)
+		if perr != nil || n <= 0 {
+			return 0, 0, false, ErrRangeNotSatisfiable
+		}
+		n = min(n, size)
+		return size - n, n, true, nil
+	}
+
+	start, perr := strconv.ParseInt(startStr, 10, 64)
+	if perr != nil || start < 0 || start >= size {
+		return 0, 0, false, ErrRangeNotSatisfiable
+	}
+
+	end := size - 1
+	if endStr != "" {
+		e, perr := strconv.ParseInt(endStr, 10, 64)
+		if perr != nil || e < start {
+			return 0, 0, false, ErrRangeNotSatisfiable
+		}
+		end = min(e, size-1)
+	}
+
+	return start, end - start + 1, true, nil
+}
@@ -0,0 +1,69 @@
+// Package storage menyimpan file lampiran prestasi di balik satu interface
+// sehingga aplikasi tidak terikat pada disk satu server.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"prestasi_backend/config"
+)
+
+// ErrNotFound dikembalikan saat key tidak ada di backend
+var ErrNotFound = errors.New("file tidak ditemukan di storage")
+
+// ErrInvalidKey dikembalikan untuk key kosong, absolut, atau mengandung ".."
+var ErrInvalidKey = errors.New("key storage tidak valid")
+
+// Backend adalah tempat penyimpanan file lampiran.
+// Key selalu dibuat oleh server (UUID), bukan dari nama file klien.
+type Backend interface {
+	// Put menyimpan isi r sebanyak size byte pada key
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get membaca mulai offset sepanjang length byte; length < 0 berarti sampai akhir
+	Get(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Delete menghapus key; key yang sudah tidak ada bukan error
+	Delete(ctx context.Context, key string) error
+}
+
+// Default adalah backend yang dipakai aplikasi, di-set di main.go
+var Default Backend
+
+// FromEnv membuat backend sesuai STORAGE_BACKEND (local | s3)
+func FromEnv() (Backend, error) {
+	switch config.Get("STORAGE_BACKEND") {
+	case "", "local":
+		root := config.Get("STORAGE_LOCAL_ROOT")
+		if root == "" {
+			root = "./uploads"
+		}
+		return NewLocal(root)
+
+	case "s3":
+		return NewS3(S3Config{
+			Endpoint:  config.Get("S3_ENDPOINT"),
+			Region:    config.Get("S3_REGION"),
+			Bucket:    config.Get("S3_BUCKET"),
+			AccessKey: config.Get("S3_ACCESS_KEY"),
+			SecretKey: config.Get("S3_SECRET_KEY"),
+			PathStyle: config.Get("S3_PATH_STYLE") != "false",
+		})
+	}
+	return nil, errors.New("STORAGE_BACKEND tidak dikenal: " + config.Get("STORAGE_BACKEND"))
+}
+
+// cleanKey menolak key yang bisa keluar dari root (path traversal)
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	return path.Clean(key), nil
+}
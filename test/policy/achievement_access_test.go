@@ -0,0 +1,241 @@
+package policy
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/middleware"
+	"prestasi_backend/route"
+	"prestasi_backend/test/repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// achievementRouteActions adalah action guard yang diharapkan untuk setiap route /achievements/:id*
+var achievementRouteActions = map[string]string{
+	"GET /api/v1/achievements/:id":                                   "read",
+	"PUT /api/v1/achievements/:id":                                   "update",
+	"DELETE /api/v1/achievements/:id":                                "delete",
+	"POST /api/v1/achievements/:id/submit":                           "update",
+	"POST /api/v1/achievements/:id/verify":                           "verify",
+	"POST /api/v1/achievements/:id/reject":                           "verify",
+	"POST /api/v1/achievements/:id/revise":                           "update",
+	"GET /api/v1/achievements/:id/history":                           "read",
+	"GET /api/v1/achievements/:id/reviews":                           "read",
+	"GET /api/v1/achievements/:id/diff":                              "read",
+	"GET /api/v1/achievements/:id/comments":                          "read",
+	"POST /api/v1/achievements/:id/comments":                         "read",
+	"POST /api/v1/achievements/:id/comments/:commentId/resolve":      "read",
+	"GET /api/v1/achievements/:id/duplicates":                        "read",
+	"GET /api/v1/achievements/:id/members":                           "read",
+	"PUT /api/v1/achievements/:id/members":                           "update",
+	"POST /api/v1/achievements/:id/members/respond":                  "read",
+	"POST /api/v1/achievements/:id/members/:studentId/verify":        "verify",
+	"GET /api/v1/achievements/:id/attachments":                       "read",
+	"POST /api/v1/achievements/:id/attachments":                      "update",
+	"GET /api/v1/achievements/:id/attachments/:attachmentId":         "read",
+	"GET /api/v1/achievements/:id/attachments/:attachmentId/url":     "read",
+	"GET /api/v1/achievements/:id/attachments/:attachmentId/preview": "read",
+	"DELETE /api/v1/achievements/:id/attachments/:attachmentId":      "update",
+}
+
+func guardAction(h fiber.Handler) string {
+	ptr := reflect.ValueOf(h).Pointer()
+	for _, action := range []string{"read", "update", "delete", "verify"} {
+		if reflect.ValueOf(middleware.AchievementAccess(action)).Pointer() == ptr {
+			return action
+		}
+	}
+	return ""
+}
+
+func TestAchievementRoutes_AllGuarded(t *testing.T) {
+	app := fiber.New()
+	route.SetupRoutes(app)
+
+	seen := map[string]bool{}
+	for _, r := range app.GetRoutes(true) {
+		if !strings.HasPrefix(r.Path, "/api/v1/achievements/:id") || r.Method == fiber.MethodHead {
+			continue
+		}
+		key := r.Method + " " + r.Path
+		seen[key] = true
+
+		want, ok := achievementRouteActions[key]
+		if !ok {
+			t.Errorf("Route %s belum ada di matrix guard", key)
+			continue
+		}
+
+		got := ""
+		for _, h := range r.Handlers {
+			if a := guardAction(h); a != "" {
+				got = a
+				break
+			}
+		}
+		if got != want {
+			t.Errorf("Route %s harusnya dijaga %q, dapat %q", key, want, got)
+		}
+	}
+
+	for key := range achievementRouteActions {
+		if !seen[key] {
+			t.Errorf("Route %s tidak terdaftar", key)
+		}
+	}
+}
+
+// Prestasi "team" dimiliki s-owner (wali lect-advisor) dengan anggota s-member
+// (wali lect-member-advisor); s-other dan lect-other tidak terkait.
+func setupAchievementAccessMocks() {
+	repo.MockGetAchievementReferenceByID(map[string]*model.AchievementReference{
+		"team":     {ID: "team", StudentID: "s-owner", Status: model.StatusSubmitted},
+		"orphaned": {ID: "orphaned", StudentID: "s-hilang", Status: model.StatusDraft},
+	})
+	repo.MockGetStudentByID(map[string]*model.Student{
+		"s-owner":  {ID: "s-owner", UserID: "u-owner", ProgramStudy: "Informatika"},
+		"s-member": {ID: "s-member", UserID: "u-member", ProgramStudy: "Sistem Informasi"},
+		"s-other":  {ID: "s-other", UserID: "u-other", ProgramStudy: "Informatika"},
+	})
+	repo.MockGetLecturersByUserID(map[string]*model.Lecturer{
+		"u-advisor":        {ID: "lect-advisor"},
+		"u-member-advisor": {ID: "lect-member-advisor"},
+		"u-lect-other":     {ID: "lect-other"},
+		"u-kaprodi":        {ID: "lect-kaprodi", Department: "informatika"},
+	})
+	repo.MockIsStudentAdvisedBy(map[string]string{
+		"s-owner":  "lect-advisor",
+		"s-member": "lect-member-advisor",
+		"s-other":  "lect-other",
+	})
+	repo.MockGetAchievementMembers(map[string][]model.AchievementMember{
+		"team": {
+			{StudentID: "s-owner", Role: model.MemberRoleLeader, Status: model.MemberConfirmed},
+			{StudentID: "s-member", Role: model.MemberRoleMember, Status: model.MemberConfirmed},
+			{StudentID: "s-declined", Role: model.MemberRoleMember, Status: model.MemberDeclined},
+		},
+	})
+}
+
+func accessApp(claims *model.JWTClaims) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user", claims)
+		return c.Next()
+	})
+	ok := func(c *fiber.Ctx) error {
+		if _, loaded := c.Locals("achievement").(*model.AchievementReference); !loaded {
+			return c.SendStatus(500)
+		}
+		return c.SendStatus(200)
+	}
+	for _, action := range []string{"read", "update", "delete", "verify"} {
+		app.Get("/"+action+"/:id", middleware.AchievementAccess(action), ok)
+	}
+	return app
+}
+
+var (
+	adminPerms    = []string{"achievement:read:all", "achievement:update:all", "achievement:delete:all"}
+	advisorPerms  = []string{"achievement:read:advisees", "achievement:verify"}
+	kaprodiPerms  = []string{"achievement:read:department"}
+	mahasiswaPerm = []string{"achievement:read:own", "achievement:update:own", "achievement:delete:own"}
+)
+
+func TestAchievementAccess_RoleMatrix(t *testing.T) {
+	setupAchievementAccessMocks()
+
+	cases := []struct {
+		name   string
+		claims *model.JWTClaims
+		want   map[string]int
+	}{
+		{"admin", &model.JWTClaims{UserID: "u-admin", Permissions: adminPerms},
+			map[string]int{"read": 200, "update": 200, "delete": 200, "verify": 403}},
+		{"dosen wali pemilik", &model.JWTClaims{UserID: "u-advisor", Permissions: advisorPerms},
+			map[string]int{"read": 200, "update": 403, "delete": 403, "verify": 200}},
+		{"dosen wali lain", &model.JWTClaims{UserID: "u-lect-other", Permissions: advisorPerms},
+			map[string]int{"read": 403, "update": 403, "delete": 403, "verify": 403}},
+		{"dosen satu departemen", &model.JWTClaims{UserID: "u-kaprodi", Permissions: kaprodiPerms},
+			map[string]int{"read": 200, "update": 403, "delete": 403, "verify": 403}},
+		{"mahasiswa pemilik", &model.JWTClaims{UserID: "u-owner", Permissions: mahasiswaPerm},
+			map[string]int{"read": 200, "update": 200, "delete": 200, "verify": 403}},
+		{"mahasiswa lain", &model.JWTClaims{UserID: "u-other", Permissions: mahasiswaPerm},
+			map[string]int{"read": 403, "update": 403, "delete": 403, "verify": 403}},
+		{"anggota tim", &model.JWTClaims{UserID: "u-member", Permissions: mahasiswaPerm},
+			map[string]int{"read": 200, "update": 403, "delete": 403, "verify": 403}},
+		{"dosen wali anggota tim", &model.JWTClaims{UserID: "u-member-advisor", Permissions: advisorPerms},
+			map[string]int{"read": 200, "update": 403, "delete": 403, "verify": 200}},
+	}
+
+	for _, tc := range cases {
+		app := accessApp(tc.claims)
+		for action, want := range tc.want {
+			resp, err := app.Test(httptest.NewRequest("GET", "/"+action+"/team", nil))
+			if err != nil {
+				t.Fatalf("%s/%s: %v", tc.name, action, err)
+			}
+			if resp.StatusCode != want {
+				t.Errorf("%s/%s: harusnya %d, dapat %d", tc.name, action, want, resp.StatusCode)
+			}
+		}
+	}
+}
+
+func TestAchievementAccess_NotFound(t *testing.T) {
+	setupAchievementAccessMocks()
+	app := accessApp(&model.JWTClaims{UserID: "u-owner", Permissions: mahasiswaPerm})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/read/tidak-ada", nil))
+	if resp.StatusCode != 404 {
+		t.Errorf("Achievement tidak ada harusnya 404, dapat %d", resp.StatusCode)
+	}
+
+	// pemilik reference sudah tidak ada di tabel students
+	resp, _ = app.Test(httptest.NewRequest("GET", "/read/orphaned", nil))
+	if resp.StatusCode != 404 {
+		t.Errorf("Mahasiswa pemilik hilang harusnya 404, dapat %d", resp.StatusCode)
+	}
+}
+
+func TestAchievementAccess_LookupError(t *testing.T) {
+	setupAchievementAccessMocks()
+	repository.GetAchievementReferenceByID = func(id string) (*model.AchievementReference, error) {
+		return nil, errors.New("koneksi terputus")
+	}
+	app := accessApp(&model.JWTClaims{UserID: "u-owner", Permissions: mahasiswaPerm})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/read/team", nil))
+	if resp.StatusCode != 500 {
+		t.Errorf("Error database harus 500, bukan 404; dapat %d", resp.StatusCode)
+	}
+}
+
+func TestAchievementAccess_KeptReviewer(t *testing.T) {
+	setupAchievementAccessMocks()
+	reviewer := "lect-other"
+	repo.MockGetAchievementReferenceByID(map[string]*model.AchievementReference{
+		"kept": {ID: "kept", StudentID: "s-owner", Status: model.StatusSubmitted, ReviewerID: &reviewer},
+	})
+
+	// dosen wali lama (bukan wali s-owner lagi) yang mempertahankan pengajuan
+	app := accessApp(&model.JWTClaims{UserID: "u-lect-other", Permissions: advisorPerms})
+	for action, want := range map[string]int{"read": 200, "verify": 200, "update": 403, "delete": 403} {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/"+action+"/kept", nil))
+		if resp.StatusCode != want {
+			t.Errorf("%s: harusnya %d, dapat %d", action, want, resp.StatusCode)
+		}
+	}
+
+	// mahasiswa tanpa profil dosen tetap diperiksa sebagai pemilik
+	app = accessApp(&model.JWTClaims{UserID: "u-owner", Permissions: mahasiswaPerm})
+	if resp, _ := app.Test(httptest.NewRequest("GET", "/read/kept", nil)); resp.StatusCode != 200 {
+		t.Errorf("Pemilik harusnya 200, dapat %d", resp.StatusCode)
+	}
+}
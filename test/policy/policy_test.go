@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"database/sql"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/policy"
+	"prestasi_backend/test/repo"
+)
+
+func TestCan_PicksWidestScope(t *testing.T) {
+	claims := &model.JWTClaims{
+		UserID: "user-1",
+		Permissions: []string{
+			"achievement:read",
+			"achievement:read:advisees",
+			"achievement:read:department",
+			"student:read:all",
+		},
+	}
+
+	if got := policy.Can(claims, "read", "achievement"); got != policy.ScopeDepartment {
+		t.Errorf("Harusnya department, dapat %s", got)
+	}
+	if got := policy.Can(claims, "read", "student"); got != policy.ScopeAll {
+		t.Errorf("Harusnya all, dapat %s", got)
+	}
+	if got := policy.Can(claims, "delete", "achievement"); got != policy.ScopeNone {
+		t.Errorf("Harusnya none, dapat %s", got)
+	}
+}
+
+func TestCan_UnscopedPermissionIsOwn(t *testing.T) {
+	claims := &model.JWTClaims{Permissions: []string{"achievement:update"}}
+
+	if got := policy.Can(claims, "update", "achievement"); got != policy.ScopeOwn {
+		t.Errorf("Permission tanpa scope harusnya own, dapat %s", got)
+	}
+	if !policy.HasPermission(claims, "achievement:update") {
+		t.Error("HasPermission harusnya true")
+	}
+}
+
+func TestCan_UnknownScopeIgnored(t *testing.T) {
+	claims := &model.JWTClaims{Permissions: []string{"achievement:read:galaxy"}}
+
+	if got := policy.Can(claims, "read", "achievement"); got != policy.ScopeNone {
+		t.Errorf("Scope tidak dikenal harusnya diabaikan, dapat %s", got)
+	}
+}
+
+func TestCanAccessStudent_Own(t *testing.T) {
+	claims := &model.JWTClaims{UserID: "user-mhs", Permissions: []string{"achievement:read:own"}}
+
+	mine := &model.Student{ID: "s1", UserID: "user-mhs"}
+	other := &model.Student{ID: "s2", UserID: "user-lain"}
+
+	if ok, _ := policy.CanAccessStudent(claims, "read", "achievement", mine); !ok {
+		t.Error("Harusnya boleh akses data sendiri")
+	}
+	if ok, _ := policy.CanAccessStudent(claims, "read", "achievement", other); ok {
+		t.Error("Tidak boleh akses data mahasiswa lain")
+	}
+}
+
+func TestCanAccessStudent_Advisees(t *testing.T) {
+	claims := &model.JWTClaims{UserID: "user-dosen", Permissions: []string{"achievement:read:advisees"}}
+	repo.MockGetLecturerByUserID(&model.Lecturer{ID: "lect-1", UserID: "user-dosen", Department: "Teknik Informatika"}, nil)
+
+	advisee := &model.Student{ID: "s1", AdvisorID: "lect-1", ProgramStudy: "Teknik Informatika"}
+	sameDept := &model.Student{ID: "s2", AdvisorID: "lect-2", ProgramStudy: "Teknik Informatika"}
+
+	if ok, _ := policy.CanAccessStudent(claims, "read", "achievement", advisee); !ok {
+		t.Error("Harusnya boleh akses mahasiswa bimbingan")
+	}
+	if ok, _ := policy.CanAccessStudent(claims, "read", "achievement", sameDept); ok {
+		t.Error("Scope advisees tidak boleh akses mahasiswa non-bimbingan")
+	}
+}
+
+func TestCanAccessStudent_Department(t *testing.T) {
+	claims := &model.JWTClaims{UserID: "user-kaprodi", Permissions: []string{"achievement:read:department"}}
+	repo.MockGetLecturerByUserID(&model.Lecturer{ID: "lect-9", UserID: "user-kaprodi", Department: "Teknik Informatika"}, nil)
+
+	sameDept := &model.Student{ID: "s2", AdvisorID: "lect-2", ProgramStudy: "teknik informatika"}
+	otherDept := &model.Student{ID: "s3", AdvisorID: "lect-3", ProgramStudy: "Sistem Informasi"}
+
+	if ok, _ := policy.CanAccessStudent(claims, "read", "achievement", sameDept); !ok {
+		t.Error("Harusnya boleh akses mahasiswa satu program studi")
+	}
+	if ok, _ := policy.CanAccessStudent(claims, "read", "achievement", otherDept); ok {
+		t.Error("Tidak boleh akses mahasiswa program studi lain")
+	}
+}
+
+func TestCanAccessStudent_NotALecturer(t *testing.T) {
+	claims := &model.JWTClaims{UserID: "user-x", Permissions: []string{"achievement:read:advisees"}}
+	repo.MockGetLecturerByUserID(nil, sql.ErrNoRows)
+
+	student := &model.Student{ID: "s1", AdvisorID: "lect-1"}
+
+	ok, err := policy.CanAccessStudent(claims, "read", "achievement", student)
+	if err != nil {
+		t.Fatalf("Tidak boleh error: %v", err)
+	}
+	if ok {
+		t.Error("User tanpa profil dosen tidak boleh akses advisees")
+	}
+}
+
+func TestCanHoldProfile_FromPermissions(t *testing.T) {
+	cases := []struct {
+		name     string
+		perms    []string
+		student  bool
+		lecturer bool
+	}{
+		{"pelapor prestasi (nama role apa pun)", []string{"achievement:create", "achievement:read:own"}, true, false},
+		{"peninjau prestasi, mis. Kaprodi", []string{"achievement:verify", "achievement:read:department"}, false, true},
+		{"admin membuat atas nama mahasiswa", []string{"achievement:create", "achievement:create:all"}, false, false},
+		{"tanpa permission prestasi", []string{"user:manage"}, false, false},
+	}
+	for _, tc := range cases {
+		if got := policy.CanHoldProfile(tc.perms, policy.ProfileStudent); got != tc.student {
+			t.Errorf("%s: profil mahasiswa harusnya %v, dapat %v", tc.name, tc.student, got)
+		}
+		if got := policy.CanHoldProfile(tc.perms, policy.ProfileLecturer); got != tc.lecturer {
+			t.Errorf("%s: profil dosen harusnya %v, dapat %v", tc.name, tc.lecturer, got)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package policy
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/middleware"
+	"prestasi_backend/test/repo"
+	"prestasi_backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// sessionApp: satu route yang hanya dijaga JWTRequired
+func sessionApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/ping", middleware.JWTRequired(), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	return app
+}
+
+// signAccessToken menerbitkan access token untuk user dengan jti baru
+func signAccessToken(t *testing.T, userID, sessionID string, version int) (string, string) {
+	t.Helper()
+	jti := uuid.NewString()
+	token, err := utils.GenerateToken(model.JWTClaims{
+		UserID:       userID,
+		SessionID:    sessionID,
+		TokenVersion: version,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(utils.AccessTokenTTL)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Gagal membuat token: %v", err)
+	}
+	return token, jti
+}
+
+func ping(app *fiber.App, token string) int {
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	if err != nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+func TestJWTRequired_RevokedFamily(t *testing.T) {
+	// ID unik per test karena status sesi & family di-cache di middleware
+	userID, family, otherFamily := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	store := repo.NewTokenStore()
+	active := store.Add("rt-aktif", userID, otherFamily)
+	store.Add("rt-dicuri", userID, family)
+	repo.MockTokenStore(store)
+
+	// rotasi biasa menandai token lama revoked, tetapi family-nya tetap aktif
+	repository.RotateRefreshToken(active.ID, &model.RefreshToken{
+		ID: uuid.NewString(), UserID: userID, TokenHash: utils.HashToken("rt-aktif-2"), FamilyID: otherFamily,
+	})
+	repo.MockGetUserTokenState(map[string]repo.UserTokenState{userID: {Active: true}}, nil)
+
+	// deteksi reuse mencabut family; jti token ini sendiri tidak pernah dicabut
+	repository.RevokeRefreshTokenFamily(family)
+
+	app := sessionApp()
+	token, jti := signAccessToken(t, userID, family, 0)
+	if status := ping(app, token); status != 401 {
+		t.Errorf("Token dari family yang dicabut harusnya 401, dapat %d", status)
+	}
+	if store.RevokedJTI[jti] {
+		t.Fatal("Test ini harus menguji family, bukan jti yang dicabut")
+	}
+
+	other, _ := signAccessToken(t, userID, otherFamily, 0)
+	if status := ping(app, other); status != 200 {
+		t.Errorf("Sesi lain milik user yang sama tidak boleh ikut dicabut, dapat %d", status)
+	}
+}
+
+func TestJWTRequired_SessionState(t *testing.T) {
+	current, inactive, stale, deleted := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	repo.MockTokenStore(repo.NewTokenStore())
+	repo.MockGetUserTokenState(map[string]repo.UserTokenState{
+		current:  {Active: true, Version: 2},
+		inactive: {Active: false, Version: 0},
+		stale:    {Active: true, Version: 3},
+	}, nil)
+
+	cases := []struct {
+		name    string
+		userID  string
+		version int
+		want    int
+	}{
+		{"versi cocok", current, 2, 200},
+		{"versi lama (ganti role/password)", stale, 2, 401},
+		{"akun nonaktif", inactive, 0, 401},
+		{"user dihapus", deleted, 0, 401},
+	}
+	app := sessionApp()
+	for _, tc := range cases {
+		token, _ := signAccessToken(t, tc.userID, "", tc.version)
+		if status := ping(app, token); status != tc.want {
+			t.Errorf("%s: harusnya %d, dapat %d", tc.name, tc.want, status)
+		}
+	}
+}
+
+func TestJWTRequired_SessionCacheExpires(t *testing.T) {
+	userID := uuid.NewString()
+	states := map[string]repo.UserTokenState{userID: {Active: true, Version: 0}}
+	calls := 0
+	repo.MockTokenStore(repo.NewTokenStore())
+	repo.MockGetUserTokenState(states, &calls)
+
+	app := sessionApp()
+	token, _ := signAccessToken(t, userID, "", 0)
+	for range 3 {
+		if status := ping(app, token); status != 200 {
+			t.Fatalf("Token valid harusnya 200, dapat %d", status)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Status sesi harus di-cache, repository dipanggil %d kali", calls)
+	}
+
+	// admin menonaktifkan akun; token lama berhenti berlaku setelah cache kedaluwarsa
+	states[userID] = repo.UserTokenState{Active: false}
+	time.Sleep(middleware.SessionCacheTTL + 100*time.Millisecond)
+
+	if status := ping(app, token); status != 401 {
+		t.Errorf("Setelah SessionCacheTTL token harusnya 401, dapat %d", status)
+	}
+	if calls != 2 {
+		t.Errorf("Cache kedaluwarsa harus membaca ulang status sesi, dipanggil %d kali", calls)
+	}
+}
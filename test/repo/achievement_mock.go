@@ -1,8 +1,13 @@
 package repo
 
 import (
+	"database/sql"
+	"time"
+
 	"prestasi_backend/app/model"
 	"prestasi_backend/app/repository" // Import repository asli
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func MockCreateAchievement(mockID string, mockErr error) {
@@ -15,4 +20,198 @@ func MockGetByStudent(mockList []model.AchievementMongo, mockErr error) {
 	repository.GetAchievementsByStudentID = func(studentID string) ([]model.AchievementMongo, error) {
 		return mockList, mockErr
 	}
-}
\ No newline at end of file
+}
+// MockGetAchievementTypeByCode mengganti lookup skema jenis prestasi
+func MockGetAchievementTypeByCode(mockType *model.AchievementType, mockErr error) {
+	repository.GetAchievementTypeByCode = func(code string) (*model.AchievementType, error) {
+		return mockType, mockErr
+	}
+}
+
+// MockGetAchievementsByIDs mengganti batch fetch MongoDB
+func MockGetAchievementsByIDs(found map[string]*model.AchievementMongo, failed map[string]error, mockErr error) {
+	repository.GetAchievementsByIDs = func(ids []string) (map[string]*model.AchievementMongo, map[string]error, error) {
+		return found, failed, mockErr
+	}
+}
+
+// MockCreateAchievementReference mengganti insert reference Postgre
+func MockCreateAchievementReference(mockErr error) {
+	repository.CreateAchievementReference = func(ref *model.AchievementReference, actorID string, note *string) error {
+		return mockErr
+	}
+}
+
+// MockDeleteAchievement mencatat ID dokumen yang dihapus ke *deleted
+func MockDeleteAchievement(deleted *[]string, mockErr error) {
+	repository.DeleteAchievement = func(id string) error {
+		*deleted = append(*deleted, id)
+		return mockErr
+	}
+}
+
+// MockReconcileSources mengganti sumber data rekonsiliasi di kedua store
+func MockReconcileSources(refs []model.AchievementReference, docs []model.AchievementMongo) {
+	repository.GetAllAchievementReferencesIncludingDeleted = func() ([]model.AchievementReference, error) {
+		return refs, nil
+	}
+	repository.GetAchievementSummaries = func() ([]model.AchievementMongo, error) {
+		return docs, nil
+	}
+}
+
+// MockAddAchievementAttachment mencatat attachment yang ditambahkan ke *added
+func MockAddAchievementAttachment(added *[]model.Attachment, mockErr error) {
+	repository.AddAchievementAttachment = func(id string, att model.Attachment) error {
+		*added = append(*added, att)
+		return mockErr
+	}
+}
+
+// MockInsertQuarantinedAttachment mencatat file karantina ke *records
+func MockInsertQuarantinedAttachment(records *[]model.QuarantinedAttachment) {
+	repository.InsertQuarantinedAttachment = func(q *model.QuarantinedAttachment) error {
+		*records = append(*records, *q)
+		return nil
+	}
+}
+
+// MockFindAchievementsByDedupKeys mengganti pencarian kandidat duplikat global
+func MockFindAchievementsByDedupKeys(mockList []model.AchievementMongo, mockErr error) {
+	repository.FindAchievementsByDedupKeys = func(keys []string, excludeID string, limit int) ([]model.AchievementMongo, error) {
+		return mockList, mockErr
+	}
+}
+
+// MockGetAchievementReferencesByMongoIDs mengembalikan reference yang mongo ID-nya ada di refs
+func MockGetAchievementReferencesByMongoIDs(refs []model.AchievementReference) {
+	repository.GetAchievementReferencesByMongoIDs = func(mongoIDs []string) ([]model.AchievementReference, error) {
+		want := map[string]bool{}
+		for _, id := range mongoIDs {
+			want[id] = true
+		}
+		var out []model.AchievementReference
+		for _, r := range refs {
+			if want[r.MongoAchievementID] {
+				out = append(out, r)
+			}
+		}
+		return out, nil
+	}
+}
+
+// MockSearchAchievementsText mengganti MongoDB text search
+func MockSearchAchievementsText(docs []model.AchievementMongo, scores map[string]float64, mockErr error) {
+	repository.SearchAchievementsText = func(text string, limit int) ([]model.AchievementMongo, map[string]float64, error) {
+		return docs, scores, mockErr
+	}
+}
+
+// MockFilterAchievementReferences mengembalikan refs yang mongo ID-nya lolos
+// filter MongoIDs dan mencatat filter terakhir ke *got
+func MockFilterAchievementReferences(refs []model.AchievementReference, got *model.AchievementFilter) {
+	repository.FilterAchievementReferences = func(f model.AchievementFilter) ([]model.AchievementReference, error) {
+		if got != nil {
+			*got = f
+		}
+		want := map[string]bool{}
+		for _, id := range f.MongoIDs {
+			want[id] = true
+		}
+		var out []model.AchievementReference
+		for _, r := range refs {
+			if want[r.MongoAchievementID] {
+				out = append(out, r)
+			}
+		}
+		return out, nil
+	}
+}
+
+// MockGetAchievementReferenceByID mengembalikan reference dari map (404 bila tidak ada)
+func MockGetAchievementReferenceByID(refs map[string]*model.AchievementReference) {
+	repository.GetAchievementReferenceByID = func(id string) (*model.AchievementReference, error) {
+		if ref, ok := refs[id]; ok {
+			copied := *ref
+			return &copied, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+}
+
+// MockIsStudentAdvisedBy: advisees memetakan ID mahasiswa ke ID dosen walinya
+func MockIsStudentAdvisedBy(advisees map[string]string) {
+	repository.IsStudentAdvisedBy = func(lecturerID, studentID string) (bool, error) {
+		return advisees[studentID] == lecturerID, nil
+	}
+}
+
+// MockCreateAchievementComment mengganti insert komentar tanpa menyentuh database
+func MockCreateAchievementComment(created *[]model.AchievementComment) {
+	repository.CreateAchievementComment = func(cm *model.AchievementComment) error {
+		if created != nil {
+			*created = append(*created, *cm)
+		}
+		return nil
+	}
+}
+
+// MockGetAchievementMembers: members memetakan ID reference ke anggota timnya
+func MockGetAchievementMembers(members map[string][]model.AchievementMember) {
+	repository.GetAchievementMembers = func(refID string) ([]model.AchievementMember, error) {
+		return members[refID], nil
+	}
+}
+
+// MockAchievementMemberStore memakai members (ID reference → anggota) sebagai
+// tabel achievement_members: GetAchievementMembers membacanya, sedangkan
+// ReplaceAchievementMembers dan RespondAchievementMember mengubahnya dengan
+// aturan yang sama seperti query Postgre-nya.
+func MockAchievementMemberStore(members map[string][]model.AchievementMember) {
+	MockGetAchievementMembers(members)
+	repository.ReplaceAchievementMembers = func(refID, ownerID string, next []model.AchievementMember) error {
+		existing := map[string]model.AchievementMember{}
+		for _, m := range members[refID] {
+			existing[m.StudentID] = m
+		}
+
+		saved := make([]model.AchievementMember, 0, len(next))
+		for _, m := range next {
+			old, ok := existing[m.StudentID]
+			switch {
+			case m.StudentID == ownerID && !ok:
+				m.Status = model.MemberConfirmed
+			case !ok || (old.Status == model.MemberDeclined && m.StudentID != ownerID):
+				m.Status = model.MemberPending
+				m.RespondedAt = nil
+			default:
+				m.Status = old.Status
+				m.RespondedAt = old.RespondedAt
+			}
+			saved = append(saved, m)
+		}
+		members[refID] = saved
+		return nil
+	}
+	repository.RespondAchievementMember = func(refID, studentID, status string) error {
+		for i, m := range members[refID] {
+			if m.StudentID == studentID {
+				now := time.Now()
+				members[refID][i].Status = status
+				members[refID][i].RespondedAt = &now
+				return nil
+			}
+		}
+		return repository.ErrMemberNotFound
+	}
+}
+
+// MockUpdateAchievement mencatat update dokumen MongoDB per ID ke updated
+func MockUpdateAchievement(updated map[string]bson.M, mockErr error) {
+	repository.UpdateAchievement = func(id string, update bson.M) error {
+		if updated != nil {
+			updated[id] = update
+		}
+		return mockErr
+	}
+}
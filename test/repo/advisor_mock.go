@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"slices"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+// MockGetStudentsByAdvisor: advisees memetakan ID dosen ke mahasiswa bimbingannya
+func MockGetStudentsByAdvisor(advisees map[string][]model.Student) {
+	repository.GetStudentsByAdvisor = func(lecturerID string) ([]model.Student, error) {
+		return advisees[lecturerID], nil
+	}
+}
+
+// MockAdvisorReviewCounts: pending memetakan ID mahasiswa ke jumlah pengajuan
+// yang belum ditinjau, kept memetakan ID dosen ke pengajuan yang dipertahankannya
+func MockAdvisorReviewCounts(pending map[string]int, kept map[string]int) {
+	repository.CountPendingReviews = func(studentIDs []string) (int, error) {
+		n := 0
+		for id, c := range pending {
+			if slices.Contains(studentIDs, id) {
+				n += c
+			}
+		}
+		return n, nil
+	}
+	repository.CountReviewsKeptBy = func(lecturerID string) (int, error) {
+		return kept[lecturerID], nil
+	}
+}
+
+// AdvisorReassignment mencatat satu panggilan ReassignAdvisors
+type AdvisorReassignment struct {
+	Assignments     []model.AdvisorAssignment
+	ReleaseReviewer string
+}
+
+// MockReassignAdvisors mencatat penugasan ke *calls tanpa menyentuh database
+func MockReassignAdvisors(calls *[]AdvisorReassignment, mockErr error) {
+	repository.ReassignAdvisors = func(assignments []model.AdvisorAssignment, releaseReviewer string) error {
+		if mockErr != nil {
+			return mockErr
+		}
+		*calls = append(*calls, AdvisorReassignment{Assignments: assignments, ReleaseReviewer: releaseReviewer})
+		return nil
+	}
+}
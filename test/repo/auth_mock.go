@@ -1,8 +1,14 @@
 package repo
 
 import (
+	"database/sql"
+	"time"
+
 	"prestasi_backend/app/model"
 	"prestasi_backend/app/repository" // Pastikan import ini benar
+	"prestasi_backend/utils"
+
+	"github.com/google/uuid"
 )
 
 // Fungsi ini buat nipu service biar gak manggil DB asli
@@ -16,4 +22,139 @@ func MockGetPermissions(mockPerms []string, mockErr error) {
 	repository.GetPermissionsByRoleID = func(roleID string) ([]string, error) {
 		return mockPerms, mockErr
 	}
-}
\ No newline at end of file
+}
+
+// TokenStore menyimpan refresh token dan jti access token yang dicabut di memori
+type TokenStore struct {
+	Refresh       map[string]*model.RefreshToken // token hash → refresh token
+	RevokedJTI    map[string]bool
+	FamilyRevokes []string // family ID dari setiap panggilan RevokeRefreshTokenFamily
+}
+
+// NewTokenStore membuat store kosong
+func NewTokenStore() *TokenStore {
+	return &TokenStore{
+		Refresh:    map[string]*model.RefreshToken{},
+		RevokedJTI: map[string]bool{},
+	}
+}
+
+// Add menyimpan refresh token plain milik user dalam family tertentu
+func (s *TokenStore) Add(plain, userID, familyID string) *model.RefreshToken {
+	t := &model.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		TokenHash: utils.HashToken(plain),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+	}
+	s.Refresh[t.TokenHash] = t
+	return t
+}
+
+// MockTokenStore mengganti repository refresh token & pencabutan access token
+// dengan store di memori, termasuk perilaku rotasi dan pencabutan family.
+func MockTokenStore(store *TokenStore) {
+	repository.CreateRefreshToken = func(t *model.RefreshToken) error {
+		cp := *t
+		store.Refresh[t.TokenHash] = &cp
+		return nil
+	}
+	repository.GetRefreshTokenByHash = func(hash string) (*model.RefreshToken, error) {
+		if t, ok := store.Refresh[hash]; ok {
+			cp := *t
+			return &cp, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+	repository.RotateRefreshToken = func(oldID string, next *model.RefreshToken) error {
+		for _, t := range store.Refresh {
+			if t.ID != oldID {
+				continue
+			}
+			if t.RevokedAt != nil {
+				return repository.ErrRefreshTokenReused
+			}
+			now := time.Now()
+			t.RevokedAt = &now
+			t.ReplacedBy = &next.ID
+			cp := *next
+			store.Refresh[next.TokenHash] = &cp
+			return nil
+		}
+		return sql.ErrNoRows
+	}
+	repository.RevokeRefreshTokenFamily = func(familyID string) error {
+		store.FamilyRevokes = append(store.FamilyRevokes, familyID)
+		now := time.Now()
+		for _, t := range store.Refresh {
+			if t.FamilyID == familyID && t.RevokedAt == nil {
+				t.RevokedAt = &now
+			}
+		}
+		return nil
+	}
+	repository.IsRefreshTokenFamilyRevoked = func(familyID string) (bool, error) {
+		for _, t := range store.Refresh {
+			if t.FamilyID == familyID && t.RevokedAt != nil && t.ReplacedBy == nil {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	repository.RevokeAccessToken = func(jti, userID string, expiresAt time.Time) error {
+		store.RevokedJTI[jti] = true
+		return nil
+	}
+	repository.IsAccessTokenRevoked = func(jti string) (bool, error) {
+		return store.RevokedJTI[jti], nil
+	}
+}
+
+// UserTokenState adalah status sesi user seperti yang dibaca GetUserTokenState
+type UserTokenState struct {
+	Active  bool
+	Version int
+}
+
+// MockGetUserTokenState mengembalikan status sesi dari map (no rows bila user tidak ada).
+// calls (boleh nil) dinaikkan setiap kali repository dipanggil.
+func MockGetUserTokenState(states map[string]UserTokenState, calls *int) {
+	repository.GetUserTokenState = func(userID string) (bool, int, error) {
+		if calls != nil {
+			*calls++
+		}
+		s, ok := states[userID]
+		if !ok {
+			return false, 0, sql.ErrNoRows
+		}
+		return s.Active, s.Version, nil
+	}
+}
+
+// MockGetUserByID mengembalikan user dari map (no rows bila tidak ada)
+func MockGetUserByID(users map[string]*model.User) {
+	repository.GetUserByID = func(id string) (*model.User, error) {
+		if u, ok := users[id]; ok {
+			cp := *u
+			return &cp, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+}
+
+// MockUserUpdate mengganti penyimpanan user; setiap BumpUserTokenVersion dan
+// RevokeRefreshTokensByUser dicatat ID user-nya ke *bumps dan *revokes.
+func MockUserUpdate(bumps, revokes *[]string) {
+	repository.UpdateUser = func(u *model.User) error {
+		return nil
+	}
+	repository.BumpUserTokenVersion = func(userID string) error {
+		*bumps = append(*bumps, userID)
+		return nil
+	}
+	repository.RevokeRefreshTokensByUser = func(userID string) error {
+		*revokes = append(*revokes, userID)
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+// MockUserImportSources mengganti lookup data yang sudah ada untuk impor massal.
+// permissions memetakan ID role ke nama permission-nya.
+func MockUserImportSources(students []model.Student, lecturers []model.Lecturer, users []model.User, roles []model.Role, permissions map[string][]string) {
+	repository.GetStudentsByStudentIDs = func(studentIDs []string) ([]model.Student, error) {
+		var out []model.Student
+		for _, s := range students {
+			if slices.Contains(studentIDs, s.StudentID) {
+				out = append(out, s)
+			}
+		}
+		return out, nil
+	}
+	repository.GetLecturersByLecturerIDs = func(lecturerIDs []string) ([]model.Lecturer, error) {
+		var out []model.Lecturer
+		for _, l := range lecturers {
+			if slices.Contains(lecturerIDs, l.LecturerID) {
+				out = append(out, l)
+			}
+		}
+		return out, nil
+	}
+	repository.GetUsersByIdentity = func(ids, usernames, emails []string) ([]model.User, error) {
+		var out []model.User
+		for _, u := range users {
+			if slices.Contains(ids, u.ID) || slices.Contains(usernames, u.Username) ||
+				slices.Contains(emails, strings.ToLower(u.Email)) {
+				out = append(out, u)
+			}
+		}
+		return out, nil
+	}
+	repository.GetAllRoles = func() ([]model.Role, error) {
+		return roles, nil
+	}
+	repository.GetRoleByID = func(id string) (*model.Role, error) {
+		for i := range roles {
+			if roles[i].ID == id {
+				return &roles[i], nil
+			}
+		}
+		return nil, sql.ErrNoRows
+	}
+	repository.GetPermissionsByRoleID = func(roleID string) ([]string, error) {
+		return permissions[roleID], nil
+	}
+}
+
+// MockApplyUserImport mencatat operasi impor yang di-commit ke *applied
+func MockApplyUserImport(applied *[]model.UserImportOp, mockErr error) {
+	repository.ApplyUserImport = func(ops []model.UserImportOp) error {
+		if applied != nil {
+			*applied = append(*applied, ops...)
+		}
+		return mockErr
+	}
+}
@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"database/sql"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+// MockGetStudentByUserID mengganti lookup mahasiswa dari user_id JWT
+func MockGetStudentByUserID(mockStudent *model.Student, mockErr error) {
+	repository.GetStudentByUserID = func(userID string) (*model.Student, error) {
+		return mockStudent, mockErr
+	}
+}
+
+// MockGetLecturerByUserID mengganti lookup dosen dari user_id JWT
+func MockGetLecturerByUserID(mockLecturer *model.Lecturer, mockErr error) {
+	repository.GetLecturerByUserID = func(userID string) (*model.Lecturer, error) {
+		return mockLecturer, mockErr
+	}
+}
+
+// MockGetStudentByID mengembalikan mahasiswa dari map (no rows bila tidak ada)
+func MockGetStudentByID(students map[string]*model.Student) {
+	repository.GetStudentByID = func(id string) (*model.Student, error) {
+		if s, ok := students[id]; ok {
+			return s, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+}
+
+// MockGetLecturersByUserID seperti MockGetLecturerByUserID, tetapi per user_id
+func MockGetLecturersByUserID(lecturers map[string]*model.Lecturer) {
+	repository.GetLecturerByUserID = func(userID string) (*model.Lecturer, error) {
+		if l, ok := lecturers[userID]; ok {
+			return l, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+}
+
+// MockGetLecturerByID mengembalikan dosen dari map (no rows bila tidak ada)
+func MockGetLecturerByID(lecturers map[string]*model.Lecturer) {
+	repository.GetLecturerByID = func(id string) (*model.Lecturer, error) {
+		if l, ok := lecturers[id]; ok {
+			return l, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+}
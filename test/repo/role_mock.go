@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"database/sql"
+	"slices"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+
+	"github.com/lib/pq"
+)
+
+// RBACStore menyimpan role, permission, dan relasinya di memori
+type RBACStore struct {
+	Roles       map[string]*model.Role
+	Permissions map[string]*model.Permission
+	Grants      map[string][]string // role ID → permission ID
+	Users       map[string]int      // role ID → jumlah user yang memakai role
+}
+
+// MockRBAC mengganti repository role/permission dengan store di memori.
+// Nama role/permission yang bentrok dikembalikan sebagai unique violation.
+func MockRBAC(store *RBACStore) {
+	uniqueViolation := &pq.Error{Code: "23505"}
+
+	repository.GetAllRoles = func() ([]model.Role, error) {
+		var list []model.Role
+		for _, r := range store.Roles {
+			list = append(list, *r)
+		}
+		return list, nil
+	}
+	repository.GetRoleByID = func(id string) (*model.Role, error) {
+		if r, ok := store.Roles[id]; ok {
+			cp := *r
+			return &cp, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+	roleNameTaken := func(r *model.Role) bool {
+		for id, other := range store.Roles {
+			if id != r.ID && other.Name == r.Name {
+				return true
+			}
+		}
+		return false
+	}
+	repository.CreateRole = func(r *model.Role) error {
+		if roleNameTaken(r) {
+			return uniqueViolation
+		}
+		cp := *r
+		store.Roles[r.ID] = &cp
+		return nil
+	}
+	repository.UpdateRole = func(r *model.Role) error {
+		if _, ok := store.Roles[r.ID]; !ok {
+			return sql.ErrNoRows
+		}
+		if roleNameTaken(r) {
+			return uniqueViolation
+		}
+		cp := *r
+		store.Roles[r.ID] = &cp
+		return nil
+	}
+	repository.DeleteRole = func(id string) error {
+		if _, ok := store.Roles[id]; !ok {
+			return sql.ErrNoRows
+		}
+		delete(store.Roles, id)
+		delete(store.Grants, id)
+		return nil
+	}
+	repository.CountUsersByRole = func(roleID string) (int, error) {
+		return store.Users[roleID], nil
+	}
+
+	repository.GetPermissionByID = func(id string) (*model.Permission, error) {
+		if p, ok := store.Permissions[id]; ok {
+			cp := *p
+			return &cp, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+	permNameTaken := func(p *model.Permission) bool {
+		for id, other := range store.Permissions {
+			if id != p.ID && other.Name == p.Name {
+				return true
+			}
+		}
+		return false
+	}
+	repository.CreatePermission = func(p *model.Permission) error {
+		if permNameTaken(p) {
+			return uniqueViolation
+		}
+		cp := *p
+		store.Permissions[p.ID] = &cp
+		return nil
+	}
+	repository.UpdatePermission = func(p *model.Permission) error {
+		if _, ok := store.Permissions[p.ID]; !ok {
+			return sql.ErrNoRows
+		}
+		if permNameTaken(p) {
+			return uniqueViolation
+		}
+		cp := *p
+		store.Permissions[p.ID] = &cp
+		return nil
+	}
+	repository.DeletePermission = func(id string) error {
+		if _, ok := store.Permissions[id]; !ok {
+			return sql.ErrNoRows
+		}
+		delete(store.Permissions, id)
+		for roleID, perms := range store.Grants {
+			store.Grants[roleID] = slices.DeleteFunc(perms, func(p string) bool { return p == id })
+		}
+		return nil
+	}
+
+	repository.GetPermissionDetailsByRoleID = func(roleID string) ([]model.Permission, error) {
+		var list []model.Permission
+		for _, id := range store.Grants[roleID] {
+			list = append(list, *store.Permissions[id])
+		}
+		return list, nil
+	}
+	repository.AddPermissionToRole = func(roleID, permissionID string) error {
+		if !slices.Contains(store.Grants[roleID], permissionID) {
+			store.Grants[roleID] = append(store.Grants[roleID], permissionID)
+		}
+		return nil
+	}
+	repository.RemovePermissionFromRole = func(roleID, permissionID string) error {
+		i := slices.Index(store.Grants[roleID], permissionID)
+		if i < 0 {
+			return sql.ErrNoRows
+		}
+		store.Grants[roleID] = slices.Delete(store.Grants[roleID], i, i+1)
+		return nil
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+)
+
+func TestReviewBatch_PerItemResults(t *testing.T) {
+	repo.MockGetAchievementReferenceByID(map[string]*model.AchievementReference{
+		"ok":      {ID: "ok", StudentID: "advisee", Status: model.StatusSubmitted},
+		"other":   {ID: "other", StudentID: "stranger", Status: model.StatusSubmitted},
+		"draft":   {ID: "draft", StudentID: "advisee", Status: model.StatusDraft},
+		"raced":   {ID: "raced", StudentID: "advisee", Status: model.StatusSubmitted},
+		"no-note": {ID: "no-note", StudentID: "advisee", Status: model.StatusSubmitted},
+	})
+	repo.MockIsStudentAdvisedBy(map[string]string{"advisee": "lect-1", "stranger": "lect-2"})
+	repo.MockCreateAchievementComment(nil)
+
+	var rejected []string
+	repository.RejectAchievementReference = func(id, verifierUserID, note string) error {
+		if id == "raced" {
+			return repository.ErrStatusConflict
+		}
+		rejected = append(rejected, id)
+		return nil
+	}
+
+	lecturer := &model.Lecturer{ID: "lect-1"}
+	results := service.ReviewBatch(lecturer, "user-lect-1", model.AchievementBatchReviewRequest{
+		Decision: "reject",
+		Note:     "Bukti kurang",
+		Items: []model.AchievementBatchReviewItem{
+			{ID: "ok"},
+			{ID: "other"},
+			{ID: "draft"},
+			{ID: "missing"},
+			{ID: "raced"},
+			{ID: "ok"},
+			{ID: "no-note", Decision: "approve"},
+			{ID: ""},
+		},
+	})
+
+	want := []struct {
+		id     string
+		status int
+	}{
+		{"ok", 200}, {"other", 403}, {"draft", 400}, {"missing", 404},
+		{"raced", 409}, {"ok", 400}, {"no-note", 400}, {"", 400},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Harusnya %d hasil, dapat %d", len(want), len(results))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.ID != w.id || r.Status != w.status || r.Success != (w.status == 200) {
+			t.Errorf("Item %d: harusnya %s/%d, dapat %+v", i, w.id, w.status, r)
+		}
+	}
+
+	if len(rejected) != 1 || rejected[0] != "ok" {
+		t.Errorf("Hanya 'ok' yang boleh ditolak, dapat %v", rejected)
+	}
+}
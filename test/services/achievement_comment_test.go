@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+)
+
+func TestBuildCommentThreads(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	flat := []model.AchievementComment{
+		{ID: "c1", Kind: model.CommentKindClarification, Body: "Mohon surat keterangan"},
+		{ID: "c2", Body: "Komentar lain"},
+		{ID: "c3", ParentID: ptr("c1"), Body: "Sudah saya lampirkan"},
+		{ID: "c4", ParentID: ptr("c3"), Body: "Terima kasih"},
+		{ID: "c5", ParentID: ptr("c1"), Body: "Tambahan"},
+		{ID: "c6", ParentID: ptr("hilang"), Body: "Parent tidak ada"},
+	}
+
+	threads := service.BuildCommentThreads(flat)
+
+	if len(threads) != 3 {
+		t.Fatalf("Harusnya 3 thread akar (c1, c2, c6), dapat %d", len(threads))
+	}
+	if threads[0].ID != "c1" || threads[1].ID != "c2" || threads[2].ID != "c6" {
+		t.Errorf("Urutan akar harus mengikuti waktu: %s %s %s", threads[0].ID, threads[1].ID, threads[2].ID)
+	}
+
+	replies := threads[0].Replies
+	if len(replies) != 2 || replies[0].ID != "c3" || replies[1].ID != "c5" {
+		t.Fatalf("Balasan c1 harusnya [c3 c5], dapat %+v", replies)
+	}
+	if len(replies[0].Replies) != 1 || replies[0].Replies[0].ID != "c4" {
+		t.Errorf("Balasan bertingkat c3 → c4 hilang")
+	}
+	if threads[1].Replies != nil {
+		t.Errorf("Komentar tanpa balasan tidak boleh punya replies")
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type createdAchievement struct {
+	docStudentID string
+	ref          model.AchievementReference
+	actorID      string
+	note         *string
+}
+
+// setupAchievementCreate menyiapkan mock untuk AchievementCreate dan
+// mencatat dokumen + reference yang disimpan ke *created
+func setupAchievementCreate(created *createdAchievement) {
+	repo.MockGetAchievementTypeByCode(competitionType(), nil)
+	repo.MockGetStudentByID(map[string]*model.Student{
+		"s-budi": {ID: "s-budi", UserID: "u-budi", StudentID: "2021001"},
+		"s-ani":  {ID: "s-ani", UserID: "u-ani", StudentID: "2021002"},
+	})
+	repository.GetStudentByUserID = func(userID string) (*model.Student, error) {
+		if userID == "u-budi" {
+			return &model.Student{ID: "s-budi", UserID: "u-budi", StudentID: "2021001"}, nil
+		}
+		return nil, sql.ErrNoRows
+	}
+	repository.CreateAchievement = func(doc *model.AchievementMongo) (string, error) {
+		created.docStudentID = doc.StudentID
+		return "mongo-id-create", nil
+	}
+	repository.CreateAchievementReference = func(ref *model.AchievementReference, actorID string, note *string) error {
+		created.ref, created.actorID, created.note = *ref, actorID, note
+		return nil
+	}
+
+	// pemeriksaan duplikat setelah create
+	repo.MockGetByStudent(nil, nil)
+	repo.MockFindAchievementsByDedupKeys(nil, nil)
+	repo.MockGetAchievementReferencesByMongoIDs(nil)
+	repo.MockUpdateAchievement(nil, nil)
+}
+
+func postAchievement(claims *model.JWTClaims, body map[string]interface{}) int {
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		c.Locals("user", claims)
+		c.Locals("userId", claims.UserID)
+		return c.Next()
+	}, service.AchievementCreate)
+
+	payload := map[string]interface{}{
+		"achievement_type": "competition",
+		"title":            "Juara 1 Lomba Coding",
+		"details":          map[string]interface{}{"competitionName": "Lomba Coding Nasional", "rank": 1},
+	}
+	for k, v := range body {
+		payload[k] = v
+	}
+	raw, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+func TestAchievementCreate_StudentFromToken(t *testing.T) {
+	var created createdAchievement
+	setupAchievementCreate(&created)
+
+	mahasiswa := &model.JWTClaims{UserID: "u-budi", RoleName: "Mahasiswa", Permissions: []string{"achievement:create"}}
+
+	// student_id di body (format lama) diabaikan
+	if status := postAchievement(mahasiswa, map[string]interface{}{"student_id": "s-ani"}); status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d", status)
+	}
+	if created.ref.StudentID != "s-budi" || created.docStudentID != "s-budi" {
+		t.Errorf("Pemilik harus mahasiswa dari token di kedua store, dapat ref=%q doc=%q", created.ref.StudentID, created.docStudentID)
+	}
+	if created.note != nil {
+		t.Errorf("Pembuatan oleh pemilik sendiri tidak perlu catatan, dapat %q", *created.note)
+	}
+}
+
+func TestAchievementCreate_OnBehalfOf(t *testing.T) {
+	var created createdAchievement
+	setupAchievementCreate(&created)
+
+	mahasiswa := &model.JWTClaims{UserID: "u-budi", RoleName: "Mahasiswa", Permissions: []string{"achievement:create"}}
+	if status := postAchievement(mahasiswa, map[string]interface{}{"on_behalf_of": "s-ani"}); status != 403 {
+		t.Errorf("Mahasiswa tidak boleh memakai on_behalf_of, dapat %d", status)
+	}
+
+	admin := &model.JWTClaims{UserID: "u-admin", RoleName: "Admin", Permissions: []string{"achievement:create:all"}}
+	if status := postAchievement(admin, nil); status != 403 {
+		t.Errorf("Admin tanpa on_behalf_of harusnya 403, dapat %d", status)
+	}
+	if status := postAchievement(admin, map[string]interface{}{"on_behalf_of": "s-tidak-ada"}); status != 400 {
+		t.Errorf("on_behalf_of tidak dikenal harusnya 400, dapat %d", status)
+	}
+
+	if status := postAchievement(admin, map[string]interface{}{"on_behalf_of": "s-ani"}); status != 200 {
+		t.Fatalf("Admin dengan on_behalf_of harusnya 200, dapat %d", status)
+	}
+	if created.ref.StudentID != "s-ani" || created.docStudentID != "s-ani" {
+		t.Errorf("Pemilik harus s-ani di kedua store, dapat ref=%q doc=%q", created.ref.StudentID, created.docStudentID)
+	}
+	if created.actorID != "u-admin" {
+		t.Errorf("Aktor riwayat harus admin, dapat %q", created.actorID)
+	}
+	if created.note == nil || !strings.HasPrefix(*created.note, "on_behalf_of:") || !strings.Contains(*created.note, "2021002") {
+		t.Errorf("Riwayat harus mencatat on_behalf_of, dapat %v", created.note)
+	}
+}
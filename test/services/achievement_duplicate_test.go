@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func hackathonDoc(title string, hashes ...string) model.AchievementMongo {
+	doc := model.AchievementMongo{
+		ID:              primitive.NewObjectID(),
+		StudentID:       "s1",
+		AchievementType: "competition",
+		Title:           title,
+		Details: map[string]interface{}{
+			"competitionName": "Hackathon Nasional",
+			"eventDate":       "2025-03-01",
+		},
+	}
+	for _, h := range hashes {
+		doc.Attachments = append(doc.Attachments, model.Attachment{SHA256: h})
+	}
+	return doc
+}
+
+func TestDedupKeys(t *testing.T) {
+	doc := hackathonDoc("Juara 1 — Hackathon  Nasional!", "abc")
+	doc.Attachments = append(doc.Attachments, model.Attachment{Key: "uploads/lama.pdf", Legacy: true})
+
+	got := service.DedupKeys(&doc)
+	want := []string{"t:juara 1 hackathon nasional", "e:hackathon nasional|2025-03-01", "h:abc"}
+	if len(got) != len(want) {
+		t.Fatalf("Kunci harusnya %v, dapat %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Kunci ke-%d harusnya %q, dapat %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestScoreDuplicate(t *testing.T) {
+	a := hackathonDoc("Juara 1 Hackathon Nasional 2025", "abc")
+
+	same := hackathonDoc("juara 1 hackathon nasional 2025")
+	score, reasons := service.ScoreDuplicate(&a, &same)
+	if score != 1 || len(reasons) != 3 {
+		t.Errorf("Judul, kegiatan dan tanggal sama harusnya skor 1, dapat %v %v", score, reasons)
+	}
+
+	file := model.AchievementMongo{Title: "Sertifikat", Attachments: []model.Attachment{{SHA256: "abc"}}}
+	if score, reasons := service.ScoreDuplicate(&a, &file); score < 0.9 || reasons[0] != "same_attachment" {
+		t.Errorf("Lampiran identik harusnya skor ≥ 0.9, dapat %v %v", score, reasons)
+	}
+
+	other := model.AchievementMongo{Title: "Ketua BEM", Details: map[string]interface{}{
+		"organizationName": "BEM", "periodStart": "2024-01-01",
+	}}
+	if score, _ := service.ScoreDuplicate(&a, &other); score >= 0.6 {
+		t.Errorf("Prestasi berbeda tidak boleh dianggap duplikat, skor %v", score)
+	}
+}
+
+func TestFindDuplicates_SkipsSelfAndDeleted(t *testing.T) {
+	doc := hackathonDoc("Juara 1 Hackathon Nasional", "abc")
+	own := hackathonDoc("Juara 1 Hackathon Nasional")
+	deleted := hackathonDoc("Juara 1 Hackathon Nasional")
+	teammate := hackathonDoc("Juara I Hackathon Nasional", "abc")
+	teammate.StudentID = "s2"
+
+	repo.MockGetByStudent([]model.AchievementMongo{doc, own, deleted}, nil)
+	repo.MockFindAchievementsByDedupKeys([]model.AchievementMongo{teammate}, nil)
+	// reference prestasi "deleted" tidak dikembalikan (status deleted)
+	repo.MockGetAchievementReferencesByMongoIDs([]model.AchievementReference{
+		{ID: "ref-own", MongoAchievementID: own.ID.Hex(), Status: model.StatusDraft},
+		{ID: "ref-team", MongoAchievementID: teammate.ID.Hex(), Status: model.StatusVerified},
+		{ID: "ref-self", MongoAchievementID: doc.ID.Hex(), Status: model.StatusDraft},
+	})
+
+	matches, err := service.FindDuplicates(&doc)
+	if err != nil {
+		t.Fatalf("Tidak seharusnya error: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Harusnya 2 kecocokan, dapat %+v", matches)
+	}
+	if matches[0].ReferenceID != "ref-own" || matches[0].Score != 1 || matches[0].URL != "/achievements/ref-own" {
+		t.Errorf("Prestasi identik milik sendiri harusnya paling atas, dapat %+v", matches[0])
+	}
+	if matches[1].ReferenceID != "ref-team" || matches[1].Status != model.StatusVerified || matches[1].Reasons[0] != "same_attachment" {
+		t.Errorf("Kecocokan kedua harusnya lampiran teman setim, dapat %+v", matches[1])
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+)
+
+func TestJoinAchievements_PreservesOrderAndReportsIssues(t *testing.T) {
+	refs := []model.AchievementReference{
+		{ID: "r1", MongoAchievementID: "b"},
+		{ID: "r2", MongoAchievementID: "hilang"},
+		{ID: "r3", MongoAchievementID: "a"},
+		{ID: "r4", MongoAchievementID: "rusak"},
+		{ID: "r5", MongoAchievementID: "bukan-oid"},
+	}
+
+	repo.MockGetAchievementsByIDs(
+		map[string]*model.AchievementMongo{
+			"a": {Title: "A"},
+			"b": {Title: "B"},
+		},
+		map[string]error{
+			"rusak":     errors.New("cannot decode"),
+			"bukan-oid": repository.ErrInvalidAchievementID,
+		},
+		nil,
+	)
+
+	items, issues, err := service.JoinAchievements(refs)
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+	if len(items) != len(refs) {
+		t.Fatalf("Semua reference harus tetap muncul, dapat %d item", len(items))
+	}
+
+	for i, ref := range refs {
+		if items[i].Reference.ID != ref.ID {
+			t.Errorf("Urutan item %d harusnya %s, dapat %s", i, ref.ID, items[i].Reference.ID)
+		}
+	}
+	if items[0].Achievement.Title != "B" || items[2].Achievement.Title != "A" {
+		t.Errorf("Dokumen tidak dipasangkan ke reference yang benar")
+	}
+
+	want := map[string]string{
+		"r2": model.AchievementIssueMissing,
+		"r4": model.AchievementIssueCorrupt,
+		"r5": model.AchievementIssueInvalidID,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Harusnya %d issue, dapat %d", len(want), len(issues))
+	}
+	for _, is := range issues {
+		if want[is.ReferenceID] != is.Problem {
+			t.Errorf("Issue %s harusnya %s, dapat %s", is.ReferenceID, want[is.ReferenceID], is.Problem)
+		}
+	}
+	if items[1].Achievement != nil || items[1].Issue == nil {
+		t.Errorf("Item yang hilang harus achievement null dengan issue terisi")
+	}
+}
+
+func TestJoinAchievements_MongoError(t *testing.T) {
+	repo.MockGetAchievementsByIDs(nil, nil, errors.New("timeout"))
+
+	if _, _, err := service.JoinAchievements([]model.AchievementReference{{ID: "r1"}}); err == nil {
+		t.Errorf("Error MongoDB harus diteruskan, bukan diabaikan")
+	}
+}
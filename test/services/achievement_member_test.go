@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/middleware"
+	"prestasi_backend/test/repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestNormalizeTeamMembers_OwnerBecomesLeader(t *testing.T) {
+	members, errs := service.NormalizeTeamMembers("owner", []model.AchievementMemberInput{
+		{StudentID: "s2"},
+		{StudentID: "s3", Role: "anggota"},
+	})
+	if len(errs) != 0 {
+		t.Fatalf("Tidak seharusnya ada error, dapat %v", errs)
+	}
+	if len(members) != 3 {
+		t.Fatalf("Harusnya 3 anggota termasuk pemilik, dapat %d", len(members))
+	}
+	if members[0].StudentID != "owner" || members[0].Role != model.MemberRoleLeader {
+		t.Errorf("Pemilik harusnya menjadi ketua, dapat %+v", members[0])
+	}
+	for _, m := range members[1:] {
+		if m.Role != model.MemberRoleMember {
+			t.Errorf("%s harusnya anggota, dapat %s", m.StudentID, m.Role)
+		}
+	}
+}
+
+func TestNormalizeTeamMembers_ExplicitLeader(t *testing.T) {
+	members, errs := service.NormalizeTeamMembers("owner", []model.AchievementMemberInput{
+		{StudentID: "s2", Role: "ketua"},
+		{StudentID: "owner", Role: "anggota"},
+	})
+	if len(errs) != 0 {
+		t.Fatalf("Tidak seharusnya ada error, dapat %v", errs)
+	}
+	if len(members) != 2 {
+		t.Fatalf("Pemilik tidak boleh tercatat dua kali, dapat %d anggota", len(members))
+	}
+	if members[0].Role != model.MemberRoleMember || members[1].Role != model.MemberRoleLeader {
+		t.Errorf("s2 harusnya ketua dan pemilik anggota, dapat %+v", members)
+	}
+}
+
+func TestNormalizeTeamMembers_Invalid(t *testing.T) {
+	_, errs := service.NormalizeTeamMembers("owner", []model.AchievementMemberInput{
+		{StudentID: "s2", Role: "ketua"},
+		{StudentID: "s3", Role: "ketua"},
+		{StudentID: "s2"},
+		{StudentID: ""},
+		{StudentID: "s4", Role: "pelatih"},
+	})
+
+	got := fieldErrorMap(errs)
+	for _, field := range []string{
+		"members[1].role",
+		"members[2].student_id",
+		"members[3].student_id",
+		"members[4].role",
+	} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("Harusnya ada error untuk %s, dapat %v", field, got)
+		}
+	}
+}
+
+func TestComputeTeamPoints(t *testing.T) {
+	doc := model.AchievementMongo{AchievementType: "competition", Details: map[string]interface{}{
+		"level": "national", "rank": 1,
+	}}
+
+	// 50 × 2 × 2 = 200, dibagi rata 4 anggota terkonfirmasi
+	if got := service.ComputeTeamPoints(sampleRule(), &doc, 4); got.Points != 50 || got.TeamSize != 4 {
+		t.Errorf("Poin harusnya 50 untuk tim 4 orang, dapat %+v", got)
+	}
+	if _, ok := doc.Details["teamSize"]; ok {
+		t.Errorf("Details dokumen asli tidak boleh diubah")
+	}
+
+	// teamSize dari details lebih besar (ada anggota di luar sistem) tetap dipakai
+	doc.Details["teamSize"] = 5
+	if got := service.ComputeTeamPoints(sampleRule(), &doc, 2); got.Points != 40 {
+		t.Errorf("Poin harusnya 40 untuk teamSize 5, dapat %d", got.Points)
+	}
+
+	// individu
+	delete(doc.Details, "teamSize")
+	if got := service.ComputeTeamPoints(sampleRule(), &doc, 1); got.Points != 200 {
+		t.Errorf("Poin individu harusnya 200, dapat %d", got.Points)
+	}
+}
+
+// callAsStudent menjalankan route anggota tim sebagai user mahasiswa lewat guard AchievementAccess
+func callAsStudent(userID, method, path, action string, handler fiber.Handler, url, body string) (int, map[string]interface{}) {
+	app := fiber.New()
+	app.Add(method, path, func(c *fiber.Ctx) error {
+		c.Locals("user", &model.JWTClaims{UserID: userID, Permissions: []string{"achievement:read:own", "achievement:update:own"}})
+		c.Locals("userId", userID)
+		return c.Next()
+	}, middleware.AchievementAccess(action), handler)
+
+	req := httptest.NewRequest(method, url, bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		return 0, nil
+	}
+
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+func TestAchievementMembersUpdate_ReinvitesDeclinedMember(t *testing.T) {
+	repo.MockGetAchievementReferenceByID(map[string]*model.AchievementReference{
+		"team": {ID: "team", StudentID: "s-owner", Status: model.StatusDraft},
+	})
+	repo.MockGetStudentByID(map[string]*model.Student{
+		"s-owner":  {ID: "s-owner", UserID: "u-owner"},
+		"s-member": {ID: "s-member", UserID: "u-member"},
+	})
+	repo.MockGetStudentByUserID(&model.Student{ID: "s-member", UserID: "u-member"}, nil)
+	members := map[string][]model.AchievementMember{
+		"team": {
+			{StudentID: "s-owner", Role: model.MemberRoleLeader, Status: model.MemberConfirmed},
+			{StudentID: "s-member", Role: model.MemberRoleMember, Status: model.MemberPending},
+		},
+	}
+	repo.MockAchievementMemberStore(members)
+
+	respond := func(decision string) int {
+		status, _ := callAsStudent("u-member", "POST", "/achievements/:id/members/respond", "read",
+			service.AchievementMemberRespond, "/achievements/team/members/respond", `{"decision":"`+decision+`"}`)
+		return status
+	}
+
+	if status := respond("decline"); status != 200 {
+		t.Fatalf("Menolak keikutsertaan harusnya 200, dapat %d", status)
+	}
+	if status := respond("confirm"); status != 403 {
+		t.Fatalf("Anggota yang menolak tidak lagi bisa membuka prestasinya, harusnya 403, dapat %d", status)
+	}
+
+	// pemilik mencantumkan s-member lagi → diundang ulang
+	status, body := callAsStudent("u-owner", "PUT", "/achievements/:id/members", "update",
+		service.AchievementMembersUpdate, "/achievements/team/members", `{"members":[{"student_id":"s-member"}]}`)
+	if status != 200 {
+		t.Fatalf("Update anggota harusnya 200, dapat %d (%v)", status, body)
+	}
+	if m := members["team"][1]; m.Status != model.MemberPending || m.RespondedAt != nil {
+		t.Fatalf("Anggota yang ditambahkan ulang harus kembali pending, dapat %+v", m)
+	}
+	if m := members["team"][0]; m.Status != model.MemberConfirmed {
+		t.Errorf("Status pemilik tidak boleh berubah, dapat %+v", m)
+	}
+
+	if status := respond("confirm"); status != 200 {
+		t.Errorf("Anggota yang diundang ulang harus bisa konfirmasi, dapat %d", status)
+	}
+	if m := members["team"][1]; m.Status != model.MemberConfirmed {
+		t.Errorf("Harusnya confirmed setelah konfirmasi, dapat %+v", m)
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/middleware"
+	"prestasi_backend/test/repo"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDiffAchievement_NoChanges(t *testing.T) {
+	doc := &model.AchievementMongo{
+		Title:   "Juara 1 Hackathon",
+		Details: map[string]interface{}{"rank": 1},
+		Tags:    []string{"teknologi"},
+	}
+	copyDoc := &model.AchievementMongo{
+		Title:       "Juara 1 Hackathon",
+		Details:     map[string]interface{}{"rank": 1},
+		Tags:        []string{"teknologi"},
+		Attachments: []model.Attachment{},
+	}
+
+	changes := service.DiffAchievement(doc, copyDoc)
+	if len(changes) != 0 {
+		t.Fatalf("Harusnya tidak ada perubahan, dapat %v", changes)
+	}
+}
+
+func TestDiffAchievement_FieldsAndDetails(t *testing.T) {
+	before := &model.AchievementMongo{
+		Title:       "Juara 2 Hackathon",
+		Details:     map[string]interface{}{"rank": 2, "level": "nasional", "old": "x"},
+		Attachments: []model.Attachment{{ID: "a1", FileName: "lama.pdf"}},
+	}
+	after := &model.AchievementMongo{
+		Title:       "Juara 1 Hackathon",
+		Details:     map[string]interface{}{"rank": 1, "level": "nasional", "organizer": "Kemdikbud"},
+		Attachments: []model.Attachment{{ID: "a1", FileName: "lama.pdf"}, {ID: "a2", FileName: "baru.pdf"}},
+	}
+
+	changes := service.DiffAchievement(before, after)
+
+	want := []string{"title", "attachments", "details.old", "details.organizer", "details.rank"}
+	if len(changes) != len(want) {
+		t.Fatalf("Jumlah perubahan salah: dapat %v", changes)
+	}
+	for i, field := range want {
+		if changes[i].Field != field {
+			t.Errorf("Perubahan ke-%d harusnya %s, dapat %s", i, field, changes[i].Field)
+		}
+	}
+
+	if changes[0].Before != "Juara 2 Hackathon" || changes[0].After != "Juara 1 Hackathon" {
+		t.Errorf("Nilai before/after title salah: %+v", changes[0])
+	}
+	if changes[2].After != nil {
+		t.Errorf("Key yang dihapus harusnya after=nil, dapat %v", changes[2].After)
+	}
+}
+
+func putAchievement(id, body string) int {
+	app := fiber.New()
+	app.Put("/:id", func(c *fiber.Ctx) error {
+		c.Locals("user", &model.JWTClaims{UserID: "u-budi", Permissions: []string{"achievement:update:own"}})
+		return c.Next()
+	}, middleware.AchievementAccess("update"), service.AchievementUpdate)
+
+	req := httptest.NewRequest("PUT", "/"+id, bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+func TestAchievementUpdate_RejectedNeedsRevise(t *testing.T) {
+	repo.MockGetAchievementReferenceByID(map[string]*model.AchievementReference{
+		"draft":    {ID: "draft", StudentID: "s-budi", MongoAchievementID: "m-draft", Status: model.StatusDraft},
+		"rejected": {ID: "rejected", StudentID: "s-budi", MongoAchievementID: "m-rejected", Status: model.StatusRejected},
+	})
+	repo.MockGetStudentByID(map[string]*model.Student{"s-budi": {ID: "s-budi", UserID: "u-budi"}})
+	updated := map[string]bson.M{}
+	repo.MockUpdateAchievement(updated, nil)
+
+	if status := putAchievement("rejected", `{"title":"Juara 1"}`); status != 409 {
+		t.Errorf("Prestasi rejected harus dibuka lewat /revise (409), dapat %d", status)
+	}
+	if status := putAchievement("draft", `{}`); status != 400 {
+		t.Errorf("Update tanpa field harus 400, dapat %d", status)
+	}
+	if status := putAchievement("draft", `{"title":"Juara 1"}`); status != 200 {
+		t.Errorf("Update draft harusnya 200, dapat %d", status)
+	}
+
+	if _, ok := updated["m-rejected"]; ok || len(updated) != 1 {
+		t.Errorf("Hanya draft yang boleh diubah, dapat %v", updated)
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateAchievementRecord_CompensatesMongoOnReferenceFailure(t *testing.T) {
+	var deleted []string
+	repo.MockCreateAchievement("mongo-id-1", nil)
+	repo.MockCreateAchievementReference(errors.New("koneksi putus"))
+	repo.MockDeleteAchievement(&deleted, nil)
+
+	ref := model.AchievementReference{ID: "ref-1"}
+	err := service.CreateAchievementRecord(&model.AchievementMongo{}, &ref, "user-1", nil)
+
+	if !errors.Is(err, service.ErrAchievementReference) {
+		t.Fatalf("Harusnya ErrAchievementReference, dapat %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "mongo-id-1" {
+		t.Errorf("Dokumen MongoDB harus dihapus sebagai kompensasi, dapat %v", deleted)
+	}
+}
+
+func TestCreateAchievementRecord_Success(t *testing.T) {
+	var deleted []string
+	repo.MockCreateAchievement("mongo-id-2", nil)
+	repo.MockCreateAchievementReference(nil)
+	repo.MockDeleteAchievement(&deleted, nil)
+
+	ref := model.AchievementReference{ID: "ref-2", StudentID: "student-1"}
+	doc := model.AchievementMongo{StudentID: "student-lain"}
+	if err := service.CreateAchievementRecord(&doc, &ref, "user-1", nil); err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+	if doc.StudentID != "student-1" {
+		t.Errorf("Pemilik dokumen MongoDB harus sama dengan reference, dapat %q", doc.StudentID)
+	}
+	if ref.MongoAchievementID != "mongo-id-2" {
+		t.Errorf("Reference harus menunjuk dokumen baru, dapat %q", ref.MongoAchievementID)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Tidak boleh ada kompensasi saat sukses")
+	}
+}
+
+func TestRunReconciliation_ReportOnly(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	linked, orphan, fresh := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	gone, goneDeleted := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+
+	repo.MockReconcileSources(
+		[]model.AchievementReference{
+			{ID: "ok", MongoAchievementID: linked.Hex(), Status: model.StatusDraft},
+			{ID: "dangling", MongoAchievementID: gone, Status: model.StatusSubmitted},
+			{ID: "sudah-dihapus", MongoAchievementID: goneDeleted, Status: model.StatusDeleted},
+		},
+		[]model.AchievementMongo{
+			{ID: linked, CreatedAt: old},
+			{ID: orphan, CreatedAt: old, Title: "Yatim"},
+			{ID: fresh, CreatedAt: time.Now()}, // masih dalam grace period
+		},
+	)
+
+	var deleted []string
+	repo.MockDeleteAchievement(&deleted, nil)
+
+	report, err := service.RunReconciliation(false, 15*time.Minute, "")
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+
+	if len(report.OrphanDocuments) != 1 || report.OrphanDocuments[0].MongoAchievementID != orphan.Hex() {
+		t.Errorf("Harusnya tepat satu dokumen yatim (%s), dapat %+v", orphan.Hex(), report.OrphanDocuments)
+	}
+	if len(report.DanglingReferences) != 1 || report.DanglingReferences[0].ReferenceID != "dangling" {
+		t.Errorf("Harusnya tepat satu reference tanpa dokumen, dapat %+v", report.DanglingReferences)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Mode laporan tidak boleh menghapus apa pun, dapat %v", deleted)
+	}
+}
+
+func TestRunReconciliation_RepairsStudentMismatch(t *testing.T) {
+	same, moved := primitive.NewObjectID(), primitive.NewObjectID()
+
+	repo.MockReconcileSources(
+		[]model.AchievementReference{
+			{ID: "sama", StudentID: "student-1", MongoAchievementID: same.Hex(), Status: model.StatusDraft},
+			{ID: "beda", StudentID: "student-1", MongoAchievementID: moved.Hex(), Status: model.StatusSubmitted},
+		},
+		[]model.AchievementMongo{
+			{ID: same, StudentID: "student-1"},
+			{ID: moved, StudentID: "student-2"},
+		},
+	)
+
+	updated := map[string]bson.M{}
+	repo.MockUpdateAchievement(updated, nil)
+
+	report, err := service.RunReconciliation(true, 15*time.Minute, "admin-1")
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+
+	if len(report.StudentMismatches) != 1 {
+		t.Fatalf("Harusnya tepat satu pemilik berbeda, dapat %+v", report.StudentMismatches)
+	}
+	m := report.StudentMismatches[0]
+	if m.ReferenceID != "beda" || m.DocumentStudentID != "student-2" || !m.Repaired {
+		t.Errorf("Mismatch tidak sesuai, dapat %+v", m)
+	}
+	if got := updated[moved.Hex()]["studentId"]; got != "student-1" {
+		t.Errorf("studentId dokumen harus disamakan dengan Postgre, dapat %v", got)
+	}
+	if len(report.DanglingReferences) != 0 {
+		t.Errorf("Mismatch bukan reference tanpa dokumen, dapat %+v", report.DanglingReferences)
+	}
+}
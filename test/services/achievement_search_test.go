@@ -0,0 +1,85 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHighlightText(t *testing.T) {
+	got, ok := service.HighlightText("Juara 1 <Hackathon> Nasional", []string{"hackathon"}, 160)
+	if !ok || got != "Juara 1 &lt;<mark>Hackathon</mark>&gt; Nasional" {
+		t.Errorf("Highlight salah: %q", got)
+	}
+
+	if _, ok := service.HighlightText("Hackathonku", []string{"hackathon"}, 160); ok {
+		t.Errorf("Hanya kata utuh yang boleh di-highlight")
+	}
+
+	long := strings.Repeat("lorem ipsum ", 40) + "robotik" + strings.Repeat(" dolor sit", 40)
+	got, ok = service.HighlightText(long, []string{"robotik"}, 60)
+	if !ok || !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "<mark>robotik</mark>") {
+		t.Errorf("Cuplikan teks panjang harus dipotong di sekitar kecocokan, dapat %q", got)
+	}
+}
+
+func TestRunAchievementSearch(t *testing.T) {
+	mk := func(typ, title string, tags ...string) model.AchievementMongo {
+		return model.AchievementMongo{ID: primitive.NewObjectID(), AchievementType: typ, Title: title, Tags: tags,
+			Details: map[string]interface{}{"competitionName": "Kontes Robot Indonesia"}}
+	}
+	a := mk("competition", "Juara 2 Kontes Robot", "robotik", "teknologi")
+	b := mk("competition", "Finalis Robot Sepak Bola", "robotik")
+	hidden := mk("other", "Robot milik mahasiswa lain", "robotik")
+
+	repo.MockSearchAchievementsText(
+		[]model.AchievementMongo{b, a, hidden},
+		map[string]float64{a.ID.Hex(): 3.1, b.ID.Hex(): 1.2, hidden.ID.Hex(): 5},
+		nil,
+	)
+	// hidden tidak lolos filter scope di Postgre
+	var got model.AchievementFilter
+	repo.MockFilterAchievementReferences([]model.AchievementReference{
+		{ID: "ref-a", MongoAchievementID: a.ID.Hex(), Status: model.StatusVerified},
+		{ID: "ref-b", MongoAchievementID: b.ID.Hex(), Status: model.StatusDraft},
+	}, &got)
+
+	hits, facets, err := service.RunAchievementSearch(`robot -drone`, model.AchievementFilter{StudentID: "s1"})
+	if err != nil {
+		t.Fatalf("Tidak seharusnya error: %v", err)
+	}
+
+	if got.StudentID != "s1" || len(got.MongoIDs) != 3 {
+		t.Errorf("Filter scope dan ID hasil pencarian harus diteruskan ke Postgre, dapat %+v", got)
+	}
+	if len(hits) != 2 || hits[0].Reference.ID != "ref-a" || hits[1].Reference.ID != "ref-b" {
+		t.Fatalf("Hasil harus urut skor dan tanpa prestasi di luar scope, dapat %+v", hits)
+	}
+	if hits[0].Highlights["title"] != "Juara 2 Kontes <mark>Robot</mark>" {
+		t.Errorf("Highlight judul salah: %q", hits[0].Highlights["title"])
+	}
+	if _, ok := hits[0].Highlights["details.competitionName"]; !ok {
+		t.Errorf("Field details yang cocok harus di-highlight, dapat %v", hits[0].Highlights)
+	}
+
+	if len(facets.Types) != 1 || facets.Types[0] != (model.FacetCount{Value: "competition", Count: 2}) {
+		t.Errorf("Facet jenis salah: %+v", facets.Types)
+	}
+	if facets.Tags[0] != (model.FacetCount{Value: "robotik", Count: 2}) || facets.Tags[1].Value != "teknologi" {
+		t.Errorf("Facet tag salah: %+v", facets.Tags)
+	}
+	if len(facets.Statuses) != 2 {
+		t.Errorf("Facet status salah: %+v", facets.Statuses)
+	}
+
+	// filter type/tags (MongoIDs) diiriskan dengan hasil pencarian
+	hits, _, _ = service.RunAchievementSearch("robot", model.AchievementFilter{MongoIDs: []string{b.ID.Hex()}})
+	if len(hits) != 1 || hits[0].Reference.ID != "ref-b" {
+		t.Errorf("Hasil harus dibatasi filter MongoIDs, dapat %+v", hits)
+	}
+}
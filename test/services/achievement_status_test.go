@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+)
+
+func TestCanTransitionAchievement_Allowed(t *testing.T) {
+	cases := []struct{ from, to string }{
+		{model.StatusDraft, model.StatusSubmitted},
+		{model.StatusSubmitted, model.StatusVerified},
+		{model.StatusSubmitted, model.StatusRejected},
+		{model.StatusRejected, model.StatusDraft},
+		{model.StatusDraft, model.StatusDeleted},
+	}
+
+	for _, tc := range cases {
+		if !service.CanTransitionAchievement(tc.from, tc.to, false) {
+			t.Errorf("Transisi %s → %s harusnya boleh", tc.from, tc.to)
+		}
+	}
+}
+
+func TestCanTransitionAchievement_Rejected(t *testing.T) {
+	cases := []struct{ from, to string }{
+		{model.StatusDraft, model.StatusVerified},
+		{model.StatusRejected, model.StatusVerified},
+		{model.StatusVerified, model.StatusRejected},
+		{model.StatusVerified, model.StatusDraft},
+		{model.StatusSubmitted, model.StatusDraft},
+		{model.StatusSubmitted, model.StatusDeleted},
+		{model.StatusDeleted, model.StatusDraft},
+	}
+
+	for _, tc := range cases {
+		if service.CanTransitionAchievement(tc.from, tc.to, false) {
+			t.Errorf("Transisi %s → %s harusnya ditolak", tc.from, tc.to)
+		}
+	}
+}
+
+func TestCanTransitionAchievement_AdminDelete(t *testing.T) {
+	for _, from := range []string{model.StatusDraft, model.StatusSubmitted, model.StatusVerified, model.StatusRejected} {
+		if !service.CanTransitionAchievement(from, model.StatusDeleted, true) {
+			t.Errorf("Admin harusnya boleh menghapus status %s", from)
+		}
+	}
+
+	if service.CanTransitionAchievement(model.StatusDeleted, model.StatusDeleted, true) {
+		t.Error("Prestasi yang sudah dihapus tidak boleh dihapus lagi")
+	}
+}
@@ -20,6 +20,7 @@ func TestSubmitAchievement_Success(t *testing.T) {
 	}
 
 	// 2. Inject Mock
+	repo.MockGetAchievementTypeByCode(competitionType(), nil)
 	repo.MockCreateAchievement("mongo-id-999", nil)
 
 	// 3. Panggil Service
@@ -45,6 +46,8 @@ func TestSubmitAchievement_InvalidInput(t *testing.T) {
 		},
 	}
 
+	repo.MockGetAchievementTypeByCode(competitionType(), nil)
+
 	_, err := service.SubmitAchievement(input)
 
 	if err == nil {
@@ -52,6 +55,37 @@ func TestSubmitAchievement_InvalidInput(t *testing.T) {
 	}
 }
 
+func TestSubmitAchievement_RankFromJSON(t *testing.T) {
+	// Case: angka dari JSON selalu float64
+	input := model.AchievementMongo{
+		Title: "Juara 2 Lomba Coding",
+		Details: map[string]interface{}{
+			"competitionName": "Lomba Coding Nasional",
+			"rank":            float64(2),
+		},
+	}
+
+	repo.MockGetAchievementTypeByCode(competitionType(), nil)
+	repo.MockCreateAchievement("mongo-id-1000", nil)
+
+	if _, err := service.SubmitAchievement(input); err != nil {
+		t.Errorf("Rank float64 bulat harusnya valid, tapi error: %v", err)
+	}
+}
+
+// competitionType adalah skema minimal untuk test SubmitAchievement
+func competitionType() *model.AchievementType {
+	min := float64(1)
+	return &model.AchievementType{
+		Code:   "competition",
+		Active: true,
+		Fields: []model.AchievementField{
+			{Name: "competitionName", Type: model.FieldTypeString, Required: true},
+			{Name: "rank", Type: model.FieldTypeInteger, Required: true, Min: &min},
+		},
+	}
+}
+
 func TestGetAchievements_Found(t *testing.T) {
 	// 1. Siapkan Data Dummy (Isinya 2 prestasi)
 	mockData := []model.AchievementMongo{
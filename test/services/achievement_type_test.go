@@ -0,0 +1,109 @@
+package services
+
+import (
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+)
+
+func defaultType(code string) *model.AchievementType {
+	for _, t := range service.DefaultAchievementTypes() {
+		if t.Code == code {
+			return &t
+		}
+	}
+	return nil
+}
+
+func fieldErrorMap(errs []model.FieldError) map[string]string {
+	m := map[string]string{}
+	for _, e := range errs {
+		m[e.Field] = e.Message
+	}
+	return m
+}
+
+func TestValidateAchievementDetails_Valid(t *testing.T) {
+	details := map[string]interface{}{
+		"competitionName": "Gemastik",
+		"level":           "national",
+		"rank":            float64(1),
+		"teamSize":        float64(3),
+		"eventDate":       "2025-10-12",
+	}
+
+	out, errs := service.ValidateAchievementDetails(defaultType("competition"), details)
+	if len(errs) != 0 {
+		t.Fatalf("Harusnya valid, dapat error: %v", errs)
+	}
+	if rank, ok := out["rank"].(int64); !ok || rank != 1 {
+		t.Errorf("Rank harusnya dinormalisasi ke int64(1), dapat %#v", out["rank"])
+	}
+}
+
+func TestValidateAchievementDetails_FieldErrors(t *testing.T) {
+	details := map[string]interface{}{
+		"level":    "galaxy",
+		"rank":     1.5,
+		"teamSize": "tiga",
+		"unknown":  true,
+	}
+
+	_, errs := service.ValidateAchievementDetails(defaultType("competition"), details)
+	got := fieldErrorMap(errs)
+
+	for _, field := range []string{
+		"details.competitionName",
+		"details.level",
+		"details.rank",
+		"details.teamSize",
+		"details.unknown",
+	} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("Harusnya ada error untuk %s, dapat %v", field, errs)
+		}
+	}
+}
+
+func TestValidateAchievementDetails_AllowExtra(t *testing.T) {
+	details := map[string]interface{}{
+		"level":  "campus",
+		"custom": "bebas",
+	}
+
+	out, errs := service.ValidateAchievementDetails(defaultType("other"), details)
+	if len(errs) != 0 {
+		t.Fatalf("Jenis 'other' harusnya menerima field tambahan, dapat %v", errs)
+	}
+	if out["custom"] != "bebas" {
+		t.Errorf("Field tambahan harusnya dipertahankan")
+	}
+}
+
+func TestValidateAchievementTypeDefinition(t *testing.T) {
+	min, max := float64(10), float64(1)
+	req := &model.AchievementTypeRequest{
+		Code: "Bad Code",
+		Fields: []model.AchievementField{
+			{Name: "a", Type: "string"},
+			{Name: "a", Type: "uuid"},
+			{Name: "b", Type: "number", Min: &min, Max: &max},
+			{Name: "c", Type: "string", Pattern: "("},
+		},
+	}
+
+	got := fieldErrorMap(service.ValidateAchievementTypeDefinition(req))
+	for _, field := range []string{"code", "name", "fields[1].name", "fields[1].type", "fields[2].min", "fields[3].pattern"} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("Harusnya ada error untuk %s, dapat %v", field, got)
+		}
+	}
+
+	for _, def := range service.DefaultAchievementTypes() {
+		req := &model.AchievementTypeRequest{Code: def.Code, Name: def.Name, Fields: def.Fields}
+		if errs := service.ValidateAchievementTypeDefinition(req); len(errs) != 0 {
+			t.Errorf("Skema bawaan %s harusnya valid, dapat %v", def.Code, errs)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+// Mahasiswa s-budi dan s-ani dibimbing lect-lama, s-cici (nonaktif) juga;
+// lect-pensiun sudah nonaktif.
+func setupAdvisorMocks(calls *[]repo.AdvisorReassignment) {
+	retired := time.Now()
+	repo.MockGetStudentByID(map[string]*model.Student{
+		"s-budi": {ID: "s-budi", AdvisorID: "lect-lama"},
+		"s-ani":  {ID: "s-ani", AdvisorID: "lect-lama"},
+		"s-baru": {ID: "s-baru"},
+		"s-cici": {ID: "s-cici", AdvisorID: "lect-lama", DeactivatedAt: &retired},
+	})
+	repo.MockGetLecturerByID(map[string]*model.Lecturer{
+		"lect-lama":    {ID: "lect-lama"},
+		"lect-baru":    {ID: "lect-baru"},
+		"lect-pensiun": {ID: "lect-pensiun", DeactivatedAt: &retired},
+	})
+	repo.MockGetStudentsByAdvisor(map[string][]model.Student{
+		"lect-lama": {
+			{ID: "s-budi", AdvisorID: "lect-lama"},
+			{ID: "s-ani", AdvisorID: "lect-lama"},
+			{ID: "s-cici", AdvisorID: "lect-lama", DeactivatedAt: &retired},
+		},
+	})
+	repo.MockAdvisorReviewCounts(map[string]int{"s-budi": 2}, map[string]int{"lect-lama": 1})
+	repo.MockReassignAdvisors(calls, nil)
+}
+
+func callAdvisorHandler(method, path string, handler fiber.Handler, url string, body interface{}) (int, map[string]interface{}) {
+	app := fiber.New()
+	app.Add(method, path, func(c *fiber.Ctx) error {
+		c.Locals("user", &model.JWTClaims{UserID: "u-admin"})
+		return c.Next()
+	}, handler)
+
+	raw, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, url, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		return 0, nil
+	}
+
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+func setAdvisor(studentID string, body model.AdvisorAssignRequest) (int, map[string]interface{}) {
+	return callAdvisorHandler("PUT", "/students/:id/advisor", service.StudentSetAdvisor, "/students/"+studentID+"/advisor", body)
+}
+
+func reassignAdvisees(lecturerID string, body model.AdvisorReassignRequest) (int, map[string]interface{}) {
+	return callAdvisorHandler("POST", "/lecturers/:id/reassign-advisees", service.LecturerReassignAdvisees, "/lecturers/"+lecturerID+"/reassign-advisees", body)
+}
+
+func TestStudentSetAdvisor_Validation(t *testing.T) {
+	var calls []repo.AdvisorReassignment
+	setupAdvisorMocks(&calls)
+
+	cases := []struct {
+		name    string
+		student string
+		req     model.AdvisorAssignRequest
+		want    int
+	}{
+		{"mahasiswa tidak ada", "s-hilang", model.AdvisorAssignRequest{AdvisorID: "lect-baru"}, 404},
+		{"mahasiswa nonaktif", "s-cici", model.AdvisorAssignRequest{AdvisorID: "lect-baru"}, 400},
+		{"dosen tidak ada", "s-ani", model.AdvisorAssignRequest{AdvisorID: "lect-hilang"}, 400},
+		{"dosen nonaktif", "s-ani", model.AdvisorAssignRequest{AdvisorID: "lect-pensiun"}, 400},
+		{"dosen sama", "s-ani", model.AdvisorAssignRequest{AdvisorID: "lect-lama"}, 400},
+		{"pending_reviews salah", "s-ani", model.AdvisorAssignRequest{AdvisorID: "lect-baru", PendingReviews: "buang"}, 400},
+		{"tanggal masa depan", "s-ani", model.AdvisorAssignRequest{AdvisorID: "lect-baru", EffectiveDate: time.Now().AddDate(0, 0, 2).Format("2006-01-02")}, 400},
+		{"pengajuan tertunda tanpa pilihan", "s-budi", model.AdvisorAssignRequest{AdvisorID: "lect-baru"}, 409},
+	}
+	for _, tc := range cases {
+		if status, body := setAdvisor(tc.student, tc.req); status != tc.want {
+			t.Errorf("%s: harusnya %d, dapat %d (%v)", tc.name, tc.want, status, body)
+		}
+	}
+	if len(calls) != 0 {
+		t.Errorf("Request yang ditolak tidak boleh mengganti dosen wali, dapat %+v", calls)
+	}
+}
+
+func TestStudentSetAdvisor_RecordsAssignment(t *testing.T) {
+	var calls []repo.AdvisorReassignment
+	setupAdvisorMocks(&calls)
+
+	status, body := setAdvisor("s-budi", model.AdvisorAssignRequest{
+		AdvisorID:      "lect-baru",
+		PendingReviews: model.PendingReviewsKeep,
+		EffectiveDate:  "2025-08-01",
+		Reason:         " Dosen wali cuti ",
+	})
+	if status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if body["pending_reviews"] != float64(2) {
+		t.Errorf("Jumlah pengajuan tertunda harus dilaporkan, dapat %v", body["pending_reviews"])
+	}
+	if len(calls) != 1 || len(calls[0].Assignments) != 1 || calls[0].ReleaseReviewer != "" {
+		t.Fatalf("Harusnya satu penugasan tanpa release, dapat %+v", calls)
+	}
+
+	a := calls[0].Assignments[0]
+	if a.StudentID != "s-budi" || a.LecturerID != "lect-baru" || a.ID == "" ||
+		a.EffectiveFrom.Format("2006-01-02") != "2025-08-01" {
+		t.Errorf("Penugasan salah: %+v", a)
+	}
+	if a.PendingReviews == nil || *a.PendingReviews != model.PendingReviewsKeep {
+		t.Errorf("pending_reviews keep harus diteruskan, dapat %v", a.PendingReviews)
+	}
+	if a.Reason == nil || *a.Reason != "Dosen wali cuti" || a.AssignedBy == nil || *a.AssignedBy != "u-admin" {
+		t.Errorf("Alasan dan pelaku harus dicatat, dapat %+v", a)
+	}
+
+	// mahasiswa tanpa dosen wali: tidak ada pengajuan yang perlu dipilihkan
+	calls = nil
+	if status, body := setAdvisor("s-baru", model.AdvisorAssignRequest{AdvisorID: "lect-baru", PendingReviews: model.PendingReviewsTransfer}); status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if a := calls[0].Assignments[0]; a.PendingReviews != nil {
+		t.Errorf("Penugasan pertama tidak mencatat pending_reviews, dapat %v", *a.PendingReviews)
+	}
+}
+
+func TestStudentSetAdvisor_EffectiveDateBeforeCurrent(t *testing.T) {
+	var calls []repo.AdvisorReassignment
+	setupAdvisorMocks(&calls)
+	repo.MockReassignAdvisors(&calls, &pq.Error{Code: "23514"})
+
+	status, _ := setAdvisor("s-ani", model.AdvisorAssignRequest{AdvisorID: "lect-baru", EffectiveDate: "2000-01-01"})
+	if status != 400 {
+		t.Errorf("Pelanggaran CHECK effective_to harus 400, dapat %d", status)
+	}
+}
+
+func TestLecturerReassignAdvisees_All(t *testing.T) {
+	var calls []repo.AdvisorReassignment
+	setupAdvisorMocks(&calls)
+
+	status, body := reassignAdvisees("lect-lama", model.AdvisorReassignRequest{ToAdvisorID: "lect-baru"})
+	if status != 409 || body["pending_reviews"] != float64(3) {
+		t.Fatalf("Pengajuan tertunda + yang dipertahankan harus 409 dengan jumlah 3, dapat %d (%v)", status, body)
+	}
+
+	status, body = reassignAdvisees("lect-lama", model.AdvisorReassignRequest{
+		ToAdvisorID:    "lect-baru",
+		PendingReviews: model.PendingReviewsTransfer,
+	})
+	if status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("Harusnya satu transaksi, dapat %d", len(calls))
+	}
+
+	var moved []string
+	for _, a := range calls[0].Assignments {
+		if a.LecturerID != "lect-baru" || a.PendingReviews == nil || *a.PendingReviews != model.PendingReviewsTransfer {
+			t.Errorf("Penugasan salah: %+v", a)
+		}
+		moved = append(moved, a.StudentID)
+	}
+	if !slices.Equal(moved, []string{"s-budi", "s-ani"}) {
+		t.Errorf("Hanya mahasiswa aktif yang dipindahkan, dapat %v", moved)
+	}
+	if calls[0].ReleaseReviewer != "lect-lama" {
+		t.Errorf("transfer semua bimbingan harus melepas pengajuan yang dipertahankan, dapat %q", calls[0].ReleaseReviewer)
+	}
+}
+
+func TestLecturerReassignAdvisees_Selected(t *testing.T) {
+	var calls []repo.AdvisorReassignment
+	setupAdvisorMocks(&calls)
+
+	status, body := reassignAdvisees("lect-lama", model.AdvisorReassignRequest{
+		ToAdvisorID: "lect-baru",
+		StudentIDs:  []string{"s-ani", "s-baru"},
+	})
+	if status != 400 {
+		t.Fatalf("Mahasiswa bukan bimbingan harus 400, dapat %d", status)
+	}
+	if ids, _ := body["student_ids"].([]interface{}); len(ids) != 1 || ids[0] != "s-baru" {
+		t.Errorf("Harus menyebut mahasiswa yang bukan bimbingan, dapat %v", body["student_ids"])
+	}
+
+	// s-ani tidak punya pengajuan tertunda dan yang dipertahankan dosen lama tidak ikut diproses
+	status, body = reassignAdvisees("lect-lama", model.AdvisorReassignRequest{
+		ToAdvisorID:    "lect-baru",
+		StudentIDs:     []string{"s-ani", "s-ani"},
+		PendingReviews: model.PendingReviewsTransfer,
+	})
+	if status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if len(calls) != 1 || len(calls[0].Assignments) != 1 || calls[0].ReleaseReviewer != "" {
+		t.Errorf("Harusnya satu penugasan tanpa release, dapat %+v", calls)
+	}
+
+	if status, _ := reassignAdvisees("lect-lama", model.AdvisorReassignRequest{ToAdvisorID: "lect-lama"}); status != 400 {
+		t.Errorf("Dosen tujuan sama harus 400, dapat %d", status)
+	}
+	if status, _ := reassignAdvisees("lect-hilang", model.AdvisorReassignRequest{ToAdvisorID: "lect-baru"}); status != 404 {
+		t.Errorf("Dosen asal tidak ada harus 404, dapat %d", status)
+	}
+}
+
+func TestReviewBatch_KeptReviewer(t *testing.T) {
+	kept := "lect-lama"
+	repo.MockGetAchievementReferenceByID(map[string]*model.AchievementReference{
+		"kept":  {ID: "kept", StudentID: "s-budi", Status: model.StatusSubmitted, ReviewerID: &kept},
+		"baru":  {ID: "baru", StudentID: "s-budi", Status: model.StatusSubmitted},
+		"kept2": {ID: "kept2", StudentID: "s-budi", Status: model.StatusSubmitted, ReviewerID: &kept},
+	})
+	repo.MockIsStudentAdvisedBy(map[string]string{"s-budi": "lect-baru"})
+	repo.MockCreateAchievementComment(nil)
+
+	var rejected []string
+	repository.RejectAchievementReference = func(id, verifierUserID, note string) error {
+		rejected = append(rejected, id)
+		return nil
+	}
+
+	req := model.AchievementBatchReviewRequest{
+		Decision: "reject",
+		Note:     "Bukti kurang",
+		Items:    []model.AchievementBatchReviewItem{{ID: "kept"}, {ID: "baru"}},
+	}
+
+	results := service.ReviewBatch(&model.Lecturer{ID: "lect-lama"}, "u-lect-lama", req)
+	if results[0].Status != 200 || results[1].Status != 403 {
+		t.Errorf("Dosen wali lama hanya boleh meninjau pengajuan yang dipertahankan, dapat %+v", results)
+	}
+
+	req.Items = []model.AchievementBatchReviewItem{{ID: "kept2"}, {ID: "baru"}}
+	results = service.ReviewBatch(&model.Lecturer{ID: "lect-baru"}, "u-lect-baru", req)
+	if results[0].Status != 403 || results[1].Status != 200 {
+		t.Errorf("Dosen wali baru tidak boleh meninjau pengajuan yang dipertahankan, dapat %+v", results)
+	}
+
+	if !slices.Equal(rejected, []string{"kept", "baru"}) {
+		t.Errorf("Penolakan yang tercatat salah: %v", rejected)
+	}
+}
+
+func TestAchievementMemberVerify_KeptReviewer(t *testing.T) {
+	kept := "lect-lama"
+	repo.MockGetLecturersByUserID(map[string]*model.Lecturer{
+		"u-lect-lama":    {ID: "lect-lama"},
+		"u-lect-baru":    {ID: "lect-baru"},
+		"u-lect-anggota": {ID: "lect-anggota"},
+	})
+	repo.MockIsStudentAdvisedBy(map[string]string{"s-budi": "lect-baru", "s-ani": "lect-anggota"})
+
+	// status draft: lolos pemeriksaan peninjau berarti 400, ditolak berarti 403
+	ref := &model.AchievementReference{ID: "team", StudentID: "s-budi", Status: model.StatusDraft, ReviewerID: &kept}
+	verifyMember := func(userID, studentID string) int {
+		app := fiber.New()
+		app.Post("/:id/members/:studentId/verify", func(c *fiber.Ctx) error {
+			c.Locals("user", &model.JWTClaims{UserID: userID})
+			c.Locals("userId", userID)
+			c.Locals("achievement", ref)
+			return c.Next()
+		}, service.AchievementMemberVerify)
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/team/members/"+studentID+"/verify", nil))
+		if err != nil {
+			return 0
+		}
+		return resp.StatusCode
+	}
+
+	cases := []struct {
+		name, userID, studentID string
+		want                    int
+	}{
+		{"dosen wali lama, bagian pemilik", "u-lect-lama", "s-budi", 400},
+		{"dosen wali baru, bagian pemilik", "u-lect-baru", "s-budi", 403},
+		{"dosen wali anggota", "u-lect-anggota", "s-ani", 400},
+		{"dosen wali lama, bagian anggota", "u-lect-lama", "s-ani", 403},
+	}
+	for _, tc := range cases {
+		if got := verifyMember(tc.userID, tc.studentID); got != tc.want {
+			t.Errorf("%s: harusnya %d, dapat %d", tc.name, tc.want, got)
+		}
+	}
+}
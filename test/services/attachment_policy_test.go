@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/storage"
+	"prestasi_backend/test/repo"
+)
+
+var (
+	pdfHead = []byte("%PDF-1.7\n%âãÏÓ\n1 0 obj")
+	pngHead = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	exeHead = []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00")
+)
+
+func rejectionStatus(err error) int {
+	var r *service.AttachmentRejection
+	if errors.As(err, &r) {
+		return r.Status
+	}
+	return 0
+}
+
+func TestValidateAttachmentUpload(t *testing.T) {
+	limits := service.DefaultAttachmentLimits()
+	limits.MaxFileBytes, limits.MaxTotalBytes, limits.MaxCount = 100, 250, 2
+
+	one := []model.Attachment{{Size: 100}}
+	two := []model.Attachment{{Size: 10}, {Size: 10}}
+
+	cases := []struct {
+		name     string
+		existing []model.Attachment
+		file     string
+		size     int64
+		head     []byte
+		status   int
+	}{
+		{"pdf valid", nil, "sertifikat.pdf", 50, pdfHead, 0},
+		{"jpeg/png ekstensi huruf besar", nil, "FOTO.PNG", 50, pngHead, 0},
+		{"file terlalu besar", nil, "a.pdf", 101, pdfHead, http.StatusRequestEntityTooLarge},
+		{"jumlah attachment penuh", two, "a.pdf", 10, pdfHead, http.StatusRequestEntityTooLarge},
+		{"total masih dalam batas", one, "a.pdf", 100, pdfHead, 0},
+		{"total melebihi batas", append(one, model.Attachment{Size: 100}), "a.pdf", 10, pdfHead, http.StatusRequestEntityTooLarge},
+		{"executable", nil, "a.exe", 10, exeHead, http.StatusUnsupportedMediaType},
+		{"exe menyamar sebagai pdf", nil, "sertifikat.pdf", 10, exeHead, http.StatusUnsupportedMediaType},
+		{"png dengan ekstensi pdf", nil, "sertifikat.pdf", 10, pngHead, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range cases {
+		_, err := service.ValidateAttachmentUpload(limits, tc.existing, tc.file, tc.size, tc.head)
+		if got := rejectionStatus(err); got != tc.status {
+			t.Errorf("%s: status harusnya %d, dapat %d (%v)", tc.name, tc.status, got, err)
+		}
+	}
+}
+
+// fakeScanner menandai file yang mengandung string EICAR sebagai mencurigakan
+type fakeScanner struct{}
+
+func (fakeScanner) Scan(ctx context.Context, fileName string, r io.Reader) (service.ScanVerdict, error) {
+	data, _ := io.ReadAll(r)
+	if bytes.Contains(data, []byte("EICAR")) {
+		return service.ScanVerdict{Clean: false, Reason: "Eicar-Test-Signature"}, nil
+	}
+	return service.ScanVerdict{Clean: true}, nil
+}
+
+func TestProcessAttachmentUpload_QuarantinesSuspiciousFile(t *testing.T) {
+	backend, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service.Limits = service.DefaultAttachmentLimits()
+	service.Scanner = fakeScanner{}
+	defer func() { service.Scanner = service.NoopScanner{} }()
+
+	var added []model.Attachment
+	var quarantined []model.QuarantinedAttachment
+	repo.MockAddAchievementAttachment(&added, nil)
+	repo.MockInsertQuarantinedAttachment(&quarantined)
+
+	ref := &model.AchievementReference{ID: "ref-1", MongoAchievementID: "mongo-1"}
+	doc := &model.AchievementMongo{}
+
+	infected := append(append([]byte{}, pdfHead...), []byte("X5O!P%@AP EICAR-STANDARD-ANTIVIRUS-TEST-FILE")...)
+	_, err = service.ProcessAttachmentUpload(backend, ref, doc, "sertifikat.pdf", int64(len(infected)), bytes.NewReader(infected), "user-1")
+
+	if rejectionStatus(err) != http.StatusUnprocessableEntity {
+		t.Fatalf("File mencurigakan harus ditolak 422, dapat %v", err)
+	}
+	if len(added) != 0 {
+		t.Errorf("File mencurigakan tidak boleh masuk ke attachment prestasi")
+	}
+	if len(quarantined) != 1 || !strings.HasPrefix(quarantined[0].Attachment.Key, "quarantine/mongo-1/") ||
+		quarantined[0].Reason != "Eicar-Test-Signature" {
+		t.Fatalf("File harus dicatat di karantina, dapat %+v", quarantined)
+	}
+	if _, err := backend.Get(context.Background(), quarantined[0].Attachment.Key, 0, -1); err != nil {
+		t.Errorf("Isi file karantina harus tersimpan: %v", err)
+	}
+
+	clean := append(append([]byte{}, pdfHead...), []byte("isi sertifikat")...)
+	att, err := service.ProcessAttachmentUpload(backend, ref, doc, "sertifikat.pdf", int64(len(clean)), bytes.NewReader(clean), "user-1")
+	if err != nil {
+		t.Fatalf("File bersih harus diterima: %v", err)
+	}
+	if len(added) != 1 || added[0].ID != att.ID || att.MimeType != "application/pdf" {
+		t.Errorf("Metadata file bersih tidak tersimpan dengan benar: %+v", added)
+	}
+}
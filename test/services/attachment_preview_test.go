@@ -0,0 +1,110 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/storage"
+	"prestasi_backend/test/repo"
+)
+
+func samplePNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGeneratePreview_Image(t *testing.T) {
+	data, preview, err := service.GeneratePreview("image/png", bytes.NewReader(samplePNG(t, 800, 400)))
+	if err != nil {
+		t.Fatalf("Thumbnail gambar gagal: %v", err)
+	}
+	if preview.Width != 320 || preview.Height != 160 {
+		t.Errorf("Ukuran thumbnail harusnya 320x160, dapat %dx%d", preview.Width, preview.Height)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Thumbnail harus PNG valid: %v", err)
+	}
+	if img.Bounds().Dx() != 320 || preview.Size != int64(len(data)) {
+		t.Errorf("Metadata preview tidak sesuai isi")
+	}
+}
+
+func TestGeneratePreview_SmallImageKeepsSize(t *testing.T) {
+	_, preview, err := service.GeneratePreview("image/png", bytes.NewReader(samplePNG(t, 100, 50)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Width != 100 || preview.Height != 50 {
+		t.Errorf("Gambar kecil tidak boleh diperbesar, dapat %dx%d", preview.Width, preview.Height)
+	}
+}
+
+func TestGeneratePreview_PDF(t *testing.T) {
+	service.PDFRenderer = nil
+	if _, _, err := service.GeneratePreview("application/pdf", bytes.NewReader(pdfHead)); !errors.Is(err, service.ErrPreviewUnsupported) {
+		t.Errorf("Tanpa renderer, preview PDF harus ErrPreviewUnsupported, dapat %v", err)
+	}
+
+	// renderer palsu menggantikan pdftoppm
+	page := samplePNG(t, 1024, 1448)
+	service.PDFRenderer = func(ctx context.Context, pdf io.Reader) ([]byte, error) {
+		return page, nil
+	}
+	defer func() { service.PDFRenderer = nil }()
+
+	_, preview, err := service.GeneratePreview("application/pdf", bytes.NewReader(pdfHead))
+	if err != nil {
+		t.Fatalf("Preview PDF gagal: %v", err)
+	}
+	if preview.Height != 320 || preview.Width != 226 {
+		t.Errorf("Preview halaman A4 harusnya 226x320, dapat %dx%d", preview.Width, preview.Height)
+	}
+}
+
+func TestProcessAttachmentUpload_StoresPreviewNextToOriginal(t *testing.T) {
+	backend, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.Limits = service.DefaultAttachmentLimits()
+	service.Scanner = service.NoopScanner{}
+
+	var added []model.Attachment
+	repo.MockAddAchievementAttachment(&added, nil)
+
+	img := samplePNG(t, 640, 480)
+	ref := &model.AchievementReference{ID: "ref-1", MongoAchievementID: "mongo-1"}
+	att, err := service.ProcessAttachmentUpload(backend, ref, &model.AchievementMongo{}, "foto.png", int64(len(img)), bytes.NewReader(img), "user-1")
+	if err != nil {
+		t.Fatalf("Upload gagal: %v", err)
+	}
+
+	if att.Preview == nil || att.Preview.Key != att.Key+".preview.png" {
+		t.Fatalf("Preview harus disimpan di samping file asli, dapat %+v", att.Preview)
+	}
+	if _, err := backend.Get(context.Background(), att.Preview.Key, 0, -1); err != nil {
+		t.Errorf("File preview harus ada di storage: %v", err)
+	}
+	if added[0].Preview == nil {
+		t.Errorf("Metadata preview harus ikut tersimpan di MongoDB")
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/storage"
+	"prestasi_backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAttachment_DecodesLegacyFilename(t *testing.T) {
+	raw, err := bson.Marshal(bson.M{
+		"title":       "Prestasi lama",
+		"attachments": bson.A{"20251126_214540_sertifikat.pdf"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc model.AchievementMongo
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("Dokumen lama harus tetap bisa dibaca: %v", err)
+	}
+
+	if len(doc.Attachments) != 1 {
+		t.Fatalf("Harusnya 1 attachment, dapat %d", len(doc.Attachments))
+	}
+	att := doc.Attachments[0]
+	if !att.Legacy || att.Key != "20251126_214540_sertifikat.pdf" || att.FileName != att.Key {
+		t.Errorf("Attachment lama tidak terbaca dengan benar: %+v", att)
+	}
+}
+
+func TestAttachment_RoundTripMetadata(t *testing.T) {
+	in := model.AchievementMongo{Attachments: []model.Attachment{{
+		ID: "id-1", Key: "achievements/x/id-1", FileName: "a.pdf", Size: 10, MimeType: "application/pdf",
+	}}}
+
+	raw, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out model.AchievementMongo
+	if err := bson.Unmarshal(raw, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := out.Attachments[0]; got.Key != "achievements/x/id-1" || got.Legacy || got.Size != 10 {
+		t.Errorf("Metadata tidak utuh setelah round-trip: %+v", got)
+	}
+}
+
+func TestStoreAttachment_UsesUUIDKeyAndRecordsMetadata(t *testing.T) {
+	backend, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("x"), 100)...)
+	att, err := service.StoreAttachment(backend, "achievements/mongo-1", "../../etc/passwd.pdf", int64(len(content)), bytes.NewReader(content), "user-1")
+	if err != nil {
+		t.Fatalf("StoreAttachment gagal: %v", err)
+	}
+
+	if strings.Contains(att.Key, "..") || !strings.HasPrefix(att.Key, "achievements/mongo-1/") {
+		t.Errorf("Key harus dibuat server, dapat %q", att.Key)
+	}
+	if att.FileName != "passwd.pdf" {
+		t.Errorf("Nama asli harus dibersihkan dari path, dapat %q", att.FileName)
+	}
+	if att.MimeType != "application/pdf" {
+		t.Errorf("MIME harus dideteksi dari isi, dapat %q", att.MimeType)
+	}
+
+	sum := sha256.Sum256(content)
+	if att.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("sha256 salah")
+	}
+
+	rc, err := backend.Get(context.Background(), att.Key, 0, -1)
+	if err != nil {
+		t.Fatalf("File harus ada di storage: %v", err)
+	}
+	defer rc.Close()
+	stored, _ := io.ReadAll(rc)
+	if !bytes.Equal(stored, content) {
+		t.Errorf("Isi file di storage berbeda")
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	path := "/achievements/ref-1/attachments/att-1"
+	now := time.Now()
+
+	signed := utils.SignURL(path, now.Add(time.Minute))
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, sig := u.Query().Get("expires"), u.Query().Get("signature")
+
+	if !utils.VerifySignedURL(path, expires, sig, now) {
+		t.Errorf("URL yang baru dibuat harus valid")
+	}
+	if utils.VerifySignedURL(path, expires, sig, now.Add(2*time.Minute)) {
+		t.Errorf("URL kedaluwarsa harus ditolak")
+	}
+	if utils.VerifySignedURL("/achievements/ref-1/attachments/att-2", expires, sig, now) {
+		t.Errorf("Tanda tangan tidak boleh berlaku untuk attachment lain")
+	}
+	if utils.VerifySignedURL(path, expires+"0", sig, now) {
+		t.Errorf("Mengubah expires harus membatalkan tanda tangan")
+	}
+}
@@ -1,12 +1,22 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
 	"testing"
+	"time"
 	"prestasi_backend/app/model"
 	"prestasi_backend/app/service"
+	"prestasi_backend/middleware"
 	"prestasi_backend/test/repo"
+	"prestasi_backend/utils"
 	"golang.org/x/crypto/bcrypt"
 	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 func TestLogin_Success(t *testing.T) {
@@ -79,4 +89,123 @@ func TestLogin_UserNotFound(t *testing.T) {
 	if err == nil {
 		t.Error("Harusnya error User Not Found, tapi malah sukses")
 	}
-}
\ No newline at end of file
+}
+
+// User aktif dengan role Mahasiswa; refresh token "rt-awal" milik familyID.
+// ID dibuat unik per test karena status sesi di-cache di middleware.
+func setupRefreshMocks(userID, familyID string) *repo.TokenStore {
+	store := repo.NewTokenStore()
+	store.Add("rt-awal", userID, familyID)
+	repo.MockTokenStore(store)
+	repo.MockGetUserByID(map[string]*model.User{
+		userID: {ID: userID, Username: "mhs_refresh", RoleID: "r-mhs", IsActive: true},
+	})
+	repo.MockRBAC(&repo.RBACStore{Roles: map[string]*model.Role{"r-mhs": {ID: "r-mhs", Name: "Mahasiswa"}}})
+	repo.MockGetPermissions([]string{"achievement:create"}, nil)
+	repo.MockGetUserTokenState(map[string]repo.UserTokenState{userID: {Active: true}}, nil)
+	return store
+}
+
+func callAuth(app *fiber.App, method, url, token string, body interface{}) (int, map[string]interface{}) {
+	raw, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, url, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		return 0, nil
+	}
+
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+func authApp() *fiber.App {
+	app := fiber.New()
+	app.Post("/auth/refresh", service.AuthRefresh)
+	app.Post("/auth/logout", middleware.JWTRequired(), service.AuthLogout)
+	app.Get("/auth/ping", middleware.JWTRequired(), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+	return app
+}
+
+func refresh(app *fiber.App, plain string) (int, map[string]interface{}) {
+	return callAuth(app, "POST", "/auth/refresh", "", model.RefreshTokenRequest{RefreshToken: plain})
+}
+
+func TestAuthRefresh_RotatedTokenRefused(t *testing.T) {
+	store := setupRefreshMocks(uuid.NewString(), uuid.NewString())
+	app := authApp()
+
+	status, body := refresh(app, "rt-awal")
+	if status != 200 {
+		t.Fatalf("Refresh pertama harusnya 200, dapat %d (%v)", status, body)
+	}
+	next, _ := body["refresh_token"].(string)
+	if next == "" || next == "rt-awal" {
+		t.Fatalf("Refresh harus mengembalikan refresh token baru, dapat %q", next)
+	}
+	if len(store.FamilyRevokes) != 0 {
+		t.Errorf("Rotasi biasa tidak boleh mencabut family, dapat %v", store.FamilyRevokes)
+	}
+
+	if status, body := refresh(app, "rt-awal"); status != 401 {
+		t.Errorf("Token yang sudah dirotasi harusnya ditolak 401, dapat %d (%v)", status, body)
+	}
+}
+
+func TestAuthRefresh_ReuseRevokesFamily(t *testing.T) {
+	family := uuid.NewString()
+	store := setupRefreshMocks(uuid.NewString(), family)
+	app := authApp()
+
+	_, body := refresh(app, "rt-awal")
+	next, _ := body["refresh_token"].(string)
+
+	// penyerang memakai ulang token lama → seluruh family dicabut
+	refresh(app, "rt-awal")
+	if len(store.FamilyRevokes) != 1 || store.FamilyRevokes[0] != family {
+		t.Fatalf("Reuse harus mencabut family %s, dapat %v", family, store.FamilyRevokes)
+	}
+
+	// token pengganti milik pemakai sah juga ikut tidak berlaku
+	if status, body := refresh(app, next); status != 401 {
+		t.Errorf("Token terbaru di family yang dicabut harusnya 401, dapat %d (%v)", status, body)
+	}
+}
+
+func TestAuthLogout_RevokesAccessToken(t *testing.T) {
+	userID := uuid.NewString()
+	store := setupRefreshMocks(userID, uuid.NewString())
+	app := authApp()
+
+	// token tanpa sid, jadi hanya pencabutan jti yang bisa menolaknya
+	jti := uuid.NewString()
+	token, err := utils.GenerateToken(model.JWTClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(utils.AccessTokenTTL)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Gagal membuat token: %v", err)
+	}
+
+	if status, _ := callAuth(app, "GET", "/auth/ping", token, nil); status != 200 {
+		t.Fatalf("Sebelum logout harusnya 200, dapat %d", status)
+	}
+	if status, body := callAuth(app, "POST", "/auth/logout", token, nil); status != 200 {
+		t.Fatalf("Logout harusnya 200, dapat %d (%v)", status, body)
+	}
+	if !store.RevokedJTI[jti] {
+		t.Fatalf("Logout harus mencabut jti %s", jti)
+	}
+	if status, _ := callAuth(app, "GET", "/auth/ping", token, nil); status != 401 {
+		t.Errorf("Token yang sudah logout harusnya 401, dapat %d", status)
+	}
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+)
+
+func TestListRejectsUnknownSort(t *testing.T) {
+	q := model.ListQuery{Limit: 20, Sort: "password_hash", Order: "desc"}
+
+	if _, _, err := repository.ListUsers(model.UserFilter{}, q); !errors.Is(err, repository.ErrInvalidSort) {
+		t.Errorf("Sort tidak dikenal harusnya ErrInvalidSort, dapat %v", err)
+	}
+	if _, _, err := repository.ListAchievementReferences(model.AchievementFilter{}, q); !errors.Is(err, repository.ErrInvalidSort) {
+		t.Errorf("Sort tidak dikenal harusnya ErrInvalidSort, dapat %v", err)
+	}
+}
+
+func TestListRejectsInvalidCursor(t *testing.T) {
+	// cursor dibuat untuk sort lain
+	other := base64.RawURLEncoding.EncodeToString([]byte(`{"s":"username","o":"desc","v":"a","id":"1"}`))
+
+	for _, cursor := range []string{"%%%bukan-base64", other} {
+		q := model.ListQuery{Limit: 20, Cursor: cursor, Order: "desc"}
+		if _, _, err := repository.ListUsers(model.UserFilter{}, q); !errors.Is(err, repository.ErrInvalidCursor) {
+			t.Errorf("Cursor %q harusnya ErrInvalidCursor, dapat %v", cursor, err)
+		}
+	}
+}
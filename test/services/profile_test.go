@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+)
+
+func fieldSet(errs []model.FieldError) map[string]string {
+	out := map[string]string{}
+	for _, e := range errs {
+		out[e.Field] = e.Message
+	}
+	return out
+}
+
+func TestValidateStudentProfile_RequiredAndFormat(t *testing.T) {
+	p := model.StudentProfileRequest{StudentID: "  ", ProgramStudy: "Informatika", AcademicYear: "2021-2022"}
+
+	errs, err := service.ValidateStudentProfile(&p)
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+
+	got := fieldSet(errs)
+	if got["student_id"] != "wajib diisi" {
+		t.Errorf("NIM kosong harus ditolak, dapat %v", got)
+	}
+	if got["academic_year"] != "format harus YYYY/YYYY" {
+		t.Errorf("Format angkatan salah harus ditolak, dapat %v", got)
+	}
+	if _, ok := got["program_study"]; ok {
+		t.Errorf("Program studi valid tidak boleh error, dapat %v", got)
+	}
+}
+
+func TestValidateStudentProfile_Advisor(t *testing.T) {
+	retired := time.Now()
+	repo.MockGetLecturerByID(map[string]*model.Lecturer{
+		"lect-aktif":   {ID: "lect-aktif"},
+		"lect-pensiun": {ID: "lect-pensiun", DeactivatedAt: &retired},
+	})
+
+	cases := map[string]string{
+		"lect-aktif":   "",
+		"lect-pensiun": "dosen sudah nonaktif",
+		"lect-hilang":  "dosen tidak ditemukan",
+	}
+	for advisor, want := range cases {
+		p := model.StudentProfileRequest{
+			StudentID:    "2021001",
+			ProgramStudy: "Informatika",
+			AcademicYear: "2021/2022",
+			AdvisorID:    " " + advisor + " ",
+		}
+		errs, err := service.ValidateStudentProfile(&p)
+		if err != nil {
+			t.Fatalf("%s: tidak diharapkan error: %v", advisor, err)
+		}
+		if got := fieldSet(errs)["advisor_id"]; got != want {
+			t.Errorf("%s: harusnya %q, dapat %q", advisor, want, got)
+		}
+		if p.AdvisorID != advisor {
+			t.Errorf("advisor_id harus di-trim, dapat %q", p.AdvisorID)
+		}
+	}
+}
+
+func TestValidateLecturerProfile_Required(t *testing.T) {
+	p := model.LecturerProfileRequest{LecturerID: " 0012345 ", Department: ""}
+
+	got := fieldSet(service.ValidateLecturerProfile(&p))
+	if len(got) != 1 || got["department"] != "wajib diisi" {
+		t.Errorf("Hanya departemen yang harus error, dapat %v", got)
+	}
+	if p.LecturerID != "0012345" {
+		t.Errorf("NIDN harus di-trim, dapat %q", p.LecturerID)
+	}
+}
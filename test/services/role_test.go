@@ -0,0 +1,177 @@
+package services
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+)
+
+// Role admin (dipakai 1 user) punya user:manage; role kosong belum dipakai siapa pun.
+func setupRBACStore() *repo.RBACStore {
+	store := &repo.RBACStore{
+		Roles: map[string]*model.Role{
+			"r-admin":  {ID: "r-admin", Name: "Admin"},
+			"r-kosong": {ID: "r-kosong", Name: "Kosong"},
+		},
+		Permissions: map[string]*model.Permission{
+			"p-user":   {ID: "p-user", Name: "user:manage", Resource: "user", Action: "manage"},
+			"p-report": {ID: "p-report", Name: "report:read", Resource: "report", Action: "read"},
+		},
+		Grants: map[string][]string{"r-admin": {"p-user"}},
+		Users:  map[string]int{"r-admin": 1},
+	}
+	repo.MockRBAC(store)
+	return store
+}
+
+func TestRoleCreate_Validation(t *testing.T) {
+	store := setupRBACStore()
+
+	cases := []struct {
+		name string
+		req  model.RoleRequest
+		want int
+	}{
+		{"nama kosong", model.RoleRequest{Name: "  "}, 400},
+		{"nama sudah dipakai", model.RoleRequest{Name: "Admin"}, 409},
+		{"role baru", model.RoleRequest{Name: " Kaprodi ", Description: "Ketua program studi"}, 201},
+	}
+	for _, tc := range cases {
+		if status, body := callAdvisorHandler("POST", "/roles", service.RoleCreate, "/roles", tc.req); status != tc.want {
+			t.Errorf("%s: harusnya %d, dapat %d (%v)", tc.name, tc.want, status, body)
+		}
+	}
+
+	if len(store.Roles) != 3 {
+		t.Fatalf("Harusnya hanya satu role yang ditambahkan, dapat %d role", len(store.Roles))
+	}
+	if !slices.ContainsFunc(slices.Collect(maps.Values(store.Roles)), func(r *model.Role) bool { return r.Name == "Kaprodi" }) {
+		t.Error("Nama role harus di-trim sebelum disimpan")
+	}
+}
+
+func TestRoleUpdate_KeepsNameWhenBlank(t *testing.T) {
+	store := setupRBACStore()
+
+	if status, _ := callAdvisorHandler("PUT", "/roles/:id", service.RoleUpdate, "/roles/r-hilang", model.RoleRequest{Name: "X"}); status != 404 {
+		t.Errorf("Role yang tidak ada harusnya 404, dapat %d", status)
+	}
+	if status, _ := callAdvisorHandler("PUT", "/roles/:id", service.RoleUpdate, "/roles/r-kosong", model.RoleRequest{Name: "Admin"}); status != 409 {
+		t.Errorf("Nama yang bentrok harusnya 409, dapat %d", status)
+	}
+
+	status, body := callAdvisorHandler("PUT", "/roles/:id", service.RoleUpdate, "/roles/r-kosong", model.RoleRequest{Description: "Belum dipakai"})
+	if status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if r := store.Roles["r-kosong"]; r.Name != "Kosong" || r.Description != "Belum dipakai" {
+		t.Errorf("Nama kosong tidak boleh mengubah nama role, dapat %+v", r)
+	}
+}
+
+func TestRoleDelete_InUse(t *testing.T) {
+	store := setupRBACStore()
+
+	if status, _ := callAdvisorHandler("DELETE", "/roles/:id", service.RoleDelete, "/roles/r-admin", nil); status != 409 {
+		t.Errorf("Role yang masih dipakai harusnya 409, dapat %d", status)
+	}
+	if _, ok := store.Roles["r-admin"]; !ok {
+		t.Fatal("Role yang masih dipakai tidak boleh terhapus")
+	}
+	if status, _ := callAdvisorHandler("DELETE", "/roles/:id", service.RoleDelete, "/roles/r-hilang", nil); status != 404 {
+		t.Errorf("Role yang tidak ada harusnya 404, dapat %d", status)
+	}
+	if status, body := callAdvisorHandler("DELETE", "/roles/:id", service.RoleDelete, "/roles/r-kosong", nil); status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if _, ok := store.Roles["r-kosong"]; ok {
+		t.Error("Role yang tidak dipakai harusnya terhapus")
+	}
+}
+
+func TestPermissionCreate_DefaultName(t *testing.T) {
+	store := setupRBACStore()
+
+	if status, _ := callAdvisorHandler("POST", "/permissions", service.PermissionCreate, "/permissions", model.PermissionRequest{Resource: "achievement"}); status != 400 {
+		t.Errorf("Tanpa action harusnya 400, dapat %d", status)
+	}
+	if status, _ := callAdvisorHandler("POST", "/permissions", service.PermissionCreate, "/permissions", model.PermissionRequest{Resource: "user", Action: "manage"}); status != 409 {
+		t.Errorf("Nama default yang bentrok harusnya 409, dapat %d", status)
+	}
+
+	status, body := callAdvisorHandler("POST", "/permissions", service.PermissionCreate, "/permissions", model.PermissionRequest{Resource: " achievement ", Action: "export"})
+	if status != 201 {
+		t.Fatalf("Harusnya 201, dapat %d (%v)", status, body)
+	}
+	data, _ := body["data"].(map[string]interface{})
+	if data["name"] != "achievement:export" {
+		t.Errorf("Nama default harus resource:action, dapat %v", data["name"])
+	}
+	if len(store.Permissions) != 3 {
+		t.Errorf("Harusnya satu permission ditambahkan, dapat %d permission", len(store.Permissions))
+	}
+}
+
+func TestPermissionDelete_DetachesFromRoles(t *testing.T) {
+	store := setupRBACStore()
+
+	if status, _ := callAdvisorHandler("DELETE", "/permissions/:id", service.PermissionDelete, "/permissions/p-hilang", nil); status != 404 {
+		t.Errorf("Permission yang tidak ada harusnya 404, dapat %d", status)
+	}
+	if status, body := callAdvisorHandler("DELETE", "/permissions/:id", service.PermissionDelete, "/permissions/p-user", nil); status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if slices.Contains(store.Grants["r-admin"], "p-user") {
+		t.Error("Permission yang dihapus harus dilepas dari semua role")
+	}
+}
+
+func TestRolePermissionAdd_Validation(t *testing.T) {
+	store := setupRBACStore()
+
+	cases := []struct {
+		name string
+		role string
+		req  model.RolePermissionRequest
+		want int
+	}{
+		{"permission_id kosong", "r-kosong", model.RolePermissionRequest{}, 400},
+		{"role tidak ada", "r-hilang", model.RolePermissionRequest{PermissionID: "p-report"}, 404},
+		{"permission tidak ada", "r-kosong", model.RolePermissionRequest{PermissionID: "p-hilang"}, 404},
+		{"berhasil", "r-kosong", model.RolePermissionRequest{PermissionID: "p-report"}, 200},
+	}
+	for _, tc := range cases {
+		status, body := callAdvisorHandler("POST", "/roles/:id/permissions", service.RolePermissionAdd, "/roles/"+tc.role+"/permissions", tc.req)
+		if status != tc.want {
+			t.Errorf("%s: harusnya %d, dapat %d (%v)", tc.name, tc.want, status, body)
+		}
+	}
+	if got := store.Grants["r-kosong"]; !slices.Equal(got, []string{"p-report"}) {
+		t.Errorf("Harusnya hanya p-report yang terpasang, dapat %v", got)
+	}
+}
+
+func TestRolePermissionRemove_NotAttached(t *testing.T) {
+	store := setupRBACStore()
+
+	remove := func(roleID, permID string) (int, map[string]interface{}) {
+		return callAdvisorHandler("DELETE", "/roles/:id/permissions/:permissionId", service.RolePermissionRemove, "/roles/"+roleID+"/permissions/"+permID, nil)
+	}
+
+	if status, body := remove("r-admin", "p-report"); status != 404 {
+		t.Errorf("Permission yang tidak terpasang harusnya 404, dapat %d (%v)", status, body)
+	}
+	if status, body := remove("r-admin", "p-user"); status != 200 {
+		t.Fatalf("Harusnya 200, dapat %d (%v)", status, body)
+	}
+	if len(store.Grants["r-admin"]) != 0 {
+		t.Errorf("Permission harusnya sudah dilepas, dapat %v", store.Grants["r-admin"])
+	}
+	if status, _ := remove("r-admin", "p-user"); status != 404 {
+		t.Errorf("Melepas dua kali harusnya 404, dapat %d", status)
+	}
+}
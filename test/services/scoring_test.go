@@ -0,0 +1,125 @@
+package services
+
+import (
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+)
+
+func sampleRule() *model.ScoringRule {
+	return &model.ScoringRule{
+		Version: 3,
+		Rules: model.ScoringRuleSet{
+			BasePoints:             map[string]float64{"competition": 50, "default": 20},
+			LevelMultipliers:       map[string]float64{"international": 3, "national": 2},
+			DefaultLevelMultiplier: 1,
+			RankMultipliers: []model.RankMultiplier{
+				{MaxRank: 3, Multiplier: 1.25},
+				{MaxRank: 1, Multiplier: 2},
+			},
+			DefaultRankMultiplier: 1,
+			TeamSplit:             service.TeamSplitDivide,
+			MaxPoints:             250,
+		},
+	}
+}
+
+func TestComputeAchievementPoints(t *testing.T) {
+	cases := []struct {
+		name    string
+		doc     model.AchievementMongo
+		want    int
+		wantVer int
+	}{
+		{
+			name: "juara 1 nasional individu (rank dari JSON float64)",
+			doc: model.AchievementMongo{AchievementType: "competition", Details: map[string]interface{}{
+				"level": "national", "rank": float64(1),
+			}},
+			want: 200, // 50 × 2 × 2
+		},
+		{
+			name: "juara 3 nasional tim 5 orang",
+			doc: model.AchievementMongo{AchievementType: "competition", Details: map[string]interface{}{
+				"level": "national", "rank": int64(3), "teamSize": int64(5),
+			}},
+			want: 25, // 50 × 2 × 1.25 / 5
+		},
+		{
+			name: "peringkat di luar tabel memakai default",
+			doc: model.AchievementMongo{AchievementType: "competition", Details: map[string]interface{}{
+				"level": "national", "rank": 10,
+			}},
+			want: 100,
+		},
+		{
+			name: "jenis tanpa base memakai default dan level tak dikenal",
+			doc: model.AchievementMongo{AchievementType: "organization", Details: map[string]interface{}{
+				"level": "galaxy",
+			}},
+			want: 20,
+		},
+		{
+			name: "dibatasi max_points",
+			doc: model.AchievementMongo{AchievementType: "competition", Details: map[string]interface{}{
+				"level": "international", "rank": 1,
+			}},
+			want: 250, // 300 dipotong
+		},
+	}
+
+	for _, tc := range cases {
+		got := service.ComputeAchievementPoints(sampleRule(), &tc.doc)
+		if got.Points != tc.want {
+			t.Errorf("%s: poin harusnya %d, dapat %d (%+v)", tc.name, tc.want, got.Points, got)
+		}
+		if got.RuleVersion != 3 {
+			t.Errorf("%s: versi aturan harusnya tercatat 3, dapat %d", tc.name, got.RuleVersion)
+		}
+	}
+}
+
+func TestComputeAchievementPoints_IgnoresClientPoints(t *testing.T) {
+	doc := model.AchievementMongo{
+		AchievementType: "competition",
+		Points:          10000,
+		Details:         map[string]interface{}{"level": "national", "rank": 1},
+	}
+
+	if got := service.ComputeAchievementPoints(sampleRule(), &doc); got.Points != 200 {
+		t.Errorf("Poin dari klien harusnya diabaikan, dapat %d", got.Points)
+	}
+}
+
+func TestValidateScoringRuleSet(t *testing.T) {
+	set := model.ScoringRuleSet{
+		BasePoints:      map[string]float64{"competition": -1},
+		RankMultipliers: []model.RankMultiplier{{MaxRank: 0, Multiplier: 1}, {MaxRank: 2, Multiplier: -1}},
+		TeamSplit:       "half",
+		MinPoints:       10,
+		MaxPoints:       5,
+	}
+
+	got := fieldErrorMap(service.ValidateScoringRuleSet(&set))
+	for _, field := range []string{
+		"rules.base_points.competition",
+		"rules.rank_multipliers[0].max_rank",
+		"rules.rank_multipliers[1].multiplier",
+		"rules.team_split",
+		"rules.max_points",
+	} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("Harusnya ada error untuk %s, dapat %v", field, got)
+		}
+	}
+
+	valid := sampleRule().Rules
+	valid.TeamSplit = ""
+	if errs := service.ValidateScoringRuleSet(&valid); len(errs) != 0 {
+		t.Errorf("Aturan contoh harusnya valid, dapat %v", errs)
+	}
+	if valid.TeamSplit != service.TeamSplitNone {
+		t.Errorf("team_split kosong harusnya default ke none")
+	}
+}
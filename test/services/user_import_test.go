@@ -0,0 +1,324 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/repository"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+	"prestasi_backend/utils"
+)
+
+func TestParseUserImport_CSVAliases(t *testing.T) {
+	// ekspor Excel berlocale Indonesia: BOM, pemisah titik koma, kolom tambahan
+	data := "\xEF\xBB\xBFNIM;Nama;E-mail;Program Studi;Angkatan;NIDN Wali;Catatan\n" +
+		"2025001; Budi ;budi@univ.ac.id;Informatika;2025/2026;0011;-\n" +
+		";;;;;;\n" +
+		"2025002;Ani;ani@univ.ac.id;Informatika;2025/2026\n"
+
+	rows, err := service.ParseUserImport(model.ImportKindStudents, "mahasiswa.csv", []byte(data))
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Baris kosong harus dilewati, dapat %d baris", len(rows))
+	}
+
+	first := rows[0]
+	if first.Row != 2 || first.Number != "2025001" || first.FullName != "Budi" || first.AdvisorNIDN != "0011" {
+		t.Errorf("Baris pertama salah dibaca: %+v", first)
+	}
+	if rows[1].Row != 4 || rows[1].AdvisorNIDN != "" {
+		t.Errorf("Nomor baris harus sesuai file dan kolom pendek dianggap kosong: %+v", rows[1])
+	}
+}
+
+func TestParseUserImport_MissingColumn(t *testing.T) {
+	data := "nidn,nama,email\n0011,Dosen A,a@univ.ac.id\n"
+
+	_, err := service.ParseUserImport(model.ImportKindLecturers, "dosen.csv", []byte(data))
+	var rejected *service.UserImportRejection
+	if !errors.As(err, &rejected) || rejected.Status != 400 || !strings.Contains(rejected.Message, "department") {
+		t.Errorf("Kolom department yang hilang harus ditolak 400, dapat %v", err)
+	}
+
+	_, err = service.ParseUserImport(model.ImportKindLecturers, "dosen.pdf", []byte(data))
+	if !errors.As(err, &rejected) || rejected.Status != 400 {
+		t.Errorf("Ekstensi selain csv/xlsx harus ditolak 400, dapat %v", err)
+	}
+}
+
+// buildXLSX menyusun XLSX minimal: shared string, inline string, dan angka
+func buildXLSX(t *testing.T) []byte {
+	t.Helper()
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Dosen" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/data.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>NIDN</t></si><si><t>Nama</t></si><si><t>Email</t></si><si><t>Departemen</t></si>
+<si><r><t>Dr. </t></r><r><t>Sari</t></r></si></sst>`,
+		"xl/worksheets/data.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>3</v></c></row>
+<row r="3"><c r="A3"><v>1.9880101E+17</v></c><c r="B3" t="s"><v>4</v></c><c r="D3" t="inlineStr"><is><t>Informatika</t></is></c></row>
+</sheetData></worksheet>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseUserImport_XLSX(t *testing.T) {
+	rows, err := service.ParseUserImport(model.ImportKindLecturers, "dosen.XLSX", buildXLSX(t))
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("Harusnya 1 baris, dapat %d", len(rows))
+	}
+
+	r := rows[0]
+	if r.Row != 3 {
+		t.Errorf("Nomor baris harus mengikuti atribut r, dapat %d", r.Row)
+	}
+	if r.Number != "198801010000000000" {
+		t.Errorf("NIDN notasi ilmiah harus ditulis ulang sebagai digit, dapat %q", r.Number)
+	}
+	if r.FullName != "Dr. Sari" || r.Email != "" || r.Department != "Informatika" {
+		t.Errorf("Rich text, sel kosong, dan inline string salah dibaca: %+v", r)
+	}
+}
+
+const (
+	roleMahasiswaID = "role-mhs"
+	roleDosenID     = "role-dosen"
+	roleAdminID     = "role-admin"
+)
+
+// nama role sengaja bukan nama bawaan: role dipilih dari permission-nya
+var importRoles = []model.Role{
+	{ID: roleAdminID, Name: "Admin"},
+	{ID: roleMahasiswaID, Name: "Peserta Didik"},
+	{ID: roleDosenID, Name: "Pembimbing Akademik"},
+}
+
+func setupStudentImport(applied *[]model.UserImportOp) {
+	retired := time.Now()
+	repo.MockUserImportSources(
+		[]model.Student{
+			{ID: "s-budi", UserID: "u-budi", StudentID: "2021001", ProgramStudy: "Informatika", AcademicYear: "2021/2022", AdvisorID: "lect-a"},
+			{ID: "s-ani", UserID: "u-ani", StudentID: "2021002", ProgramStudy: "Informatika", AcademicYear: "2021/2022"},
+		},
+		[]model.Lecturer{
+			{ID: "lect-a", LecturerID: "0011"},
+			{ID: "lect-pensiun", LecturerID: "0099", DeactivatedAt: &retired},
+		},
+		[]model.User{
+			{ID: "u-budi", Username: "2021001", Email: "budi@univ.ac.id", FullName: "Budi", RoleID: roleMahasiswaID},
+			{ID: "u-ani", Username: "2021002", Email: "ani@univ.ac.id", FullName: "Ani", RoleID: roleMahasiswaID},
+			{ID: "u-dosen", Username: "dosen1", Email: "dosen@univ.ac.id", FullName: "Dosen", RoleID: roleDosenID},
+		},
+		importRoles,
+		map[string][]string{
+			roleMahasiswaID: {"achievement:create", "achievement:read:own"},
+			roleDosenID:     {"achievement:verify", "achievement:read:advisees"},
+			roleAdminID:     {"achievement:create:all", "user:manage"},
+		},
+	)
+	repo.MockApplyUserImport(applied, nil)
+}
+
+func TestRunUserImport_DryRunPlan(t *testing.T) {
+	var applied []model.UserImportOp
+	setupStudentImport(&applied)
+
+	data := "nim,nama,email,prodi,angkatan,nidn_wali\n" +
+		"2021001,Budi,BUDI@univ.ac.id,Informatika,2021/2022,0011\n" + // sama persis
+		"2021002,Ani,ani@univ.ac.id,Sistem Informasi,2021/2022,\n" + // pindah prodi
+		"2025001,Citra,citra@univ.ac.id,Informatika,2025/2026,0011\n" + // baru
+		"2025001,Dedi,dedi@univ.ac.id,Informatika,2025/2026,\n" + // NIM duplikat
+		"2025002,Eka,eka-univ.ac.id,Informatika,2025,\n" + // email & angkatan salah
+		"2025003,Fajar,fajar@univ.ac.id,Informatika,2025/2026,7777\n" + // dosen wali tidak ada
+		"2025004,Gita,gita@univ.ac.id,Informatika,2025/2026,0099\n" + // dosen wali pensiun
+		"2025005,Hana,dosen@univ.ac.id,Informatika,2025/2026,\n" // email milik user lain
+
+	report, err := service.RunUserImport(model.ImportKindStudents, "", "mhs.csv", []byte(data), true)
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+	if report.Committed || len(applied) != 0 {
+		t.Fatalf("Dry-run tidak boleh menyimpan apa pun")
+	}
+
+	wantAction := []string{"unchanged", "update", "create", "error", "error", "error", "error", "error"}
+	wantError := []map[string]string{nil, nil, nil,
+		{"student_id": "duplikat dengan baris 4"},
+		{"email": "format email tidak valid", "academic_year": "format harus YYYY/YYYY"},
+		{"advisor_nidn": "dosen wali tidak ditemukan"},
+		{"advisor_nidn": "dosen wali sudah nonaktif"},
+		{"email": "sudah dipakai user lain"},
+	}
+	for i, r := range report.Rows {
+		if r.Action != wantAction[i] {
+			t.Errorf("Baris %d: action harusnya %q, dapat %q (%v)", r.Row, wantAction[i], r.Action, r.Errors)
+		}
+		got := fieldSet(r.Errors)
+		for field, msg := range wantError[i] {
+			if got[field] != msg {
+				t.Errorf("Baris %d: error %s harusnya %q, dapat %v", r.Row, field, msg, got)
+			}
+		}
+		if r.InitialPassword != "" {
+			t.Errorf("Dry-run tidak boleh membuat password awal")
+		}
+	}
+
+	want := model.UserImportSummary{Total: 8, Created: 1, Updated: 1, Unchanged: 1, Failed: 5}
+	if report.Summary != want {
+		t.Errorf("Summary harusnya %+v, dapat %+v", want, report.Summary)
+	}
+}
+
+func TestRunUserImport_CommitRejectedWhenInvalid(t *testing.T) {
+	var applied []model.UserImportOp
+	setupStudentImport(&applied)
+
+	data := "nim,nama,email,prodi,angkatan\n" +
+		"2025001,Citra,citra@univ.ac.id,Informatika,2025/2026\n" +
+		"2025002,,eka@univ.ac.id,Informatika,2025/2026\n"
+
+	report, err := service.RunUserImport(model.ImportKindStudents, "", "mhs.csv", []byte(data), false)
+	var rejected *service.UserImportRejection
+	if !errors.As(err, &rejected) || rejected.Status != 422 {
+		t.Fatalf("Commit dengan baris tidak valid harus 422, dapat %v", err)
+	}
+	if report == nil || report.Committed || report.Summary.Failed != 1 {
+		t.Errorf("Report tetap dikembalikan tanpa commit, dapat %+v", report)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Tidak boleh ada baris yang disimpan, dapat %d", len(applied))
+	}
+}
+
+func TestRunUserImport_Commit(t *testing.T) {
+	var applied []model.UserImportOp
+	setupStudentImport(&applied)
+
+	data := "nim,nama,email,prodi,angkatan,nidn_wali\n" +
+		"2021001,Budi,budi@univ.ac.id,Informatika,2021/2022,\n" + // sama, tidak ditulis
+		"2021002,Ani Lestari,ani@univ.ac.id,Informatika,2021/2022,0011\n" +
+		"2025001,Citra,citra@univ.ac.id,Informatika,2025/2026,0011\n"
+
+	report, err := service.RunUserImport(model.ImportKindStudents, "", "mhs.csv", []byte(data), false)
+	if err != nil {
+		t.Fatalf("Tidak diharapkan error: %v", err)
+	}
+	if !report.Committed {
+		t.Fatalf("Report harus committed")
+	}
+	if len(applied) != 2 {
+		t.Fatalf("Hanya baris berubah yang ditulis, harusnya 2 op, dapat %d", len(applied))
+	}
+
+	update, create := applied[0], applied[1]
+	if update.NewUser || update.NewProfile || update.User.ID != "u-ani" || update.User.FullName != "Ani Lestari" ||
+		update.Student.ID != "s-ani" || update.Student.AdvisorID != "lect-a" {
+		t.Errorf("Op update salah: %+v / %+v", update.User, update.Student)
+	}
+	if update.User.PasswordHash != "" {
+		t.Errorf("Password user lama tidak boleh diubah")
+	}
+	if a := update.Advisor; a == nil || a.StudentID != "s-ani" || a.LecturerID != "lect-a" ||
+		a.PendingReviews == nil || *a.PendingReviews != model.PendingReviewsKeep {
+		t.Errorf("Penggantian dosen wali harus dicatat dengan pending_reviews keep, dapat %+v", a)
+	}
+	if create.Advisor != nil {
+		t.Errorf("Profil baru tidak memakai op penggantian dosen wali")
+	}
+
+	if !create.NewUser || !create.NewProfile || create.User.Username != "2025001" || create.User.RoleID != roleMahasiswaID ||
+		!create.User.IsActive || create.Student.UserID != create.User.ID || create.Student.AdvisorID != "lect-a" {
+		t.Errorf("Op create salah: %+v / %+v", create.User, create.Student)
+	}
+
+	created := report.Rows[2]
+	if created.Username != "2025001" || len(created.InitialPassword) != 12 {
+		t.Fatalf("Password awal harus dilaporkan untuk user baru, dapat %+v", created)
+	}
+	if !utils.CheckPassword(created.InitialPassword, create.User.PasswordHash) {
+		t.Errorf("Hash yang disimpan harus cocok dengan password awal")
+	}
+	if report.Rows[1].InitialPassword != "" {
+		t.Errorf("User lama tidak boleh mendapat password awal")
+	}
+
+	var csvOut bytes.Buffer
+	if err := service.WriteUserImportCSV(&csvOut, report); err != nil {
+		t.Fatalf("Gagal menulis report CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "row,action,nim,") {
+		t.Fatalf("Report CSV harus berisi header + 3 baris, dapat %q", csvOut.String())
+	}
+	if !strings.Contains(lines[3], ",create,2025001,") || !strings.Contains(lines[3], created.InitialPassword) {
+		t.Errorf("Baris create di CSV harus memuat password awal, dapat %q", lines[3])
+	}
+}
+
+func TestRunUserImport_ResolveRole(t *testing.T) {
+	var applied []model.UserImportOp
+	setupStudentImport(&applied)
+	data := "nim,nama,email,prodi,angkatan\n2025001,Citra,citra@univ.ac.id,Informatika,2025/2026\n"
+
+	report, err := service.RunUserImport(model.ImportKindStudents, "", "mhs.csv", []byte(data), true)
+	if err != nil || report.RoleID != roleMahasiswaID || report.RoleName != "Peserta Didik" {
+		t.Fatalf("Role harus dipilih dari permission, dapat %+v / %v", report, err)
+	}
+
+	cases := map[string]int{
+		roleDosenID: 400, // tidak boleh memiliki profil mahasiswa
+		roleAdminID: 400, // create:all bukan pelapor prestasi sendiri
+		"role-lain": 400,
+	}
+	for roleID, want := range cases {
+		_, err := service.RunUserImport(model.ImportKindStudents, roleID, "mhs.csv", []byte(data), true)
+		var rejected *service.UserImportRejection
+		if !errors.As(err, &rejected) || rejected.Status != want {
+			t.Errorf("%s: harusnya %d, dapat %v", roleID, want, err)
+		}
+	}
+
+	// dua role memenuhi syarat: role_id wajib dipilih
+	repository.GetAllRoles = func() ([]model.Role, error) {
+		return append(importRoles, model.Role{ID: roleMahasiswaID + "-2", Name: "Mahasiswa Pertukaran"}), nil
+	}
+	repository.GetPermissionsByRoleID = func(roleID string) ([]string, error) {
+		if strings.HasPrefix(roleID, roleMahasiswaID) {
+			return []string{"achievement:create"}, nil
+		}
+		return nil, nil
+	}
+	_, err = service.RunUserImport(model.ImportKindStudents, "", "mhs.csv", []byte(data), true)
+	var rejected *service.UserImportRejection
+	if !errors.As(err, &rejected) || rejected.Status != 400 {
+		t.Errorf("Role ambigu harus 400, dapat %v", err)
+	}
+}
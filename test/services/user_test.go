@@ -0,0 +1,70 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"prestasi_backend/app/model"
+	"prestasi_backend/app/service"
+	"prestasi_backend/test/repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// updateUser memanggil UserUpdate tanpa batas waktu app.Test, karena hashing
+// password (bcrypt cost 14) bisa lebih lama dari timeout default 1 detik.
+func updateUser(id string, body model.UserUpdateRequest) (int, map[string]interface{}) {
+	app := fiber.New()
+	app.Put("/users/:id", service.UserUpdate)
+
+	raw, _ := json.Marshal(body)
+	req := httptest.NewRequest("PUT", "/users/"+id, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		return 0, nil
+	}
+
+	var out map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+func TestUserUpdate_BumpsTokenVersion(t *testing.T) {
+	cases := []struct {
+		name   string
+		active bool
+		req    model.UserUpdateRequest
+		bump   bool
+	}{
+		{"nonaktifkan akun", true, model.UserUpdateRequest{Username: "budi", IsActive: false}, true},
+		{"ganti password", true, model.UserUpdateRequest{Username: "budi", Password: "BaruSekali123!", IsActive: true}, true},
+		{"ganti nama saja", true, model.UserUpdateRequest{Username: "budi", FullName: "Budi S", IsActive: true}, false},
+		{"aktifkan kembali", false, model.UserUpdateRequest{Username: "budi", IsActive: true}, false},
+		{"tetap nonaktif", false, model.UserUpdateRequest{Username: "budi", IsActive: false}, false},
+	}
+	for _, tc := range cases {
+		var bumps, revokes []string
+		repo.MockUserUpdate(&bumps, &revokes)
+		repo.MockGetUserByID(map[string]*model.User{
+			"u-budi": {ID: "u-budi", Username: "budi", IsActive: tc.active},
+		})
+
+		status, body := updateUser("u-budi", tc.req)
+		if status != 200 {
+			t.Errorf("%s: harusnya 200, dapat %d (%v)", tc.name, status, body)
+			continue
+		}
+
+		var want []string
+		if tc.bump {
+			want = []string{"u-budi"}
+		}
+		if !slices.Equal(bumps, want) || !slices.Equal(revokes, want) {
+			t.Errorf("%s: sesi dicabut harusnya %v, dapat bumps=%v revokes=%v", tc.name, want, bumps, revokes)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"prestasi_backend/storage"
+)
+
+// roundTrip menguji Put/Get/range/Delete yang sama untuk setiap backend
+func roundTrip(t *testing.T, b storage.Backend, key string) {
+	ctx := context.Background()
+	body := "0123456789abcdef"
+
+	if err := b.Put(ctx, key, strings.NewReader(body), int64(len(body)), "text/plain"); err != nil {
+		t.Fatalf("Put gagal: %v", err)
+	}
+
+	read := func(offset, length int64) string {
+		rc, err := b.Get(ctx, key, offset, length)
+		if err != nil {
+			t.Fatalf("Get(%d,%d) gagal: %v", offset, length, err)
+		}
+		defer rc.Close()
+		data, _ := io.ReadAll(rc)
+		return string(data)
+	}
+
+	if got := read(0, -1); got != body {
+		t.Errorf("Isi penuh salah: %q", got)
+	}
+	if got := read(10, 4); got != "abcd" {
+		t.Errorf("Range 10+4 harusnya abcd, dapat %q", got)
+	}
+	if got := read(12, -1); got != "cdef" {
+		t.Errorf("Range 12- harusnya cdef, dapat %q", got)
+	}
+
+	if err := b.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete gagal: %v", err)
+	}
+	if err := b.Delete(ctx, key); err != nil {
+		t.Errorf("Delete key yang sudah tidak ada tidak boleh error: %v", err)
+	}
+	if _, err := b.Get(ctx, key, 0, -1); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Get setelah Delete harusnya ErrNotFound, dapat %v", err)
+	}
+}
+
+func TestLocal_RoundTrip(t *testing.T) {
+	b, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, b, "achievements/abc/0b9f6c1e-uuid")
+}
+
+func TestLocal_RejectsTraversal(t *testing.T) {
+	b, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../etc/passwd", "/etc/passwd", "a/../../b", "a//b", "a\\..\\b", ""} {
+		err := b.Put(context.Background(), key, strings.NewReader("x"), 1, "")
+		if !errors.Is(err, storage.ErrInvalidKey) {
+			t.Errorf("Key %q harusnya ditolak, dapat %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"prestasi_backend/storage"
+)
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		header         string
+		offset, length int64
+		partial        bool
+	}{
+		{"", 0, 100, false},
+		{"bytes=0-9", 0, 10, true},
+		{"bytes=90-", 90, 10, true},
+		{"bytes=-20", 80, 20, true},
+		{"bytes=50-500", 50, 50, true},   // akhir dipotong ke ukuran file
+		{"bytes=0-1,5-6", 0, 100, false}, // multi-range → kirim utuh
+	}
+
+	for _, tc := range cases {
+		off, n, partial, err := storage.ParseRange(tc.header, 100)
+		if err != nil {
+			t.Errorf("%q: tidak diharapkan error: %v", tc.header, err)
+			continue
+		}
+		if off != tc.offset || n != tc.length || partial != tc.partial {
+			t.Errorf("%q: dapat (%d,%d,%v), mau (%d,%d,%v)", tc.header, off, n, partial, tc.offset, tc.length, tc.partial)
+		}
+	}
+
+	for _, bad := range []string{"bytes=100-", "bytes=20-10", "bytes=-0", "bytes=x-1"} {
+		if _, _, _, err := storage.ParseRange(bad, 100); !errors.Is(err, storage.ErrRangeNotSatisfiable) {
+			t.Errorf("%q harusnya 416, dapat %v", bad, err)
+		}
+	}
+}
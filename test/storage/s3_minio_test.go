@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"prestasi_backend/storage"
+)
+
+// Jalankan dengan MinIO lokal, mis.:
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	S3_TEST_ENDPOINT=http://localhost:9000 S3_TEST_BUCKET=test \
+//	S3_TEST_ACCESS_KEY=minioadmin S3_TEST_SECRET_KEY=minioadmin go test ./test/storage/
+func TestS3_RoundTripMinIO(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT tidak di-set, lewati test MinIO")
+	}
+
+	b, err := storage.NewS3(storage.S3Config{
+		Endpoint:  endpoint,
+		Region:    os.Getenv("S3_TEST_REGION"),
+		Bucket:    os.Getenv("S3_TEST_BUCKET"),
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, b, "test/roundtrip-object")
+}
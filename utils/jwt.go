@@ -1,15 +1,26 @@
 package utils
 
 import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
     "errors"
     "os"
     "prestasi_backend/app/model"
+    "time"
 
     "github.com/golang-jwt/jwt/v5"
 )
 
 var jwtKey = []byte(os.Getenv("JWT_SECRET"))
 
+// Umur token: access token pendek, refresh token opaque lebih panjang
+const (
+    AccessTokenTTL  = 15 * time.Minute
+    RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
 // Membuat Token
 func GenerateToken(claim model.JWTClaims) (string, error) {
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
@@ -39,4 +50,21 @@ func ParseToken(tokenString string) (*model.JWTClaims, error) {
     }
 
     return claims, nil
-}
\ No newline at end of file
+}
+
+// Membuat refresh token opaque (acak) beserta hash yang disimpan di DB
+func GenerateRefreshToken() (plain string, hash string, err error) {
+    buf := make([]byte, 32)
+    if _, err := rand.Read(buf); err != nil {
+        return "", "", err
+    }
+
+    plain = base64.RawURLEncoding.EncodeToString(buf)
+    return plain, HashToken(plain), nil
+}
+
+// Hash SHA-256 (hex) dari token opaque
+func HashToken(plain string) string {
+    sum := sha256.Sum256([]byte(plain))
+    return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SignedURLTTL adalah umur default URL unduhan bertanda tangan
+const SignedURLTTL = 10 * time.Minute
+
+// signedURLKey memakai ATTACHMENT_URL_SECRET, atau JWT_SECRET bila tidak di-set
+func signedURLKey() []byte {
+	if k := os.Getenv("ATTACHMENT_URL_SECRET"); k != "" {
+		return []byte(k)
+	}
+	return jwtKey
+}
+
+func signPath(path string, expires int64) string {
+	mac := hmac.New(sha256.New, signedURLKey())
+	mac.Write([]byte(path + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignURL menandatangani path sampai waktu expires dan mengembalikan
+// path lengkap dengan query ?expires=&signature=
+func SignURL(path string, expires time.Time) string {
+	exp := expires.Unix()
+	return path + "?expires=" + strconv.FormatInt(exp, 10) + "&signature=" + signPath(path, exp)
+}
+
+// VerifySignedURL memeriksa tanda tangan dan masa berlaku URL dari SignURL
+func VerifySignedURL(path, expires, signature string, now time.Time) bool {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > exp {
+		return false
+	}
+	return hmac.Equal([]byte(signPath(path, exp)), []byte(signature))
+}
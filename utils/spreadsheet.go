@@ -0,0 +1,274 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MaxSpreadsheetRows membatasi jumlah baris yang dibaca dari satu file
+const MaxSpreadsheetRows = 100000
+
+// batas ukuran satu entry di dalam file XLSX setelah didekompresi
+const maxXLSXEntryBytes = 64 << 20
+
+var ErrUnsupportedSpreadsheet = errors.New("format file harus .csv atau .xlsx")
+
+// ReadSpreadsheet membaca file CSV atau XLSX (sheet pertama) menjadi baris
+// sel teks. Indeks slice sama dengan nomor baris di file dikurangi satu;
+// baris kosong tetap ada agar nomor baris bisa dilaporkan apa adanya.
+func ReadSpreadsheet(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	}
+	return nil, ErrUnsupportedSpreadsheet
+}
+
+// ==================================================================
+// CSV
+// ==================================================================
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+
+	// Excel berlocale Indonesia mengekspor CSV dengan pemisah titik koma
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rows [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV tidak valid: %w", err)
+		}
+		if len(rows) >= MaxSpreadsheetRows {
+			return nil, fmt.Errorf("file melebihi %d baris", MaxSpreadsheetRows)
+		}
+		rows = append(rows, record)
+	}
+}
+
+// ==================================================================
+// XLSX
+// ==================================================================
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText adalah teks sel biasa (<t>) atau rich text (<r><t>)
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Num   int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("XLSX tidak valid: bukan arsip zip")
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeZipXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, errors.New("XLSX tidak valid: sheet tidak ditemukan")
+	}
+	var sheet xlsxWorksheet
+	if err := decodeZipXML(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		num := row.Num
+		if num <= len(rows) {
+			num = len(rows) + 1
+		}
+		if num > MaxSpreadsheetRows {
+			return nil, fmt.Errorf("file melebihi %d baris", MaxSpreadsheetRows)
+		}
+		for len(rows) < num-1 {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for _, c := range row.Cells {
+			col := len(cells)
+			if c.Ref != "" {
+				if idx, ok := xlsxColumnIndex(c.Ref); ok && idx >= col {
+					col = idx
+				}
+			}
+			for len(cells) < col {
+				cells = append(cells, "")
+			}
+
+			var v string
+			switch c.Type {
+			case "s":
+				i, err := strconv.Atoi(strings.TrimSpace(c.Value))
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, fmt.Errorf("XLSX tidak valid: shared string %q di sel %s", c.Value, c.Ref)
+				}
+				v = shared.Items[i].String()
+			case "inlineStr":
+				v = c.Inline.String()
+			case "b":
+				v = map[string]string{"1": "TRUE", "0": "FALSE"}[c.Value]
+			case "", "n":
+				v = xlsxNumber(c.Value)
+			default: // str, e
+				v = c.Value
+			}
+			cells = append(cells, v)
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// firstSheetPath mencari file XML sheet pertama lewat workbook.xml dan relasinya
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("XLSX tidak valid: workbook.xml tidak ditemukan")
+	}
+	var wb xlsxWorkbook
+	if err := decodeZipXML(wbFile, &wb); err != nil {
+		return "", err
+	}
+	relFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(wb.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodeZipXML(relFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("XLSX tidak valid: %w", err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXLSXEntryBytes)).Decode(v); err != nil {
+		return fmt.Errorf("XLSX tidak valid (%s): %w", f.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex mengubah referensi sel ("C12") menjadi indeks kolom (2)
+func xlsxColumnIndex(ref string) (int, bool) {
+	idx := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		idx = idx*26 + int(r-'A'+1)
+		n++
+	}
+	return idx - 1, n > 0
+}
+
+// xlsxNumber menulis ulang angka notasi ilmiah (NIM panjang) menjadi digit biasa
+func xlsxNumber(v string) string {
+	if !strings.ContainsAny(v, "eE") {
+		return v
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}